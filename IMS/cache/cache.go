@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+type Cache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+}
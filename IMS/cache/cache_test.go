@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		capacity int
+		run      func(c *LRUCache, advance func(time.Duration))
+		want     map[string]string
+	}{
+		{
+			name:     "evicts least recently used",
+			capacity: 2,
+			run: func(c *LRUCache, _ func(time.Duration)) {
+				c.Set(ctx, "a", "1", 0)
+				c.Set(ctx, "b", "2", 0)
+				c.Get(ctx, "a")
+				c.Set(ctx, "c", "3", 0)
+			},
+			want: map[string]string{"a": "1", "b": "", "c": "3"},
+		},
+		{
+			name:     "overwrite refreshes recency",
+			capacity: 2,
+			run: func(c *LRUCache, _ func(time.Duration)) {
+				c.Set(ctx, "a", "1", 0)
+				c.Set(ctx, "b", "2", 0)
+				c.Set(ctx, "a", "10", 0)
+				c.Set(ctx, "c", "3", 0)
+			},
+			want: map[string]string{"a": "10", "b": "", "c": "3"},
+		},
+		{
+			name:     "zero capacity is unbounded",
+			capacity: 0,
+			run: func(c *LRUCache, _ func(time.Duration)) {
+				c.Set(ctx, "a", "1", 0)
+				c.Set(ctx, "b", "2", 0)
+				c.Set(ctx, "c", "3", 0)
+			},
+			want: map[string]string{"a": "1", "b": "2", "c": "3"},
+		},
+		{
+			name:     "expires after ttl",
+			capacity: 10,
+			run: func(c *LRUCache, advance func(time.Duration)) {
+				c.Set(ctx, "short", "1", time.Second)
+				c.Set(ctx, "long", "2", time.Minute)
+				c.Set(ctx, "forever", "3", 0)
+				advance(2 * time.Second)
+			},
+			want: map[string]string{"short": "", "long": "2", "forever": "3"},
+		},
+		{
+			name:     "del removes keys",
+			capacity: 10,
+			run: func(c *LRUCache, _ func(time.Duration)) {
+				c.Set(ctx, "a", "1", 0)
+				c.Set(ctx, "b", "2", 0)
+				c.Del(ctx, "a", "missing")
+			},
+			want: map[string]string{"a": "", "b": "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			c := NewLRUCache(tt.capacity)
+			c.now = func() time.Time { return now }
+			tt.run(c, func(d time.Duration) { now = now.Add(d) })
+
+			for key, want := range tt.want {
+				got, err := c.Get(ctx, key)
+				if want == "" {
+					if !errors.Is(err, ErrCacheMiss) {
+						t.Fatalf("Get(%q) = %q, %v; want a miss", key, got, err)
+					}
+					continue
+				}
+				if err != nil || got != want {
+					t.Fatalf("Get(%q) = %q, %v; want %q", key, got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTieredCache(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		local     map[string]string
+		remote    map[string]string
+		want      string
+		wantLocal bool
+	}{
+		{"local hit", map[string]string{"k": "l1"}, map[string]string{"k": "l2"}, "l1", true},
+		{"remote hit fills local", nil, map[string]string{"k": "l2"}, "l2", true},
+		{"miss in both", nil, nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, remote := NewLRUCache(0), NewLRUCache(0)
+			for k, v := range tt.local {
+				local.Set(ctx, k, v, 0)
+			}
+			for k, v := range tt.remote {
+				remote.Set(ctx, k, v, 0)
+			}
+			c := NewTieredCache(local, remote, time.Minute)
+
+			got, err := c.Get(ctx, "k")
+			if tt.want == "" {
+				if !errors.Is(err, ErrCacheMiss) {
+					t.Fatalf("Get = %q, %v; want a miss", got, err)
+				}
+			} else if err != nil || got != tt.want {
+				t.Fatalf("Get = %q, %v; want %q", got, err, tt.want)
+			}
+			if _, err := local.Get(ctx, "k"); (err == nil) != tt.wantLocal {
+				t.Fatalf("local holds key = %v, want %v", err == nil, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestTieredCacheSetAndDel(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	local, remote := NewLRUCache(0), NewLRUCache(0)
+	local.now = func() time.Time { return now }
+	remote.now = local.now
+	c := NewTieredCache(local, remote, time.Minute)
+
+	c.Set(ctx, "short", "1", time.Second)
+	c.Set(ctx, "long", "2", time.Hour)
+	now = now.Add(2 * time.Minute)
+
+	if _, err := local.Get(ctx, "long"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatal("local entry outlived LocalTTL")
+	}
+	if got, err := c.Get(ctx, "long"); err != nil || got != "2" {
+		t.Fatalf("Get(long) = %q, %v; want it refilled from remote", got, err)
+	}
+	if _, err := c.Get(ctx, "short"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatal("short entry outlived its ttl")
+	}
+
+	c.Del(ctx, "long")
+	for name, tier := range map[string]*LRUCache{"local": local, "remote": remote} {
+		if _, err := tier.Get(ctx, "long"); !errors.Is(err, ErrCacheMiss) {
+			t.Fatalf("%s tier still holds a deleted key", name)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TieredCache serves reads from a process-local L1 before falling back to a
+// shared L2. Del only clears the L1 of the instance that calls it, so other
+// instances keep serving their copy for up to LocalTTL. For hubs and SKUs that
+// copy carries the version used as the ETag, and an update sent with it is
+// refused with VERSION_MISMATCH until the entry expires; keep LocalTTL short.
+type TieredCache struct {
+	Local    Cache
+	Remote   Cache
+	LocalTTL time.Duration
+}
+
+func NewTieredCache(local Cache, remote Cache, localTTL time.Duration) *TieredCache {
+	return &TieredCache{Local: local, Remote: remote, LocalTTL: localTTL}
+}
+
+func (c *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	if val, err := c.Local.Get(ctx, key); err == nil {
+		return val, nil
+	}
+
+	val, err := c.Remote.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	_ = c.Local.Set(ctx, key, val, c.LocalTTL)
+	return val, nil
+}
+
+func (c *TieredCache) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	localTTL := c.LocalTTL
+	if ttl > 0 && ttl < localTTL {
+		localTTL = ttl
+	}
+	_ = c.Local.Set(ctx, key, value, localTTL)
+	return c.Remote.Set(ctx, key, value, ttl)
+}
+
+func (c *TieredCache) Del(ctx context.Context, keys ...string) error {
+	return errors.Join(c.Local.Del(ctx, keys...), c.Remote.Del(ctx, keys...))
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/db"
+	"github.com/Trishank-Omniful/Onboarding-Task/jobs"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/Trishank-Omniful/Onboarding-Task/seeder"
+)
+
+const usage = `Usage:
+  ims [serve]                 start the HTTP server
+  ims migrate up              apply all pending migrations
+  ims migrate down [steps]    roll back the last N migrations (default 1)
+  ims migrate status          show the applied and pending migrations
+  ims migrate force <version> set the version and clear the dirty flag
+  ims seed [flags]            generate hubs, SKUs and inventory, see ims seed -h
+  ims snapshot [flags]        snapshot inventory for yesterday (UTC), see ims snapshot -h`
+
+func runCommand(cfg *config.Config, args []string) int {
+	var err error
+	switch args[0] {
+	case "migrate":
+		err = runMigrate(cfg, args[1:])
+	case "seed":
+		err = runSeed(cfg, args[1:])
+	case "snapshot":
+		err = runSnapshot(cfg, args[1:])
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+		return 0
+	default:
+		err = fmt.Errorf("unknown command %q", args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ims: %v\n\n%s\n", err, usage)
+		return 1
+	}
+	return 0
+}
+
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("migrate requires a subcommand")
+	}
+
+	switch args[0] {
+	case "up":
+		if err := db.MigrateUp(cfg.Postgres); err != nil {
+			return err
+		}
+		log.Println("Migrations applied successfully")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+			steps = n
+		}
+		if err := db.MigrateDown(cfg.Postgres, steps); err != nil {
+			return err
+		}
+		log.Printf("Rolled back %d migration(s)", steps)
+	case "status":
+		status, err := db.GetMigrationStatus(cfg.Postgres)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(status)
+	case "force":
+		if len(args) < 2 {
+			return fmt.Errorf("force requires a version")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := db.ForceMigrationVersion(cfg.Postgres, version); err != nil {
+			return err
+		}
+		log.Printf("Forced migration version to %d", version)
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q", args[0])
+	}
+	return nil
+}
+
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fixturePath := flags.String("fixture", "", "YAML or JSON fixture file")
+	catalogPath := flags.String("catalog", "", "write the seeded hubs and SKUs as JSON for `oms seed`")
+	reset := flags.Bool("reset", false, "truncate hubs, skus and inventories first")
+	hubs := flags.Int("hubs", 0, "number of generated hubs")
+	skus := flags.Int("skus", 0, "number of generated SKUs")
+	inventory := flags.Int("inventory", 0, "number of inventory rows spread over the hub x SKU matrix")
+	tenants := flags.Int("tenants", 0, "number of tenants")
+	sellers := flags.Int("sellers", 0, "sellers per tenant")
+	batchSize := flags.Int("batch-size", 0, "rows per INSERT statement")
+	seed := flags.Int64("seed", 0, "random seed, the same seed reproduces the same dataset")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	fixture := seeder.DefaultFixture()
+	if *fixturePath != "" {
+		var err error
+		if fixture, err = seeder.LoadFixture(*fixturePath); err != nil {
+			return err
+		}
+	}
+	// Only flags given explicitly override the fixture.
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "hubs":
+			fixture.Hubs = *hubs
+		case "skus":
+			fixture.SKUs = *skus
+		case "inventory":
+			fixture.Inventory = *inventory
+		case "tenants":
+			fixture.Tenants = *tenants
+		case "sellers":
+			fixture.SellersPerTenant = *sellers
+		case "batch-size":
+			fixture.BatchSize = *batchSize
+		case "seed":
+			fixture.Seed = *seed
+		}
+	})
+	if err := fixture.Validate(); err != nil {
+		return err
+	}
+
+	if err := db.Connect(context.Background(), cfg.Postgres); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	s := seeder.NewSeeder(db.GetDB(), fixture)
+	if *reset {
+		if err := s.Reset(); err != nil {
+			return err
+		}
+	}
+
+	summary, catalog, err := s.Run()
+	if err != nil {
+		return err
+	}
+	log.Printf("Seeded %d hubs, %d skus and %d inventory rows in %s",
+		summary.Hubs, summary.SKUs, summary.Inventories, summary.Duration.Round(time.Millisecond))
+
+	if *catalogPath != "" {
+		file, err := os.Create(*catalogPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err := catalog.Write(file); err != nil {
+			return err
+		}
+		log.Printf("Catalog written to %s", *catalogPath)
+	}
+	return nil
+}
+
+// runSnapshot takes a snapshot outside the server schedule, e.g. after a
+// missed run. It records current stock, so only yesterday's snapshot can be
+// taken, and an existing one is only replaced with -force.
+func runSnapshot(cfg *config.Config, args []string) error {
+	now := time.Now()
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	day := flags.String("date", jobs.SnapshotDate(now).Format(time.DateOnly), "snapshot date as YYYY-MM-DD, must be yesterday (UTC)")
+	force := flags.Bool("force", false, "overwrite an existing snapshot of the date")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	date, err := time.Parse(time.DateOnly, *day)
+	if err != nil {
+		return fmt.Errorf("invalid date %q", *day)
+	}
+
+	if err := db.Connect(context.Background(), cfg.Postgres); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := repository.NewSnapshotRepository(db.GetDB())
+	if err := jobs.CheckManualSnapshot(context.Background(), repo, date, now, *force); err != nil {
+		return err
+	}
+	rows, err := repo.TakeSnapshot(context.Background(), date)
+	if err != nil {
+		return err
+	}
+	log.Printf("Snapshot of %s taken with %d rows", *day, rows)
+	return nil
+}
+
+func printMigrationStatus(status db.MigrationStatus) {
+	fmt.Printf("current version: %d", status.Version)
+	if status.Dirty {
+		fmt.Print(" (dirty, fix the schema and run `ims migrate force <version>`)")
+	}
+	fmt.Println()
+	for _, v := range status.Available {
+		state := "applied"
+		if v > status.Version {
+			state = "pending"
+		}
+		fmt.Printf("  %06d  %s\n", v, state)
+	}
+}
+
+func warnOnPendingMigrations(cfg *config.Config) {
+	status, err := db.GetMigrationStatus(cfg.Postgres)
+	if err != nil {
+		log.Print("Could not read migration status: ", err)
+		return
+	}
+	if status.Dirty {
+		log.Printf("Migration version %d is dirty, run `ims migrate force <version>` once the schema is fixed", status.Version)
+	}
+	if pending := status.Pending(); len(pending) > 0 {
+		log.Printf("%d pending migration(s), run `ims migrate up` before serving traffic", len(pending))
+	}
+}
@@ -0,0 +1,484 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Startup   StartupConfig   `yaml:"startup"`
+	Postgres  PostgresConfig  `yaml:"postgres"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Cache     CacheConfig     `yaml:"cache"`
+	Batch     BatchConfig     `yaml:"batch"`
+	Kafka     KafkaConfig     `yaml:"kafka"`
+	Snapshot  SnapshotConfig  `yaml:"snapshot"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Logging   LoggingConfig   `yaml:"logging"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+}
+
+type ServerConfig struct {
+	Port         string        `yaml:"port"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// ShutdownTimeout bounds how long in-flight requests may drain after
+	// SIGTERM before connection pools are closed anyway.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ReadinessTimeout bounds each dependency check behind /health/ready.
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout"`
+}
+
+// StartupConfig controls how long boot waits for dependencies before the
+// process exits non-zero.
+type StartupConfig struct {
+	Timeout        time.Duration `yaml:"timeout"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+}
+
+type PostgresConfig struct {
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Database        string        `yaml:"database"`
+	SSLMode         string        `yaml:"sslmode"`
+	SSLRootCert     string        `yaml:"sslrootcert"`
+	MigrateURL      string        `yaml:"migrate_url"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+type RedisConfig struct {
+	Hosts       []string `yaml:"hosts"`
+	PoolSize    int      `yaml:"pool_size"`
+	MinIdleConn int      `yaml:"min_idle_conn"`
+}
+
+type CacheConfig struct {
+	LocalCapacity int           `yaml:"local_capacity"`
+	LocalTTL      time.Duration `yaml:"local_ttl"`
+	HubTTL        time.Duration `yaml:"hub_ttl"`
+	SKUTTL        time.Duration `yaml:"sku_ttl"`
+}
+
+type BatchConfig struct {
+	MaxSize int `yaml:"max_size"`
+}
+
+type KafkaConfig struct {
+	Brokers     []string `yaml:"brokers"`
+	ClientID    string   `yaml:"client_id"`
+	AlertsTopic string   `yaml:"alerts_topic"`
+}
+
+// SnapshotConfig schedules the daily inventory snapshot. It runs RunAt past
+// every UTC midnight and records the day that just ended.
+type SnapshotConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	RunAt   time.Duration `yaml:"run_at"`
+}
+
+// TracingConfig selects where spans go: "none" keeps W3C propagation but
+// records nothing, "stdout" prints spans and "otlp" sends them over OTLP/HTTP.
+type TracingConfig = tracing.Config
+
+type LoggingConfig = logger.Config
+
+// RateLimitConfig drives the per-tenant and per-IP token buckets. Requests
+// are matched to a group by their route template; unmatched routes use
+// Default. Tenants overrides a group's limit for one tenant, keyed by tenant
+// then group name.
+type RateLimitConfig struct {
+	Enabled bool                            `yaml:"enabled"`
+	Default RateLimit                       `yaml:"default"`
+	Groups  []RateLimitGroup                `yaml:"groups"`
+	Tenants map[string]map[string]RateLimit `yaml:"tenants"`
+}
+
+type RateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+type RateLimitGroup struct {
+	Name      string   `yaml:"name"`
+	Routes    []string `yaml:"routes"`
+	RateLimit `yaml:",inline"`
+}
+
+var (
+	logLevels  = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	logFormats = map[string]bool{"json": true, "text": true}
+)
+
+var tracingExporters = map[string]bool{"none": true, "stdout": true, "otlp": true}
+
+var sslModes = map[string]bool{
+	"disable": true, "allow": true, "prefer": true, "require": true, "verify-ca": true, "verify-full": true,
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:         ":8000",
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  70 * time.Second,
+
+			ShutdownTimeout:  15 * time.Second,
+			ReadinessTimeout: 2 * time.Second,
+		},
+		Startup: StartupConfig{
+			Timeout:        time.Minute,
+			InitialBackoff: 500 * time.Millisecond,
+			MaxBackoff:     10 * time.Second,
+		},
+		Postgres: PostgresConfig{
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Redis: RedisConfig{
+			Hosts:       []string{"localhost:6379"},
+			PoolSize:    50,
+			MinIdleConn: 10,
+		},
+		Cache: CacheConfig{
+			LocalCapacity: 10000,
+			LocalTTL:      30 * time.Second,
+			HubTTL:        5 * time.Minute,
+			SKUTTL:        5 * time.Minute,
+		},
+		Batch: BatchConfig{MaxSize: 1000},
+		Kafka: KafkaConfig{
+			Brokers:     []string{"localhost:9092"},
+			ClientID:    "ims",
+			AlertsTopic: "ims.inventory.alerts",
+		},
+		Snapshot: SnapshotConfig{Enabled: true, RunAt: 5 * time.Minute},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
+		Logging: LoggingConfig{Level: "info", Format: "json"},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Default: RateLimit{RequestsPerSecond: 50, Burst: 100},
+			Groups: []RateLimitGroup{{
+				Name: "batch",
+				Routes: []string{
+					"/api/v1/ims/hub/batch",
+					"/api/v1/ims/sku/batch",
+					"/api/v1/ims/inventory/batch",
+					"/api/v1/ims/hub/import",
+					"/api/v1/ims/sku/import",
+					"/api/v1/ims/inventory/import",
+				},
+				RateLimit: RateLimit{RequestsPerSecond: 2, Burst: 5},
+			}},
+		},
+	}
+}
+
+// Load starts from Default, applies the YAML file named by CONFIG_FILE if
+// set, then environment variables, and validates the result.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parse config %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) applyEnv() error {
+	env := &envReader{}
+	env.str("SERVER_PORT", &c.Server.Port)
+	env.duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	env.duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	env.duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	env.duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	env.duration("SERVER_READINESS_TIMEOUT", &c.Server.ReadinessTimeout)
+
+	env.duration("STARTUP_TIMEOUT", &c.Startup.Timeout)
+	env.duration("STARTUP_INITIAL_BACKOFF", &c.Startup.InitialBackoff)
+	env.duration("STARTUP_MAX_BACKOFF", &c.Startup.MaxBackoff)
+
+	env.str("POSTGRES_HOST", &c.Postgres.Host)
+	env.integer("POSTGRES_PORT", &c.Postgres.Port)
+	env.str("POSTGRES_USER", &c.Postgres.User)
+	env.str("POSTGRES_PASSWORD", &c.Postgres.Password)
+	env.str("POSTGRES_DB", &c.Postgres.Database)
+	env.str("POSTGRES_SSLMODE", &c.Postgres.SSLMode)
+	env.str("POSTGRES_SSLROOTCERT", &c.Postgres.SSLRootCert)
+	env.str("POSTGRES_URL", &c.Postgres.MigrateURL)
+	env.integer("POSTGRES_MAX_OPEN_CONNS", &c.Postgres.MaxOpenConns)
+	env.integer("POSTGRES_MAX_IDLE_CONNS", &c.Postgres.MaxIdleConns)
+	env.duration("POSTGRES_CONN_MAX_LIFETIME", &c.Postgres.ConnMaxLifetime)
+	env.duration("POSTGRES_CONN_MAX_IDLE_TIME", &c.Postgres.ConnMaxIdleTime)
+
+	env.list("REDIS_HOSTS", &c.Redis.Hosts)
+	env.integer("REDIS_POOL_SIZE", &c.Redis.PoolSize)
+	env.integer("REDIS_MIN_IDLE_CONN", &c.Redis.MinIdleConn)
+
+	env.integer("CACHE_LOCAL_CAPACITY", &c.Cache.LocalCapacity)
+	env.duration("CACHE_LOCAL_TTL", &c.Cache.LocalTTL)
+	env.duration("CACHE_HUB_TTL", &c.Cache.HubTTL)
+	env.duration("CACHE_SKU_TTL", &c.Cache.SKUTTL)
+
+	env.integer("BATCH_MAX_SIZE", &c.Batch.MaxSize)
+
+	env.list("KAFKA_BROKERS", &c.Kafka.Brokers)
+	env.str("KAFKA_CLIENT_ID", &c.Kafka.ClientID)
+	env.str("KAFKA_ALERTS_TOPIC", &c.Kafka.AlertsTopic)
+
+	env.boolean("SNAPSHOT_ENABLED", &c.Snapshot.Enabled)
+	env.duration("SNAPSHOT_RUN_AT", &c.Snapshot.RunAt)
+
+	env.str("TRACING_EXPORTER", &c.Tracing.Exporter)
+	env.str("OTEL_EXPORTER_OTLP_ENDPOINT", &c.Tracing.Endpoint)
+	env.boolean("TRACING_INSECURE", &c.Tracing.Insecure)
+	env.number("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	env.str("LOG_LEVEL", &c.Logging.Level)
+	env.str("LOG_FORMAT", &c.Logging.Format)
+
+	env.boolean("RATE_LIMIT_ENABLED", &c.RateLimit.Enabled)
+	env.number("RATE_LIMIT_REQUESTS_PER_SECOND", &c.RateLimit.Default.RequestsPerSecond)
+	env.integer("RATE_LIMIT_BURST", &c.RateLimit.Default.Burst)
+	return errors.Join(env.errs...)
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, port, err := net.SplitHostPort(c.Server.Port); err != nil || port == "" {
+		errs = append(errs, fmt.Errorf("server.port %q must look like :8000 or host:8000", c.Server.Port))
+	}
+	check(c.Server.ReadTimeout > 0 && c.Server.WriteTimeout > 0 && c.Server.IdleTimeout > 0, "server timeouts must be positive")
+	check(c.Server.ShutdownTimeout > 0 && c.Server.ReadinessTimeout > 0, "server shutdown and readiness timeouts must be positive")
+
+	check(c.Startup.Timeout > 0, "startup.timeout must be positive")
+	check(c.Startup.InitialBackoff > 0 && c.Startup.InitialBackoff <= c.Startup.MaxBackoff,
+		"startup.initial_backoff must be positive and not exceed max_backoff")
+
+	check(c.Postgres.Host != "", "postgres.host is required")
+	check(c.Postgres.Port > 0 && c.Postgres.Port < 65536, "postgres.port %d is out of range", c.Postgres.Port)
+	check(c.Postgres.User != "", "postgres.user is required")
+	check(c.Postgres.Database != "", "postgres.database is required")
+	check(sslModes[c.Postgres.SSLMode], "postgres.sslmode %q is not a valid libpq sslmode", c.Postgres.SSLMode)
+	check(c.Postgres.MaxOpenConns > 0, "postgres.max_open_conns must be positive")
+	check(c.Postgres.MaxIdleConns >= 0 && c.Postgres.MaxIdleConns <= c.Postgres.MaxOpenConns,
+		"postgres.max_idle_conns must be between 0 and max_open_conns")
+
+	check(len(c.Redis.Hosts) > 0, "redis.hosts is required")
+	check(c.Redis.PoolSize > 0, "redis.pool_size must be positive")
+	check(c.Redis.MinIdleConn >= 0 && c.Redis.MinIdleConn <= c.Redis.PoolSize, "redis.min_idle_conn must be between 0 and pool_size")
+
+	check(c.Cache.LocalCapacity >= 0, "cache.local_capacity cannot be negative")
+	check(c.Cache.LocalTTL > 0 && c.Cache.HubTTL > 0 && c.Cache.SKUTTL > 0, "cache TTLs must be positive")
+
+	check(c.Batch.MaxSize > 0, "batch.max_size must be positive")
+	check(len(c.Kafka.Brokers) > 0, "kafka.brokers is required")
+	check(c.Kafka.AlertsTopic != "", "kafka.alerts_topic is required")
+	check(c.Snapshot.RunAt >= 0 && c.Snapshot.RunAt < 24*time.Hour, "snapshot.run_at must be between 0 and 24h")
+
+	check(tracingExporters[c.Tracing.Exporter], "tracing.exporter %q must be none, stdout or otlp", c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "otlp" || c.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(logLevels[strings.ToLower(c.Logging.Level)], "logging.level %q must be debug, info, warn or error", c.Logging.Level)
+	check(logFormats[strings.ToLower(c.Logging.Format)], "logging.format %q must be json or text", c.Logging.Format)
+
+	errs = append(errs, c.RateLimit.validate()...)
+
+	return errors.Join(errs...)
+}
+
+func (r RateLimitConfig) validate() []error {
+	var errs []error
+	checkLimit := func(name string, l RateLimit) {
+		if l.RequestsPerSecond <= 0 || l.Burst < 1 {
+			errs = append(errs, fmt.Errorf("rate_limit %s needs positive requests_per_second and burst", name))
+		}
+	}
+
+	checkLimit("default", r.Default)
+	groups := map[string]bool{"default": true}
+	routes := map[string]string{}
+	for _, g := range r.Groups {
+		if g.Name == "" || groups[g.Name] {
+			errs = append(errs, fmt.Errorf("rate_limit group name %q is empty or repeated", g.Name))
+		}
+		groups[g.Name] = true
+		checkLimit("group "+g.Name, g.RateLimit)
+		for _, route := range g.Routes {
+			if other, ok := routes[route]; ok {
+				errs = append(errs, fmt.Errorf("rate_limit route %s is in both %s and %s", route, other, g.Name))
+			}
+			routes[route] = g.Name
+		}
+	}
+	for tenant, overrides := range r.Tenants {
+		for group, l := range overrides {
+			if !groups[group] {
+				errs = append(errs, fmt.Errorf("rate_limit tenant %s overrides unknown group %q", tenant, group))
+			}
+			checkLimit("tenant "+tenant+" group "+group, l)
+		}
+	}
+	return errs
+}
+
+// DSN is the keyword/value connection string used by gorm.
+func (p PostgresConfig) DSN() string {
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		dsnValue(p.Host), p.Port, dsnValue(p.User), dsnValue(p.Password), dsnValue(p.Database), p.SSLMode)
+	if p.SSLRootCert != "" {
+		dsn += " sslrootcert=" + dsnValue(p.SSLRootCert)
+	}
+	return dsn
+}
+
+func dsnValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// URL is the postgres:// form of DSN, used by golang-migrate. MigrateURL
+// replaces it when set, e.g. to migrate as a different role.
+func (p PostgresConfig) URL() string {
+	if p.MigrateURL != "" {
+		return p.MigrateURL
+	}
+	query := url.Values{"sslmode": {p.SSLMode}}
+	if p.SSLRootCert != "" {
+		query.Set("sslrootcert", p.SSLRootCert)
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(p.User, p.Password),
+		Host:     net.JoinHostPort(p.Host, strconv.Itoa(p.Port)),
+		Path:     p.Database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) str(key string, dst *string) {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		*dst = v
+	}
+}
+
+func (e *envReader) list(key string, dst *[]string) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return
+	}
+	var values []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	*dst = values
+}
+
+func (e *envReader) integer(key string, dst *int) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not an integer", key, v))
+		return
+	}
+	*dst = n
+}
+
+func (e *envReader) boolean(key string, dst *bool) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a boolean", key, v))
+		return
+	}
+	*dst = b
+}
+
+func (e *envReader) number(key string, dst *float64) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a number", key, v))
+		return
+	}
+	*dst = f
+}
+
+func (e *envReader) duration(key string, dst *time.Duration) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a duration like 10s or 5m", key, v))
+		return
+	}
+	*dst = d
+}
@@ -17,18 +17,55 @@ const (
 	ErrInvalidRequest        = "Invalid Request"
 	ErrInsufficientInventory = "Insufficient Inventory"
 	ErrBatchOperation        = "Batch Operation Failed"
+	ErrInvalidReference      = "Referenced Hub or SKU does not exist"
+	ErrSupersededRow         = "Superseded by a later row for the same hub and SKU"
+	ErrValidationFailed      = "Validation Failed"
+	ErrInvalidPartialFlag    = "partial must be true or false"
+	ErrInvalidForceFlag      = "force must be true or false"
+	ErrTransferNotInTransit  = "Transfer is not in transit"
+	ErrTransferCreate        = "Failed to create transfer"
+	ErrTransferView          = "Failed to view transfers"
+	ErrCycleCountNotOpen     = "Cycle count is not open"
+	ErrCycleCountCreate      = "Failed to create cycle count"
+	ErrCycleCountView        = "Failed to view cycle counts"
+	ErrInvalidApprover       = "approved_by is required and at most 255 characters"
+	ErrInvalidDryRunFlag     = "dry_run must be true or false"
+	ErrExport                = "Failed to export"
+	ErrThresholdUpdate       = "Failed to update stock thresholds"
+	ErrAlertView             = "Failed to view stock alerts"
+	ErrInvalidStockLevel     = "level must be low_stock, below_safety_stock or out_of_stock"
+	ErrInvalidResolvedFlag   = "include_resolved must be true or false"
+	ErrSnapshotView          = "Failed to view inventory snapshots"
+	ErrInvalidDateRange      = "to must not be before from"
+	ErrValuationView         = "Failed to value inventory"
+	ErrInvalidReportFormat   = "format must be json or csv"
+	ErrRateLimited           = "Too Many Requests"
+	ErrIfMatchRequired       = "If-Match header with the ETag from GET is required"
+	ErrVersionMismatch       = "Resource was modified since it was read"
 
-	RedisHost        = "localhost:6379"
-	RedisPoolSize    = 50
-	RedisMinIdleConn = 10
+	ErrCodeSuffixNotFound        = "_NOT_FOUND"
+	ErrCodeSuffixExists          = "_ALREADY_EXISTS"
+	ErrCodeSuffixHasStock        = "_HAS_STOCK"
+	ErrCodeInvalidID             = "INVALID_ID"
+	ErrCodeInvalidJSON           = "INVALID_JSON"
+	ErrCodeInvalidCSV            = "INVALID_CSV"
+	ErrCodeInvalidRequest        = "INVALID_REQUEST"
+	ErrCodeInvalidReference      = "INVALID_REFERENCE"
+	ErrCodeInvalidQuantity       = "INVALID_QUANTITY"
+	ErrCodeValidation            = "VALIDATION_FAILED"
+	ErrCodeInsufficientInventory = "INSUFFICIENT_INVENTORY"
+	ErrCodeInternal              = "INTERNAL_ERROR"
+	ErrCodeRateLimited           = "RATE_LIMITED"
+	ErrCodePreconditionRequired  = "PRECONDITION_REQUIRED"
+	ErrCodeVersionMismatch       = "VERSION_MISMATCH"
+	ErrCodeTransferNotInTransit  = "TRANSFER_NOT_IN_TRANSIT"
+	ErrCodeCycleCountNotOpen     = "CYCLE_COUNT_NOT_OPEN"
 
-	ServerPort         = ":8000"
-	ServerReadTimeout  = 10
-	ServerWriteTimeout = 10
-	ServerIdleTimeout  = 70
-
-	CacheTTLHubs = 5
-	CacheTTLSKUs = 5
+	EntityHub        = "Hub"
+	EntitySKU        = "SKU"
+	EntityInventory  = "Inventory"
+	EntityTransfer   = "Transfer"
+	EntityCycleCount = "Cycle Count"
 
 	CacheKeyHubID   = "hub:id:"
 	CacheKeyHubName = "hub:name:"
@@ -45,11 +82,21 @@ const (
 	CacheKeyHubValidation = "hub:validation:"
 	CacheKeyInventoryView = "inventory:view:"
 
-	MaxBatchSize     = 1000
 	DefaultBatchSize = 100
+	UpsertChunkSize  = 1000
+
+	DefaultHistoryLimit = 100
+	MaxHistoryLimit     = 1000
 
 	ErrInventoryUpsert = "Failed to upsert inventory"
 	ErrInventoryReduce = "Failed to reduce inventory"
 	ErrInventoryView   = "Failed to view inventory"
 	ErrAtomicOperation = "Atomic operation failed"
 )
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	HeaderTenantID  = "X-Tenant-ID"
+	HeaderETag      = "ETag"
+	HeaderIfMatch   = "If-Match"
+)
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/gin-gonic/gin"
+)
+
+func isPartialBatch(c *gin.Context) (bool, error) {
+	return strconv.ParseBool(c.DefaultQuery("partial", "false"))
+}
+
+// partitionBatch validates every item and returns the result slice with the
+// invalid items already filled in, along with the positions of valid items.
+func partitionBatch(size int, validate func(i int) error) ([]models.BatchItemResult, []int) {
+	results := make([]models.BatchItemResult, size)
+	var positions []int
+	for i := 0; i < size; i++ {
+		if err := validate(i); err != nil {
+			results[i] = models.BatchItemResult{
+				Index:  i,
+				Status: models.BatchItemInvalid,
+				Reason: constants.ErrValidationFailed,
+				Errors: err,
+			}
+			continue
+		}
+		positions = append(positions, i)
+	}
+	return results, positions
+}
+
+// mergeBatchResults places the repository outcomes for the valid items back
+// at their position in the original request.
+func mergeBatchResults(results []models.BatchItemResult, positions []int, outcomes []models.BatchItemResult) {
+	for j, i := range positions {
+		outcome := outcomes[j]
+		outcome.Index = i
+		results[i] = outcome
+	}
+}
+
+func respondBatchResults(c *gin.Context, message string, results []models.BatchItemResult) {
+	summary := make(map[models.BatchItemStatus]int)
+	for _, result := range results {
+		summary[result.Status]++
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"summary": summary,
+		"results": results,
+	})
+}
@@ -0,0 +1,245 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/csvio"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportHubs creates hubs from CSV. Names already taken are reported as
+// duplicates.
+func (ctrl *HubController) ImportHubs(c *gin.Context) {
+	importCSV(c, ctrl.MaxBatchSize, csvImport[models.Hub]{
+		columns:  csvio.HubColumns,
+		validate: validators.ValidateHub,
+		store:    ctrl.Repo.CreateHubsBatchPartial,
+		check:    ctrl.Repo.CheckHubsBatchPartial,
+		key:      func(hub *models.Hub) string { return hub.Name },
+	})
+}
+
+func (ctrl *HubController) ExportHubs(c *gin.Context) {
+	exportCSV(c, "hubs.csv", csvio.HubColumns, func(fn func([]models.Hub) error) error {
+		return ctrl.Repo.ExportHubs(c.Request.Context(), fn)
+	})
+}
+
+// ImportSkus creates SKUs from CSV. Codes already taken are reported as
+// duplicates.
+func (ctrl *SkuController) ImportSkus(c *gin.Context) {
+	importCSV(c, ctrl.MaxBatchSize, csvImport[models.SKU]{
+		columns:  csvio.SKUColumns,
+		validate: validators.ValidateSKU,
+		store:    ctrl.Repo.CreateSKUsBatchPartial,
+		check:    ctrl.Repo.CheckSKUsBatchPartial,
+		key:      func(sku *models.SKU) string { return sku.Code },
+	})
+}
+
+// ExportSkus takes the tenant_id and seller_id filters of /sku/filter.
+func (ctrl *SkuController) ExportSkus(c *gin.Context) {
+	filter := repository.SKUFilter{TenantID: c.Query("tenant_id"), SellerID: c.Query("seller_id")}
+	exportCSV(c, "skus.csv", csvio.SKUColumns, func(fn func([]models.SKU) error) error {
+		return ctrl.Repo.ExportSkus(c.Request.Context(), filter, fn)
+	})
+}
+
+// ImportInventory upserts quantities from CSV by hub_id and sku_id.
+func (ctrl *InventoryController) ImportInventory(c *gin.Context) {
+	importCSV(c, ctrl.MaxBatchSize, csvImport[models.Inventory]{
+		columns:  csvio.InventoryColumns,
+		validate: validators.ValidateInventory,
+		store:    ctrl.Repo.UpsertInventoryBatchPartial,
+		check:    ctrl.Repo.CheckInventoryBatchPartial,
+	})
+}
+
+// ExportInventory takes the hub_id and sku_id filters of GET /inventory.
+func (ctrl *InventoryController) ExportInventory(c *gin.Context) {
+	hubID, err := optionalID(c, "hub_id")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return
+	}
+	skuID, err := optionalID(c, "sku_id")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid sku_id format", err)
+		return
+	}
+	exportCSV(c, "inventory.csv", csvio.InventoryColumns, func(fn func([]models.Inventory) error) error {
+		return ctrl.Repo.ExportInventory(c.Request.Context(), hubID, skuID, fn)
+	})
+}
+
+// csvImport describes how rows of T are checked and stored. check classifies
+// a chunk like store without writing it. key names the unique field of a
+// row, so a dry run can report repeats across chunks that store would have
+// rejected against the chunks already written; it is nil when a later row
+// may repeat a key.
+type csvImport[T any] struct {
+	columns  []csvio.Column[T]
+	validate func(*T) error
+	store    func(ctx context.Context, items []T) ([]models.BatchItemResult, error)
+	check    func(ctx context.Context, items []T) ([]models.BatchItemResult, error)
+	key      func(*T) string
+}
+
+// csvImportReport summarises an import. Errors only lists the rows that were
+// not stored, with the CSV line number as the index.
+type csvImportReport struct {
+	Message string                         `json:"message"`
+	DryRun  bool                           `json:"dry_run"`
+	Rows    int                            `json:"rows"`
+	Summary map[models.BatchItemStatus]int `json:"summary"`
+	Errors  []models.BatchItemResult       `json:"errors"`
+}
+
+func (r *csvImportReport) add(result models.BatchItemResult) {
+	r.Rows++
+	r.Summary[result.Status]++
+	if result.Status == models.BatchItemInvalid || result.Status == models.BatchItemDuplicate {
+		r.Errors = append(r.Errors, result)
+	}
+}
+
+// importCSV streams a CSV request body and stores valid rows chunkSize at a
+// time, so the file is never held in memory. ?map[column]=header renames
+// columns and ?dry_run=true runs the same checks without storing anything.
+// Chunks stored before a malformed line stay stored; the error response
+// reports them.
+func importCSV[T any](c *gin.Context, chunkSize int, spec csvImport[T]) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidDryRunFlag, err)
+		return
+	}
+	reader, err := csvio.NewReader(c.Request.Body, spec.columns, c.QueryMap("map"))
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidCSV, err.Error(), err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	report := &csvImportReport{Message: "CSV import processed", DryRun: dryRun, Summary: make(map[models.BatchItemStatus]int), Errors: []models.BatchItemResult{}}
+	var chunk []T
+	var lines []int
+	seen := make(map[string]bool)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		step := spec.store
+		if dryRun {
+			step = spec.check
+		}
+		outcomes, err := step(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for i, outcome := range outcomes {
+			if dryRun && spec.key != nil && outcome.Status == models.BatchItemValid {
+				key := spec.key(&chunk[i])
+				if seen[key] {
+					outcome = models.BatchItemResult{Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+				}
+				seen[key] = true
+			}
+			outcome.Index = lines[i]
+			report.add(outcome)
+		}
+		chunk, lines = chunk[:0], lines[:0]
+		return nil
+	}
+
+	for {
+		item, line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var cellErrs validators.ValidationErrors
+		if err != nil && !errors.As(err, &cellErrs) {
+			if flushErr := flush(); flushErr != nil {
+				respondError(c, flushErr, constants.ErrBatchOperation)
+				return
+			}
+			respondError(c, repository.NewValidationError(constants.ErrCodeInvalidCSV, err.Error(), err).WithDetails(report), "")
+			return
+		}
+		if err == nil {
+			err = spec.validate(&item)
+		}
+		if err != nil {
+			report.add(models.BatchItemResult{Index: line, Status: models.BatchItemInvalid, Reason: constants.ErrValidationFailed, Errors: err})
+			continue
+		}
+
+		chunk, lines = append(chunk, item), append(lines, line)
+		if len(chunk) == chunkSize {
+			if err := flush(); err != nil {
+				respondError(c, err, constants.ErrBatchOperation)
+				return
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		respondError(c, err, constants.ErrBatchOperation)
+		return
+	}
+	slog.InfoContext(ctx, "csv import processed", "path", c.FullPath(), "dry_run", dryRun, "rows", report.Rows, "rejected", len(report.Errors))
+	c.JSON(http.StatusOK, report)
+}
+
+// exportCSV streams rows as a CSV download, flushing after every batch the
+// repository hands over. Once rows have been sent an error can only end the
+// response early, so it is logged.
+func exportCSV[T any](c *gin.Context, filename string, columns []csvio.Column[T], export func(fn func([]T) error) error) {
+	// The CSV writer flushes on its own once its buffer fills, which commits
+	// the response, so the headers have to be in place before any row.
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w := csvio.NewWriter(c.Writer, columns)
+	_ = w.WriteHeader()
+	send := func() error {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	rows := 0
+	err := export(func(items []T) error {
+		for i := range items {
+			if err := w.Write(&items[i]); err != nil {
+				return err
+			}
+		}
+		rows += len(items)
+		return send()
+	})
+	if err == nil {
+		err = send()
+	}
+	if err != nil && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		respondError(c, err, constants.ErrExport)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "csv export aborted", "path", c.FullPath(), "rows", rows, "error", err)
+		c.Abort()
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "csv export streamed", "path", c.FullPath(), "rows", rows)
+}
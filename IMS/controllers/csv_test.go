@@ -0,0 +1,175 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+type csvImportResponse struct {
+	DryRun  bool                           `json:"dry_run"`
+	Rows    int                            `json:"rows"`
+	Summary map[models.BatchItemStatus]int `json:"summary"`
+	Errors  []struct {
+		Index  int                    `json:"index"`
+		Status models.BatchItemStatus `json:"status"`
+	} `json:"errors"`
+}
+
+func TestImportHubs(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_taken")
+	body := "Hub Name,address,city\n" +
+		"hub_a,street 1,Pune\n" +
+		",street 2,Pune\n" +
+		"hub_taken,street 3,Pune\n"
+
+	rec := s.do(t, http.MethodPost, "/hub/import?dry_run=true&map[name]=Hub+Name", body)
+	assertStatus(t, rec, http.StatusOK)
+	var dry csvImportResponse
+	decode(t, rec, &dry)
+	if !dry.DryRun || dry.Summary[models.BatchItemValid] != 1 || dry.Summary[models.BatchItemInvalid] != 1 ||
+		dry.Summary[models.BatchItemDuplicate] != 1 {
+		t.Fatalf("dry run report = %+v, want 1 valid, 1 invalid and 1 duplicate", dry)
+	}
+	if hubs, _ := s.hubRepo.GetAllHubs(context.Background()); len(hubs) != 1 {
+		t.Fatalf("dry run stored %d hubs, want none", len(hubs)-1)
+	}
+
+	// Six rows span two chunks; the repeat in the second is still caught.
+	repeated := "name,address,city\n" +
+		"hub_b,street 1,Pune\nhub_c,street 2,Pune\nhub_d,street 3,Pune\n" +
+		"hub_e,street 4,Pune\nhub_f,street 5,Pune\nhub_b,street 6,Pune\n"
+	rec = s.do(t, http.MethodPost, "/hub/import?dry_run=true", repeated)
+	assertStatus(t, rec, http.StatusOK)
+	dry = csvImportResponse{}
+	decode(t, rec, &dry)
+	if len(dry.Errors) != 1 || dry.Errors[0].Index != 7 || dry.Errors[0].Status != models.BatchItemDuplicate {
+		t.Fatalf("dry run errors = %+v, want line 7 duplicate", dry.Errors)
+	}
+
+	rec = s.do(t, http.MethodPost, "/hub/import?map[name]=Hub+Name", body)
+	assertStatus(t, rec, http.StatusOK)
+	var got csvImportResponse
+	decode(t, rec, &got)
+	if got.Rows != 3 || got.Summary[models.BatchItemCreated] != 1 {
+		t.Fatalf("report = %+v, want 3 rows with 1 created", got)
+	}
+	if len(got.Errors) != 2 || got.Errors[0].Index != 3 || got.Errors[0].Status != models.BatchItemInvalid ||
+		got.Errors[1].Index != 4 || got.Errors[1].Status != models.BatchItemDuplicate {
+		t.Fatalf("errors = %+v, want line 3 invalid and line 4 duplicate", got.Errors)
+	}
+
+	rec = s.do(t, http.MethodPost, "/hub/import", "warehouse,region\nPune,West\n")
+	assertError(t, rec, http.StatusBadRequest, constants.ErrCodeInvalidCSV)
+}
+
+func TestImportInventory(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+
+	body := "hub_id,sku_id,quantity\n" +
+		"1,1,7\n" +
+		"1,1,abc\n" +
+		"1,1,-2\n"
+	rec := s.do(t, http.MethodPost, "/inventory/import", body)
+	assertStatus(t, rec, http.StatusOK)
+
+	var got csvImportResponse
+	decode(t, rec, &got)
+	if got.Rows != 3 || len(got.Errors) != 2 || got.Errors[0].Index != 3 || got.Errors[1].Index != 4 {
+		t.Fatalf("report = %+v, want lines 3 and 4 rejected", got)
+	}
+	if q := s.quantity(t, hub.ID, sku.ID); q != 7 {
+		t.Fatalf("quantity = %d, want 7", q)
+	}
+
+	body = "hub_id,sku_id,quantity\n" +
+		"1,1,8\n" +
+		"9,1,3\n" +
+		"1,1,9\n"
+	rec = s.do(t, http.MethodPost, "/inventory/import?dry_run=true", body)
+	assertStatus(t, rec, http.StatusOK)
+	var dry csvImportResponse
+	decode(t, rec, &dry)
+	if len(dry.Errors) != 2 || dry.Errors[0].Status != models.BatchItemDuplicate || dry.Errors[1].Status != models.BatchItemInvalid {
+		t.Fatalf("dry run errors = %+v, want line 2 superseded and line 3 with a missing hub", dry.Errors)
+	}
+	if q := s.quantity(t, hub.ID, sku.ID); q != 7 {
+		t.Fatalf("quantity after dry run = %d, want 7", q)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 4)
+
+	tests := []struct {
+		name  string
+		path  string
+		lines []string
+	}{
+		{"hubs", "/hub/export", []string{
+			"id,name,address,city,state,country,postal_code,contact_name,contact_email",
+			"1,hub_a,hub_a address,Mumbai,,India,,,",
+		}},
+		{"skus", "/sku/export?tenant_id=tenant_1", []string{
+			"id,code,name,description,tenant_id,seller_id,category,price",
+			"1,sku_a,sku_a name,,tenant_1,seller_1,,10",
+		}},
+		{"skus of another tenant", "/sku/export?tenant_id=tenant_2", []string{
+			"id,code,name,description,tenant_id,seller_id,category,price",
+		}},
+		{"inventory", "/inventory/export?hub_id=1", []string{
+			"hub_id,hub_name,sku_id,sku_code,quantity,reorder_point,safety_stock",
+			"1,hub_a,1,sku_a,4,0,0",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(t, http.MethodGet, tt.path, nil)
+			assertStatus(t, rec, http.StatusOK)
+			if ct := rec.Result().Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+				t.Fatalf("content type = %q, want text/csv", ct)
+			}
+			want := strings.Join(tt.lines, "\n") + "\n"
+			if rec.Body.String() != want {
+				t.Fatalf("body = %q, want %q", rec.Body.String(), want)
+			}
+		})
+	}
+
+	rec := s.do(t, http.MethodGet, "/inventory/export?hub_id=abc", nil)
+	assertError(t, rec, http.StatusBadRequest, constants.ErrCodeInvalidID)
+}
+
+func TestExportCSVLargerThanTheWriterBuffer(t *testing.T) {
+	s := newTestServer(t)
+	for i := 0; i < 200; i++ {
+		s.seedHub(t, fmt.Sprintf("hub_%03d", i))
+	}
+
+	rec := s.do(t, http.MethodGet, "/hub/export", nil)
+	assertStatus(t, rec, http.StatusOK)
+	if rec.Body.Len() <= 4096 {
+		t.Fatalf("body is %d bytes, want more than the 4KB writer buffer", rec.Body.Len())
+	}
+	header := rec.Result().Header
+	if ct := header.Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Fatalf("content type = %q, want text/csv", ct)
+	}
+	if cd := header.Get("Content-Disposition"); cd != `attachment; filename="hubs.csv"` {
+		t.Fatalf("content disposition = %q", cd)
+	}
+	if lines := strings.Count(rec.Body.String(), "\n"); lines != 201 {
+		t.Fatalf("got %d lines, want a header and 200 hubs", lines)
+	}
+}
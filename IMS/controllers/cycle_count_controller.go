@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+	"github.com/gin-gonic/gin"
+)
+
+type CycleCountController struct {
+	Repo         repository.CycleCountRepositoryInterface
+	MaxBatchSize int
+}
+
+func NewCycleCountController(repo repository.CycleCountRepositoryInterface, maxBatchSize int) *CycleCountController {
+	return &CycleCountController{Repo: repo, MaxBatchSize: maxBatchSize}
+}
+
+// CreateCycleCount opens a count task for a hub, optionally for one category.
+func (ctrl *CycleCountController) CreateCycleCount(c *gin.Context) {
+	var request struct {
+		HubID    uint   `json:"hub_id"`
+		Category string `json:"category"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+
+	count := models.CycleCount{HubID: request.HubID, Category: request.Category}
+	if err := validators.ValidateCycleCount(&count); err != nil {
+		respondValidation(c, err)
+		return
+	}
+	if err := ctrl.Repo.CreateCycleCount(c.Request.Context(), &count); err != nil {
+		respondError(c, err, constants.ErrCycleCountCreate)
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "cycle count opened",
+		"cycle_count_id", count.ID, "hub_id", count.HubID, "category", count.Category, "lines", len(count.Lines))
+	c.JSON(http.StatusCreated, count)
+}
+
+func (ctrl *CycleCountController) GetCycleCount(c *gin.Context) {
+	id, ok := cycleCountID(c)
+	if !ok {
+		return
+	}
+	count, err := ctrl.Repo.GetCycleCount(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, constants.ErrCycleCountView)
+		return
+	}
+	c.JSON(http.StatusOK, count)
+}
+
+func (ctrl *CycleCountController) ListCycleCounts(c *gin.Context) {
+	filter := repository.CycleCountFilter{Status: models.CycleCountStatus(c.Query("status"))}
+	switch filter.Status {
+	case "", models.CycleCountOpen, models.CycleCountApproved, models.CycleCountCancelled:
+	default:
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "status must be open, approved or cancelled", nil)
+		return
+	}
+	var err error
+	if filter.HubID, err = optionalID(c, "hub_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return
+	}
+
+	counts, err := ctrl.Repo.ListCycleCounts(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, constants.ErrCycleCountView)
+		return
+	}
+	c.JSON(http.StatusOK, counts)
+}
+
+// SubmitCounts records counted quantities; the body is a JSON array of
+// {sku_id, counted_quantity}.
+func (ctrl *CycleCountController) SubmitCounts(c *gin.Context) {
+	id, ok := cycleCountID(c)
+	if !ok {
+		return
+	}
+	var entries []models.CycleCountEntry
+	if err := c.ShouldBindJSON(&entries); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+	if err := validators.ValidateBatchSize(len(entries), ctrl.MaxBatchSize); err != nil {
+		respondValidation(c, err)
+		return
+	}
+	if err := validators.ValidateCycleCountEntries(entries); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	count, err := ctrl.Repo.SubmitCounts(c.Request.Context(), id, entries)
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
+		return
+	}
+	c.JSON(http.StatusOK, count)
+}
+
+// GetVariance lists the counted lines that differ from the system quantity.
+func (ctrl *CycleCountController) GetVariance(c *gin.Context) {
+	id, ok := cycleCountID(c)
+	if !ok {
+		return
+	}
+	count, err := ctrl.Repo.GetCycleCount(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, constants.ErrCycleCountView)
+		return
+	}
+	c.JSON(http.StatusOK, models.NewCycleCountVariance(*count))
+}
+
+// ApproveCycleCount posts the variance to stock. The approver is recorded on
+// the count.
+func (ctrl *CycleCountController) ApproveCycleCount(c *gin.Context) {
+	id, ok := cycleCountID(c)
+	if !ok {
+		return
+	}
+	var request struct {
+		ApprovedBy string `json:"approved_by"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+	request.ApprovedBy = strings.TrimSpace(request.ApprovedBy)
+	if request.ApprovedBy == "" || len(request.ApprovedBy) > 255 {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidApprover, nil)
+		return
+	}
+
+	ctrl.close(c, func(ctx context.Context) (*models.CycleCount, error) {
+		return ctrl.Repo.ApproveCycleCount(ctx, id, request.ApprovedBy)
+	})
+}
+
+func (ctrl *CycleCountController) CancelCycleCount(c *gin.Context) {
+	id, ok := cycleCountID(c)
+	if !ok {
+		return
+	}
+	ctrl.close(c, func(ctx context.Context) (*models.CycleCount, error) {
+		return ctrl.Repo.CancelCycleCount(ctx, id)
+	})
+}
+
+func (ctrl *CycleCountController) close(c *gin.Context, step func(ctx context.Context) (*models.CycleCount, error)) {
+	count, err := step(c.Request.Context())
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
+		return
+	}
+	variance := models.NewCycleCountVariance(*count)
+	slog.InfoContext(c.Request.Context(), "cycle count closed", "cycle_count_id", count.ID, "status", count.Status,
+		"approved_by", count.ApprovedBy, "adjusted_lines", len(variance.Lines), "net_variance", variance.NetVariance)
+	c.JSON(http.StatusOK, count)
+}
+
+func cycleCountID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalidID(c, err)
+		return 0, false
+	}
+	return uint(id), true
+}
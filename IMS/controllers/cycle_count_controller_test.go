@@ -0,0 +1,99 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCycleCountApprovalPostsVariance(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	skuA, skuB, skuC := s.seedSKU(t, "sku_a"), s.seedSKU(t, "sku_b"), s.seedSKU(t, "sku_c")
+	s.seedInventory(t, hub.ID, skuA.ID, 10)
+	s.seedInventory(t, hub.ID, skuB.ID, 5)
+
+	rec := s.do(t, http.MethodPost, "/inventory/cycle-counts", gin.H{"hub_id": hub.ID})
+	assertStatus(t, rec, http.StatusCreated)
+	var count models.CycleCount
+	decode(t, rec, &count)
+	if count.Status != models.CycleCountOpen || len(count.Lines) != 2 || count.Lines[0].SystemQuantity != 10 {
+		t.Fatalf("count = %+v, want an open count of sku_a and sku_b", count)
+	}
+
+	counts := []gin.H{
+		{"sku_id": skuA.ID, "counted_quantity": 7},
+		{"sku_id": skuB.ID, "counted_quantity": 5},
+		{"sku_id": skuC.ID, "counted_quantity": 2},
+	}
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/cycle-counts/1/counts", counts), http.StatusOK)
+	if got := s.quantity(t, hub.ID, skuA.ID); got != 10 {
+		t.Fatalf("sku_a quantity = %d, want 10 until approved", got)
+	}
+
+	var variance models.CycleCountVariance
+	decode(t, s.do(t, http.MethodGet, "/inventory/cycle-counts/1/variance", nil), &variance)
+	if variance.Counted != 3 || variance.Uncounted != 0 || variance.NetVariance != -1 || len(variance.Lines) != 2 {
+		t.Fatalf("variance = %+v, want sku_a -3 and sku_c +2", variance)
+	}
+
+	// Stock sold after counting is kept: approval applies the variance.
+	s.seedInventory(t, hub.ID, skuA.ID, 9)
+	rec = s.do(t, http.MethodPost, "/inventory/cycle-counts/1/approve", gin.H{"approved_by": "manager_1"})
+	assertStatus(t, rec, http.StatusOK)
+	decode(t, rec, &count)
+	if count.Status != models.CycleCountApproved || count.ApprovedBy != "manager_1" || count.ApprovedAt == nil {
+		t.Fatalf("count = %+v, want approved by manager_1", count)
+	}
+	if got := s.quantity(t, hub.ID, skuA.ID); got != 6 {
+		t.Errorf("sku_a quantity = %d, want 9 - 3", got)
+	}
+	if got := s.quantity(t, hub.ID, skuC.ID); got != 2 {
+		t.Errorf("sku_c quantity = %d, want 2 found in the count", got)
+	}
+
+	var history []models.InventoryMovement
+	decode(t, s.do(t, http.MethodGet, "/inventory/history?hub_id=1&sku_id=1", nil), &history)
+	if len(history) != 1 || history[0].Reason != models.MovementCycleCount || history[0].Quantity != -3 || *history[0].CycleCountID != 1 {
+		t.Fatalf("history = %+v, want one cycle_count adjustment", history)
+	}
+
+	assertError(t, s.do(t, http.MethodPost, "/inventory/cycle-counts/1/counts", counts), http.StatusConflict, constants.ErrCodeCycleCountNotOpen)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/cycle-counts/1/cancel", nil), http.StatusConflict, constants.ErrCodeCycleCountNotOpen)
+}
+
+func TestCycleCountValidation(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 1)
+
+	assertError(t, s.do(t, http.MethodPost, "/inventory/cycle-counts", gin.H{}), http.StatusBadRequest, constants.ErrCodeValidation)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/cycle-counts", gin.H{"hub_id": 99}), http.StatusBadRequest, constants.ErrCodeInvalidReference)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/cycle-counts/99", nil), http.StatusNotFound, "CYCLE_COUNT_NOT_FOUND")
+
+	var count models.CycleCount
+	decode(t, s.do(t, http.MethodPost, "/inventory/cycle-counts", gin.H{"hub_id": hub.ID, "category": "books"}), &count)
+	if len(count.Lines) != 0 {
+		t.Fatalf("lines = %+v, want none outside the category", count.Lines)
+	}
+
+	rec := s.do(t, http.MethodPost, "/inventory/cycle-counts/1/counts", []gin.H{
+		{"sku_id": sku.ID, "counted_quantity": -1},
+		{"sku_id": sku.ID, "counted_quantity": 1},
+	})
+	assertBatchErrors(t, rec, 0, 1)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/cycle-counts/1/counts", []gin.H{{"sku_id": sku.ID, "counted_quantity": 1}}),
+		http.StatusBadRequest, constants.ErrCodeInvalidReference)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/cycle-counts/1/approve", gin.H{}), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/cycle-counts/1/cancel", nil), http.StatusOK)
+	var open []models.CycleCount
+	decode(t, s.do(t, http.MethodGet, "/inventory/cycle-counts?status=open", nil), &open)
+	if len(open) != 0 {
+		t.Fatalf("open counts = %+v, want none after cancelling", open)
+	}
+}
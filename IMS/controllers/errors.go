@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// respondError hands err to middleware.ErrorHandler. fallback is the message
+// sent to the client when err is not a repository.Error.
+func respondError(c *gin.Context, err error, fallback string) {
+	_ = c.Error(err).SetMeta(fallback)
+}
+
+func respondBadRequest(c *gin.Context, code string, message string, err error) {
+	respondError(c, repository.NewValidationError(code, message, err), "")
+}
+
+func respondInvalidJSON(c *gin.Context, err error) {
+	respondBadRequest(c, constants.ErrCodeInvalidJSON, constants.ErrParsingJSON, err)
+}
+
+// respondValidation sends the field errors of a validators error as the
+// response details.
+func respondValidation(c *gin.Context, err error) {
+	respondError(c, repository.NewValidationError(constants.ErrCodeValidation, constants.ErrValidationFailed, err).WithDetails(err), "")
+}
+
+func respondInvalidID(c *gin.Context, err error) {
+	respondBadRequest(c, constants.ErrCodeInvalidID, constants.ErrInvalidID, err)
+}
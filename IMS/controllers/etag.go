@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/gin-gonic/gin"
+)
+
+func setETag(c *gin.Context, version uint) {
+	c.Header(constants.HeaderETag, strconv.Quote(strconv.FormatUint(uint64(version), 10)))
+}
+
+// ifMatchVersion reads the version out of the If-Match header. ETags are
+// only ever versions, so a weak W/ prefix is accepted; 0 means the header is
+// missing or was not one of our ETags.
+func ifMatchVersion(c *gin.Context) uint {
+	tag := strings.TrimPrefix(strings.TrimSpace(c.GetHeader(constants.HeaderIfMatch)), "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0
+	}
+	version, err := strconv.ParseUint(unquoted, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(version)
+}
@@ -1,8 +1,7 @@
 package controllers
 
 import (
-	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -11,22 +10,22 @@ import (
 	"github.com/Trishank-Omniful/Onboarding-Task/repository"
 	"github.com/Trishank-Omniful/Onboarding-Task/validators"
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type HubController struct {
-	Repo *repository.HubRepository
+	Repo         repository.HubRepositoryInterface
+	MaxBatchSize int
 }
 
-func NewHubController(repo *repository.HubRepository) *HubController {
-	return &HubController{Repo: repo}
+func NewHubController(repo repository.HubRepositoryInterface, maxBatchSize int) *HubController {
+	return &HubController{Repo: repo, MaxBatchSize: maxBatchSize}
 }
 
 func (ctrl *HubController) GetAllHubs(c *gin.Context) {
-	hubs, err := ctrl.Repo.GetAllHubs()
+	hubs, err := ctrl.Repo.GetAllHubs(c.Request.Context())
 	if err != nil {
-		log.Print("Failed to get all hubs: ", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrGetAllHubs})
+		slog.ErrorContext(c.Request.Context(), "failed to get all hubs", "error", err)
+		respondError(c, err, constants.ErrGetAllHubs)
 		return
 	}
 	c.JSON(http.StatusOK, hubs)
@@ -35,21 +34,18 @@ func (ctrl *HubController) GetAllHubs(c *gin.Context) {
 func (ctrl *HubController) GetHubById(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		log.Print("Invalid ID Parameter: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidID})
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
 		return
 	}
 
-	hub, err := ctrl.Repo.GetHubById(uint(id))
-
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrHubNotFound})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+	hub, err := ctrl.Repo.GetHubById(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
+	setETag(c, hub.Version)
 	c.JSON(http.StatusOK, hub)
 }
 
@@ -57,117 +53,136 @@ func (ctrl *HubController) CreateHub(c *gin.Context) {
 	var hub models.Hub
 	err := c.ShouldBindJSON(&hub)
 	if err != nil {
-		log.Print("Issue While Parsing JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": constants.ErrParsingJSON,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid json body", "error", err)
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if err := validators.ValidateHub(&hub); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		respondValidation(c, err)
 		return
 	}
 
-	err = ctrl.Repo.CreateHub(&hub)
+	err = ctrl.Repo.CreateHub(c.Request.Context(), &hub)
 	if err != nil {
-		log.Print("Failed to create hub: ", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": constants.ErrHubCreate,
-		})
+		slog.ErrorContext(c.Request.Context(), "failed to create hub", "error", err)
+		respondError(c, err, constants.ErrHubCreate)
 		return
 	}
+	setETag(c, hub.Version)
 	c.JSON(http.StatusCreated, hub)
 }
 
 func (ctrl *HubController) UpdateHub(c *gin.Context) {
 	var updatedData models.Hub
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		log.Print("Issue While Converting Id to uint: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
 		return
 	}
 	err = c.ShouldBindJSON(&updatedData)
 	if err != nil {
-		log.Print("Issue While Parsing JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid json body", "error", err)
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	updatedData.ID = uint(id)
-	err = ctrl.Repo.UpdateHub(&updatedData)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrHubNotFound})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+	updatedData.Version = ifMatchVersion(c)
+	err = ctrl.Repo.UpdateHub(c.Request.Context(), &updatedData)
+	if err != nil {
+		respondError(c, err, constants.ErrHubUpdate)
 		return
 	}
 
+	setETag(c, updatedData.Version)
 	c.JSON(http.StatusOK, updatedData)
 }
 
 func (ctrl *HubController) DeleteHub(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		log.Print("Invalid ID Parameter: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidID})
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
 		return
 	}
 
-	err = ctrl.Repo.DeleteHub(uint(id))
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrHubNotFound})
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidForceFlag, err)
 		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+	}
+
+	err = ctrl.Repo.DeleteHub(c.Request.Context(), uint(id), force)
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Hub deleted successfully"})
 }
 
+func (ctrl *HubController) RestoreHub(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
+		return
+	}
+
+	hub, err := ctrl.Repo.RestoreHub(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
+		return
+	}
+
+	setETag(c, hub.Version)
+	c.JSON(http.StatusOK, hub)
+}
+
+func (ctrl *HubController) GetDeletedHubs(c *gin.Context) {
+	deleted, err := ctrl.Repo.GetDeletedHubs(c.Request.Context())
+	if err != nil {
+		respondError(c, err, constants.ErrGetAllHubs)
+		return
+	}
+	c.JSON(http.StatusOK, deleted)
+}
+
 func (ctrl *HubController) CreateHubsBatch(c *gin.Context) {
 	var hubs []models.Hub
 	err := c.ShouldBindJSON(&hubs)
 	if err != nil {
-		log.Print("Issue While Parsing JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": constants.ErrParsingJSON,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid json body", "error", err)
+		respondInvalidJSON(c, err)
 		return
 	}
 
-	if err := validators.ValidateBatchSize(len(hubs)); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if err := validators.ValidateBatchSize(len(hubs), ctrl.MaxBatchSize); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	partial, err := isPartialBatch(c)
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidPartialFlag, err)
+		return
+	}
+	if partial {
+		ctrl.createHubsBatchPartial(c, hubs)
 		return
 	}
 
-	for i, hub := range hubs {
-		if err := validators.ValidateHub(&hub); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":     err.Error(),
-				"hub_index": i,
-			})
-			return
-		}
+	if err := validators.ValidateHubs(hubs); err != nil {
+		respondValidation(c, err)
+		return
 	}
 
-	err = ctrl.Repo.CreateHubsBatch(hubs)
+	err = ctrl.Repo.CreateHubsBatch(c.Request.Context(), hubs)
 	if err != nil {
-		log.Print("Batch hub creation failed: ", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": constants.ErrBatchOperation,
-		})
+		slog.ErrorContext(c.Request.Context(), "batch hub creation failed", "error", err)
+		respondError(c, err, constants.ErrBatchOperation)
 		return
 	}
 
@@ -177,6 +192,27 @@ func (ctrl *HubController) CreateHubsBatch(c *gin.Context) {
 	})
 }
 
+func (ctrl *HubController) createHubsBatchPartial(c *gin.Context, hubs []models.Hub) {
+	results, positions := partitionBatch(len(hubs), func(i int) error {
+		return validators.ValidateHub(&hubs[i])
+	})
+
+	valid := make([]models.Hub, len(positions))
+	for j, i := range positions {
+		valid[j] = hubs[i]
+	}
+
+	outcomes, err := ctrl.Repo.CreateHubsBatchPartial(c.Request.Context(), valid)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "partial batch hub creation failed", "error", err)
+		respondError(c, err, constants.ErrBatchOperation)
+		return
+	}
+	mergeBatchResults(results, positions, outcomes)
+
+	respondBatchResults(c, "Hub batch processed", results)
+}
+
 func (ctrl *HubController) GetHubsByIDs(c *gin.Context) {
 	var request struct {
 		IDs []uint `json:"ids"`
@@ -184,18 +220,18 @@ func (ctrl *HubController) GetHubsByIDs(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if len(request.IDs) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No IDs provided"})
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "No IDs provided", nil)
 		return
 	}
 
-	hubs, err := ctrl.Repo.GetHubsByIDs(request.IDs)
+	hubs, err := ctrl.Repo.GetHubsByIDs(c.Request.Context(), request.IDs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
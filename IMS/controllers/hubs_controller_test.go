@@ -0,0 +1,252 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+func TestGetAllHubs(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+	s.seedHub(t, "hub_b")
+
+	rec := s.do(t, http.MethodGet, "/hub", nil)
+	assertStatus(t, rec, http.StatusOK)
+
+	var hubs []models.Hub
+	decode(t, rec, &hubs)
+	if len(hubs) != 2 {
+		t.Fatalf("got %d hubs, want 2", len(hubs))
+	}
+}
+
+func TestGetHubById(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"found", "/hub/1", http.StatusOK},
+		{"not found", "/hub/99", http.StatusNotFound},
+		{"invalid id", "/hub/abc", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(t, http.MethodGet, tt.path, nil)
+			assertStatus(t, rec, tt.want)
+		})
+	}
+
+	var got models.Hub
+	decode(t, s.do(t, http.MethodGet, "/hub/1", nil), &got)
+	if got.Name != hub.Name {
+		t.Fatalf("name = %q, want %q", got.Name, hub.Name)
+	}
+}
+
+func TestCreateHub(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name string
+		body interface{}
+		want int
+	}{
+		{"valid", models.Hub{Name: "hub_a", Address: "addr"}, http.StatusCreated},
+		{"malformed json", `{"name":`, http.StatusBadRequest},
+		{"missing name", models.Hub{Address: "addr"}, http.StatusBadRequest},
+		{"missing address", models.Hub{Name: "hub_b"}, http.StatusBadRequest},
+		{"duplicate name", models.Hub{Name: "hub_a", Address: "addr"}, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(t, http.MethodPost, "/hub", tt.body)
+			assertStatus(t, rec, tt.want)
+		})
+	}
+}
+
+func TestUpdateHub(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+
+	ifMatch := func(etag string) map[string]string { return map[string]string{"If-Match": etag} }
+
+	rec := s.doWithHeaders(t, http.MethodPut, "/hub/1", models.Hub{City: "Pune"}, ifMatch(`"1"`))
+	assertStatus(t, rec, http.StatusOK)
+	if etag := rec.Header().Get("ETag"); etag != `"2"` {
+		t.Fatalf("ETag = %q, want the bumped version \"2\"", etag)
+	}
+
+	hub, err := s.hubRepo.GetHubById(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("get hub: %v", err)
+	}
+	if hub.City != "Pune" || hub.Name != "hub_a" {
+		t.Fatalf("hub = %+v, want city updated and name kept", hub)
+	}
+
+	assertError(t, s.do(t, http.MethodPut, "/hub/abc", models.Hub{}), http.StatusBadRequest, constants.ErrCodeInvalidID)
+	assertError(t, s.do(t, http.MethodPut, "/hub/1", `{"city":`), http.StatusBadRequest, constants.ErrCodeInvalidJSON)
+	assertError(t, s.doWithHeaders(t, http.MethodPut, "/hub/99", models.Hub{City: "Pune"}, ifMatch(`"1"`)), http.StatusNotFound, "HUB_NOT_FOUND")
+}
+
+func TestUpdateHubRequiresCurrentETag(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+
+	etag := s.do(t, http.MethodGet, "/hub/1", nil).Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("ETag = %q, want \"1\" for a new hub", etag)
+	}
+	assertError(t, s.do(t, http.MethodPut, "/hub/1", models.Hub{City: "Pune"}), http.StatusPreconditionRequired, constants.ErrCodePreconditionRequired)
+
+	first := s.doWithHeaders(t, http.MethodPut, "/hub/1", models.Hub{City: "Pune"}, map[string]string{"If-Match": etag})
+	assertStatus(t, first, http.StatusOK)
+
+	stale := assertError(t, s.doWithHeaders(t, http.MethodPut, "/hub/1", models.Hub{City: "Mumbai"}, map[string]string{"If-Match": etag}),
+		http.StatusPreconditionFailed, constants.ErrCodeVersionMismatch)
+	if details, _ := stale.Details.(map[string]interface{}); details["current_version"] != float64(2) {
+		t.Errorf("details = %v, want current_version 2", stale.Details)
+	}
+
+	hub, _ := s.hubRepo.GetHubById(context.Background(), 1)
+	if hub.City != "Pune" {
+		t.Fatalf("city = %q, the stale write must not overwrite the first one", hub.City)
+	}
+	weak := map[string]string{"If-Match": "W/" + first.Header().Get("ETag")}
+	assertStatus(t, s.doWithHeaders(t, http.MethodPut, "/hub/1", models.Hub{City: "Mumbai"}, weak), http.StatusOK)
+}
+
+func TestDeleteHub(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/1", nil), http.StatusOK)
+	assertError(t, s.do(t, http.MethodGet, "/hub/1", nil), http.StatusNotFound, "HUB_NOT_FOUND")
+	assertError(t, s.do(t, http.MethodDelete, "/hub/1", nil), http.StatusNotFound, "HUB_NOT_FOUND")
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/abc", nil), http.StatusBadRequest)
+}
+
+func TestDeleteHubWithStockNeedsForce(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 7)
+
+	resp := assertError(t, s.do(t, http.MethodDelete, "/hub/1", nil), http.StatusConflict, "HUB_HAS_STOCK")
+	if details, _ := resp.Details.(map[string]interface{}); details["stock"] != float64(7) {
+		t.Errorf("details = %v, want stock 7", resp.Details)
+	}
+	assertStatus(t, s.do(t, http.MethodGet, "/hub/1", nil), http.StatusOK)
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/1?force=maybe", nil), http.StatusBadRequest)
+
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/1?force=true", nil), http.StatusOK)
+	assertStatus(t, s.do(t, http.MethodGet, "/hub/1", nil), http.StatusNotFound)
+}
+
+func TestRestoreHub(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/1", nil), http.StatusOK)
+
+	var deleted []models.Hub
+	decode(t, s.do(t, http.MethodGet, "/hub/deleted", nil), &deleted)
+	if len(deleted) != 1 || deleted[0].Name != "hub_a" || !deleted[0].DeletedAt.Valid {
+		t.Fatalf("deleted hubs = %+v, want hub_a", deleted)
+	}
+
+	// The name is free again, so restoring the old hub clashes with the new one.
+	assertStatus(t, s.do(t, http.MethodPost, "/hub", models.Hub{Name: "hub_a", Address: "addr"}), http.StatusCreated)
+	assertError(t, s.do(t, http.MethodPost, "/hub/1/restore", nil), http.StatusConflict, "HUB_ALREADY_EXISTS")
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/2", nil), http.StatusOK)
+
+	rec := s.do(t, http.MethodPost, "/hub/1/restore", nil)
+	assertStatus(t, rec, http.StatusOK)
+	if rec.Header().Get("ETag") == "" {
+		t.Error("restore response has no ETag")
+	}
+	assertStatus(t, s.do(t, http.MethodGet, "/hub/1", nil), http.StatusOK)
+	assertError(t, s.do(t, http.MethodPost, "/hub/1/restore", nil), http.StatusNotFound, "HUB_NOT_FOUND")
+}
+
+func TestCreateHubsBatch(t *testing.T) {
+	s := newTestServer(t)
+
+	valid := []models.Hub{{Name: "hub_a", Address: "addr"}, {Name: "hub_b", Address: "addr"}}
+	rec := s.do(t, http.MethodPost, "/hub/batch", valid)
+	assertStatus(t, rec, http.StatusCreated)
+
+	var created struct {
+		Count int `json:"count"`
+	}
+	decode(t, rec, &created)
+	if created.Count != 2 {
+		t.Fatalf("count = %d, want 2", created.Count)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/hub/batch", []models.Hub{}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/hub/batch", `[{"name":`), http.StatusBadRequest)
+	assertError(t, s.do(t, http.MethodPost, "/hub/batch", make([]models.Hub, testMaxBatchSize+1)), http.StatusBadRequest, constants.ErrCodeValidation)
+
+	invalidBatch := []models.Hub{{Name: "hub_d"}, {Name: "hub_c", Address: "addr"}, {Address: "addr", ContactEmail: "nope"}}
+	errs := assertBatchErrors(t, s.do(t, http.MethodPost, "/hub/batch", invalidBatch), 0, 2)
+	if len(errs[1].Errors) != 2 {
+		t.Fatalf("item 2 errors = %+v, want missing name and bad email", errs[1].Errors)
+	}
+
+	duplicate := []models.Hub{{Name: "hub_e", Address: "addr"}, {Name: "hub_a", Address: "addr"}}
+	assertError(t, s.do(t, http.MethodPost, "/hub/batch", duplicate), http.StatusConflict, "HUB_ALREADY_EXISTS")
+	if _, err := s.hubRepo.GetHubByName(context.Background(), "hub_e"); err == nil {
+		t.Fatal("failed batch must not persist any hub")
+	}
+}
+
+func TestGetHubsByIDs(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+	s.seedHub(t, "hub_b")
+
+	rec := s.do(t, http.MethodPost, "/hub/batch/ids", map[string][]uint{"ids": {2, 42}})
+	assertStatus(t, rec, http.StatusOK)
+
+	var hubs []models.Hub
+	decode(t, rec, &hubs)
+	if len(hubs) != 1 || hubs[0].Name != "hub_b" {
+		t.Fatalf("hubs = %+v, want only hub_b", hubs)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/hub/batch/ids", map[string][]uint{"ids": {}}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/hub/batch/ids", `{`), http.StatusBadRequest)
+}
+
+func TestCreateHubsBatchPartial(t *testing.T) {
+	s := newTestServer(t)
+	s.seedHub(t, "hub_a")
+
+	batch := []models.Hub{
+		{Name: "hub_b", Address: "addr"},
+		{Name: "hub_a", Address: "addr"},
+		{Name: "hub_c"},
+		{Name: "hub_b", Address: "addr"},
+		{Name: "hub_d", Address: "addr"},
+	}
+	statuses, summary := decodeBatchResults(t, s.do(t, http.MethodPost, "/hub/batch?partial=true", batch))
+	assertStatuses(t, statuses,
+		models.BatchItemCreated, models.BatchItemDuplicate, models.BatchItemInvalid, models.BatchItemDuplicate, models.BatchItemCreated)
+	if summary[models.BatchItemCreated] != 2 || summary[models.BatchItemDuplicate] != 2 {
+		t.Fatalf("summary = %v", summary)
+	}
+
+	if _, err := s.hubRepo.GetHubByName(context.Background(), "hub_d"); err != nil {
+		t.Fatalf("hub_d should have been created: %v", err)
+	}
+	assertError(t, s.do(t, http.MethodPost, "/hub/batch?partial=maybe", batch), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+}
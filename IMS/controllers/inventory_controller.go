@@ -3,6 +3,8 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/Trishank-Omniful/Onboarding-Task/constants"
 	"github.com/Trishank-Omniful/Onboarding-Task/models"
@@ -12,27 +14,28 @@ import (
 )
 
 type InventoryController struct {
-	Repo *repository.InventoryRepository
+	Repo         repository.InventoryRepositoryInterface
+	MaxBatchSize int
 }
 
-func NewInventoryController(repo *repository.InventoryRepository) *InventoryController {
-	return &InventoryController{Repo: repo}
+func NewInventoryController(repo repository.InventoryRepositoryInterface, maxBatchSize int) *InventoryController {
+	return &InventoryController{Repo: repo, MaxBatchSize: maxBatchSize}
 }
 
 func (ctrl *InventoryController) UpsertInventory(c *gin.Context) {
 	var inventory models.Inventory
 	if err := c.ShouldBindJSON(&inventory); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if err := validators.ValidateInventory(&inventory); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondValidation(c, err)
 		return
 	}
 
-	if err := ctrl.Repo.UpsertInventory(&inventory); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upsert inventory"})
+	if err := ctrl.Repo.UpsertInventory(c.Request.Context(), &inventory); err != nil {
+		respondError(c, err, constants.ErrInventoryUpsert)
 		return
 	}
 
@@ -46,26 +49,26 @@ func (ctrl *InventoryController) GetInventory(c *gin.Context) {
 	if hubID != "" && skuID != "" {
 		var hubIDUint, skuIDUint uint
 		if _, err := fmt.Sscanf(hubID, "%d", &hubIDUint); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hub_id format"})
+			respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
 			return
 		}
 		if _, err := fmt.Sscanf(skuID, "%d", &skuIDUint); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sku_id format"})
+			respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid sku_id format", err)
 			return
 		}
 
-		inventory, err := ctrl.Repo.GetInventoryByHubAndSKU(hubIDUint, skuIDUint)
+		inventory, err := ctrl.Repo.GetInventoryByHubAndSKU(c.Request.Context(), hubIDUint, skuIDUint)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get inventory"})
+			respondError(c, err, constants.ErrInventoryView)
 			return
 		}
 		c.JSON(http.StatusOK, inventory)
 		return
 	}
 
-	inventory, err := ctrl.Repo.GetInventory(hubID, skuID)
+	inventory, err := ctrl.Repo.GetInventory(c.Request.Context(), hubID, skuID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get inventory"})
+		respondError(c, err, constants.ErrInventoryView)
 		return
 	}
 
@@ -75,27 +78,32 @@ func (ctrl *InventoryController) GetInventory(c *gin.Context) {
 func (ctrl *InventoryController) UpsertInventoryBatch(c *gin.Context) {
 	var inventories []models.Inventory
 	if err := c.ShouldBindJSON(&inventories); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
-	if err := validators.ValidateBatchSize(len(inventories)); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := validators.ValidateBatchSize(len(inventories), ctrl.MaxBatchSize); err != nil {
+		respondValidation(c, err)
 		return
 	}
 
-	for i, inventory := range inventories {
-		if err := validators.ValidateInventory(&inventory); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":           err.Error(),
-				"inventory_index": i,
-			})
-			return
-		}
+	partial, err := isPartialBatch(c)
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidPartialFlag, err)
+		return
+	}
+	if partial {
+		ctrl.upsertInventoryBatchPartial(c, inventories)
+		return
 	}
 
-	if err := ctrl.Repo.UpsertInventoryBatch(inventories); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrBatchOperation})
+	if err := validators.ValidateInventories(inventories); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	if err := ctrl.Repo.UpsertInventoryBatch(c.Request.Context(), inventories); err != nil {
+		respondError(c, err, constants.ErrBatchOperation)
 		return
 	}
 
@@ -105,6 +113,26 @@ func (ctrl *InventoryController) UpsertInventoryBatch(c *gin.Context) {
 	})
 }
 
+func (ctrl *InventoryController) upsertInventoryBatchPartial(c *gin.Context, inventories []models.Inventory) {
+	results, positions := partitionBatch(len(inventories), func(i int) error {
+		return validators.ValidateInventory(&inventories[i])
+	})
+
+	valid := make([]models.Inventory, len(positions))
+	for j, i := range positions {
+		valid[j] = inventories[i]
+	}
+
+	outcomes, err := ctrl.Repo.UpsertInventoryBatchPartial(c.Request.Context(), valid)
+	if err != nil {
+		respondError(c, err, constants.ErrBatchOperation)
+		return
+	}
+	mergeBatchResults(results, positions, outcomes)
+
+	respondBatchResults(c, "Inventory batch processed", results)
+}
+
 func (ctrl *InventoryController) GetInventoriesByHubAndSKUs(c *gin.Context) {
 	var request struct {
 		HubID  uint   `json:"hub_id"`
@@ -112,18 +140,18 @@ func (ctrl *InventoryController) GetInventoriesByHubAndSKUs(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if request.HubID == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "hub_id is required"})
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "hub_id is required", nil)
 		return
 	}
 
-	inventories, err := ctrl.Repo.GetInventoryWithZeroDefaults(request.HubID, request.SKUIDs)
+	inventories, err := ctrl.Repo.GetInventoryWithZeroDefaults(c.Request.Context(), request.HubID, request.SKUIDs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
@@ -138,22 +166,18 @@ func (ctrl *InventoryController) AtomicReduceInventory(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if request.HubID == 0 || request.SKUID == 0 || request.QuantityToReduce <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "hub_id, sku_id, and positive quantity_to_reduce are required"})
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "hub_id, sku_id, and positive quantity_to_reduce are required", nil)
 		return
 	}
 
-	updatedInventory, err := ctrl.Repo.AtomicReduceInventory(request.HubID, request.SKUID, request.QuantityToReduce)
+	updatedInventory, err := ctrl.Repo.AtomicReduceInventory(c.Request.Context(), request.HubID, request.SKUID, request.QuantityToReduce)
 	if err != nil {
-		if err.Error() == "insufficient inventory" {
-			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrInsufficientInventory})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrAtomicOperation})
+		respondError(c, err, constants.ErrAtomicOperation)
 		return
 	}
 
@@ -163,6 +187,189 @@ func (ctrl *InventoryController) AtomicReduceInventory(c *gin.Context) {
 	})
 }
 
+// GetInventoryHistory lists stock movements, newest first, optionally for
+// one hub and/or SKU.
+func (ctrl *InventoryController) GetInventoryHistory(c *gin.Context) {
+	hubID, err := optionalID(c, "hub_id")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return
+	}
+	skuID, err := optionalID(c, "sku_id")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid sku_id format", err)
+		return
+	}
+	limit, err := historyLimit(c)
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, fmt.Sprintf("limit must be between 1 and %d", constants.MaxHistoryLimit), err)
+		return
+	}
+
+	movements, err := ctrl.Repo.GetInventoryMovements(c.Request.Context(), hubID, skuID, limit)
+	if err != nil {
+		respondError(c, err, constants.ErrInventoryView)
+		return
+	}
+	c.JSON(http.StatusOK, movements)
+}
+
+// SetStockThresholds sets the reorder point and safety stock of a hub/SKU
+// pair. Stock at or below the reorder point raises a low-stock alert.
+func (ctrl *InventoryController) SetStockThresholds(c *gin.Context) {
+	var request struct {
+		HubID        uint `json:"hub_id"`
+		SKUID        uint `json:"sku_id"`
+		ReorderPoint int  `json:"reorder_point"`
+		SafetyStock  int  `json:"safety_stock"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+
+	inventory := models.Inventory{
+		HubID:        request.HubID,
+		SKUID:        request.SKUID,
+		ReorderPoint: request.ReorderPoint,
+		SafetyStock:  request.SafetyStock,
+	}
+	if err := validators.ValidateThresholds(&inventory); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	if err := ctrl.Repo.SetStockThresholds(c.Request.Context(), &inventory); err != nil {
+		respondError(c, err, constants.ErrThresholdUpdate)
+		return
+	}
+	c.JSON(http.StatusOK, inventory)
+}
+
+// GetInventoryAlerts lists open stock alerts, newest first. Resolved ones are
+// included with include_resolved=true.
+func (ctrl *InventoryController) GetInventoryAlerts(c *gin.Context) {
+	var filter repository.AlertFilter
+	var err error
+	if filter.HubID, err = optionalID(c, "hub_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return
+	}
+	if filter.SKUID, err = optionalID(c, "sku_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid sku_id format", err)
+		return
+	}
+	switch level := models.StockLevel(c.Query("level")); level {
+	case "", models.StockLow, models.StockBelowSafety, models.StockOutOfStock:
+		filter.Level = level
+	default:
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidStockLevel, nil)
+		return
+	}
+	if filter.IncludeResolved, err = strconv.ParseBool(c.DefaultQuery("include_resolved", "false")); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidResolvedFlag, err)
+		return
+	}
+	if filter.Limit, err = historyLimit(c); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, fmt.Sprintf("limit must be between 1 and %d", constants.MaxHistoryLimit), err)
+		return
+	}
+
+	alerts, err := ctrl.Repo.GetInventoryAlerts(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, constants.ErrAlertView)
+		return
+	}
+	c.JSON(http.StatusOK, alerts)
+}
+
+func historyLimit(c *gin.Context) (int, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constants.DefaultHistoryLimit)))
+	if err == nil && (limit <= 0 || limit > constants.MaxHistoryLimit) {
+		err = fmt.Errorf("limit %d out of range", limit)
+	}
+	return limit, err
+}
+
+type availabilityRequest struct {
+	SKUCodes []string `json:"sku_codes"`
+	Country  string   `json:"country"`
+	State    string   `json:"state"`
+}
+
+// GetAvailability totals stock per SKU across hubs for the comma-separated
+// sku_codes, optionally only in hubs of a country and/or state.
+func (ctrl *InventoryController) GetAvailability(c *gin.Context) {
+	request := availabilityRequest{Country: c.Query("country"), State: c.Query("state")}
+	for _, value := range c.QueryArray("sku_codes") {
+		request.SKUCodes = append(request.SKUCodes, strings.Split(value, ",")...)
+	}
+	ctrl.respondAvailability(c, request)
+}
+
+// GetAvailabilityBatch is GetAvailability with the codes in the body, for
+// lists too long for a URL.
+func (ctrl *InventoryController) GetAvailabilityBatch(c *gin.Context) {
+	var request availabilityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+	ctrl.respondAvailability(c, request)
+}
+
+func (ctrl *InventoryController) respondAvailability(c *gin.Context, request availabilityRequest) {
+	var codes []string
+	seen := make(map[string]bool, len(request.SKUCodes))
+	for _, code := range request.SKUCodes {
+		if code = strings.TrimSpace(code); code != "" && !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "sku_codes is required", nil)
+		return
+	}
+	if err := validators.ValidateBatchSize(len(codes), ctrl.MaxBatchSize); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	filter := repository.AvailabilityFilter{Country: request.Country, State: request.State}
+	availability, err := ctrl.Repo.GetAvailabilityBySKUCodes(c.Request.Context(), codes, filter)
+	if err != nil {
+		respondError(c, err, constants.ErrInventoryView)
+		return
+	}
+	c.JSON(http.StatusOK, availability)
+}
+
+// CheckAvailabilityBatch checks many hub/SKU lines at once and reports
+// whether the whole set can be fulfilled.
+func (ctrl *InventoryController) CheckAvailabilityBatch(c *gin.Context) {
+	var lines []models.AvailabilityLine
+	if err := c.ShouldBindJSON(&lines); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+	if err := validators.ValidateBatchSize(len(lines), ctrl.MaxBatchSize); err != nil {
+		respondValidation(c, err)
+		return
+	}
+	if err := validators.ValidateAvailabilityLines(lines); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	check, err := ctrl.Repo.CheckAvailabilityBatch(c.Request.Context(), lines)
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
+		return
+	}
+	c.JSON(http.StatusOK, check)
+}
+
 func (ctrl *InventoryController) CheckInventoryAvailability(c *gin.Context) {
 	var request struct {
 		HubID            uint `json:"hub_id"`
@@ -171,18 +378,18 @@ func (ctrl *InventoryController) CheckInventoryAvailability(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if request.HubID == 0 || request.SKUID == 0 || request.RequiredQuantity <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "hub_id, sku_id, and positive required_quantity are required"})
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "hub_id, sku_id, and positive required_quantity are required", nil)
 		return
 	}
 
-	available, err := ctrl.Repo.CheckInventoryAvailability(request.HubID, request.SKUID, request.RequiredQuantity)
+	available, err := ctrl.Repo.CheckInventoryAvailability(c.Request.Context(), request.HubID, request.SKUID, request.RequiredQuantity)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
@@ -0,0 +1,417 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestUpsertInventory(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+
+	tests := []struct {
+		name string
+		body interface{}
+		want int
+	}{
+		{"create", models.Inventory{HubID: hub.ID, SKUID: sku.ID, Quantity: 5}, http.StatusOK},
+		{"update", models.Inventory{HubID: hub.ID, SKUID: sku.ID, Quantity: 8}, http.StatusOK},
+		{"malformed json", `{"hub_id":`, http.StatusBadRequest},
+		{"missing hub", models.Inventory{SKUID: sku.ID, Quantity: 1}, http.StatusBadRequest},
+		{"negative quantity", models.Inventory{HubID: hub.ID, SKUID: sku.ID, Quantity: -1}, http.StatusBadRequest},
+		{"unknown sku", models.Inventory{HubID: hub.ID, SKUID: 99, Quantity: 1}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertStatus(t, s.do(t, http.MethodPost, "/inventory", tt.body), tt.want)
+		})
+	}
+
+	inventory, err := s.inventoryRepo.GetInventoryByHubAndSKU(context.Background(), hub.ID, sku.ID)
+	if err != nil {
+		t.Fatalf("get inventory: %v", err)
+	}
+	if inventory.Quantity != 8 {
+		t.Fatalf("quantity = %d, want 8", inventory.Quantity)
+	}
+}
+
+func TestGetInventory(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	skuA := s.seedSKU(t, "sku_a")
+	skuB := s.seedSKU(t, "sku_b")
+	s.seedInventory(t, hub.ID, skuA.ID, 3)
+
+	var single models.Inventory
+	rec := s.do(t, http.MethodGet, "/inventory?hub_id=1&sku_id=2", nil)
+	assertStatus(t, rec, http.StatusOK)
+	decode(t, rec, &single)
+	if single.SKUID != skuB.ID || single.Quantity != 0 {
+		t.Fatalf("inventory = %+v, want zero default for sku_b", single)
+	}
+
+	var list []models.Inventory
+	rec = s.do(t, http.MethodGet, "/inventory?hub_id=1", nil)
+	assertStatus(t, rec, http.StatusOK)
+	decode(t, rec, &list)
+	if len(list) != 1 || list[0].Quantity != 3 {
+		t.Fatalf("inventories = %+v, want one row with quantity 3", list)
+	}
+
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory?hub_id=x&sku_id=1", nil), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory?hub_id=1&sku_id=x", nil), http.StatusBadRequest)
+	assertError(t, s.do(t, http.MethodGet, "/inventory?hub_id=99&sku_id=1", nil), http.StatusNotFound, "HUB_NOT_FOUND")
+}
+
+func TestUpsertInventoryBatch(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	skuA := s.seedSKU(t, "sku_a")
+	skuB := s.seedSKU(t, "sku_b")
+
+	batch := []models.Inventory{
+		{HubID: hub.ID, SKUID: skuA.ID, Quantity: 1},
+		{HubID: hub.ID, SKUID: skuB.ID, Quantity: 2},
+	}
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/batch", batch), http.StatusOK)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/batch", []models.Inventory{}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/batch", `[`), http.StatusBadRequest)
+
+	assertBatchErrors(t, s.do(t, http.MethodPost, "/inventory/batch", []models.Inventory{batch[0], {HubID: hub.ID}}), 1)
+
+	unknown := []models.Inventory{{HubID: hub.ID, SKUID: skuA.ID, Quantity: 50}, {HubID: hub.ID, SKUID: 99, Quantity: 1}}
+	assertError(t, s.do(t, http.MethodPost, "/inventory/batch", unknown), http.StatusBadRequest, constants.ErrCodeInvalidReference)
+	inventory, _ := s.inventoryRepo.GetInventoryByHubAndSKU(context.Background(), hub.ID, skuA.ID)
+	if inventory.Quantity != 1 {
+		t.Fatalf("quantity = %d, want failed batch rolled back to 1", inventory.Quantity)
+	}
+}
+
+func TestGetInventoriesByHubAndSKUs(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	skuA := s.seedSKU(t, "sku_a")
+	skuB := s.seedSKU(t, "sku_b")
+	s.seedInventory(t, hub.ID, skuA.ID, 4)
+
+	rec := s.do(t, http.MethodPost, "/inventory/batch/hub-skus", map[string]interface{}{
+		"hub_id":  hub.ID,
+		"sku_ids": []uint{skuA.ID, skuB.ID},
+	})
+	assertStatus(t, rec, http.StatusOK)
+
+	var inventories []models.Inventory
+	decode(t, rec, &inventories)
+	if len(inventories) != 2 || inventories[0].Quantity != 4 || inventories[1].Quantity != 0 {
+		t.Fatalf("inventories = %+v, want [4, 0]", inventories)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/batch/hub-skus", map[string]interface{}{"sku_ids": []uint{1}}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/batch/hub-skus", `{`), http.StatusBadRequest)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/batch/hub-skus", map[string]interface{}{"hub_id": 99, "sku_ids": []uint{1}}), http.StatusNotFound, "HUB_NOT_FOUND")
+}
+
+func TestAtomicReduceInventory(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 5)
+
+	reduce := func(quantity int) map[string]interface{} {
+		return map[string]interface{}{"hub_id": hub.ID, "sku_id": sku.ID, "quantity_to_reduce": quantity}
+	}
+
+	rec := s.do(t, http.MethodPost, "/inventory/atomic/reduce", reduce(3))
+	assertStatus(t, rec, http.StatusOK)
+	var resp struct {
+		UpdatedInventory models.Inventory `json:"updated_inventory"`
+	}
+	decode(t, rec, &resp)
+	if resp.UpdatedInventory.Quantity != 2 {
+		t.Fatalf("quantity = %d, want 2", resp.UpdatedInventory.Quantity)
+	}
+
+	conflict := assertError(t, s.do(t, http.MethodPost, "/inventory/atomic/reduce", reduce(3)), http.StatusConflict, constants.ErrCodeInsufficientInventory)
+	if details, _ := conflict.Details.(map[string]interface{}); details["available"] != float64(2) {
+		t.Fatalf("details = %v, want available 2", conflict.Details)
+	}
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/atomic/reduce", reduce(0)), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/atomic/reduce", `{`), http.StatusBadRequest)
+
+	missing := map[string]interface{}{"hub_id": hub.ID, "sku_id": 99, "quantity_to_reduce": 1}
+	assertError(t, s.do(t, http.MethodPost, "/inventory/atomic/reduce", missing), http.StatusNotFound, "INVENTORY_NOT_FOUND")
+}
+
+func TestCheckInventoryAvailability(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 5)
+
+	tests := []struct {
+		name      string
+		required  int
+		available bool
+	}{
+		{"enough stock", 5, true},
+		{"not enough stock", 6, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(t, http.MethodPost, "/inventory/check-availability", map[string]interface{}{
+				"hub_id": hub.ID, "sku_id": sku.ID, "required_quantity": tt.required,
+			})
+			assertStatus(t, rec, http.StatusOK)
+			var resp struct {
+				Available bool `json:"available"`
+			}
+			decode(t, rec, &resp)
+			if resp.Available != tt.available {
+				t.Fatalf("available = %v, want %v", resp.Available, tt.available)
+			}
+		})
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/check-availability", map[string]interface{}{"hub_id": hub.ID}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/check-availability", `{`), http.StatusBadRequest)
+}
+
+func TestCheckAvailabilityBatch(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	other := s.seedSKU(t, "sku_b")
+	s.seedInventory(t, hub.ID, sku.ID, 5)
+	s.seedInventory(t, hub.ID, other.ID, 1)
+
+	check := func(lines []models.AvailabilityLine) models.AvailabilityCheck {
+		t.Helper()
+		rec := s.do(t, http.MethodPost, "/inventory/check-availability/batch", lines)
+		assertStatus(t, rec, http.StatusOK)
+		var resp models.AvailabilityCheck
+		decode(t, rec, &resp)
+		return resp
+	}
+
+	resp := check([]models.AvailabilityLine{
+		{HubID: hub.ID, SKUID: sku.ID, RequiredQuantity: 3},
+		{HubCode: "hub_a", SKUCode: "sku_b", RequiredQuantity: 1},
+	})
+	if !resp.CanFulfil || len(resp.Lines) != 2 || resp.Lines[1].SKUID != other.ID || resp.Lines[1].AvailableQuantity != 1 {
+		t.Fatalf("check = %+v, want both lines resolved and fulfillable", resp)
+	}
+
+	resp = check([]models.AvailabilityLine{
+		{HubID: hub.ID, SKUID: sku.ID, RequiredQuantity: 3},
+		{HubCode: "hub_a", SKUCode: "sku_a", RequiredQuantity: 3},
+	})
+	if resp.CanFulfil || !resp.Lines[0].Available || !resp.Lines[1].Available {
+		t.Fatalf("check = %+v, want each line available but not both together", resp)
+	}
+
+	resp = check([]models.AvailabilityLine{{HubCode: "missing", SKUCode: "sku_a", RequiredQuantity: 1}})
+	if resp.CanFulfil || resp.Lines[0].Reason != constants.ErrInvalidReference || resp.Lines[0].HubCode != "missing" {
+		t.Fatalf("check = %+v, want the unknown hub reported", resp)
+	}
+
+	assertBatchErrors(t, s.do(t, http.MethodPost, "/inventory/check-availability/batch", []models.AvailabilityLine{
+		{HubID: hub.ID, SKUID: sku.ID, RequiredQuantity: 1},
+		{SKUID: sku.ID},
+	}), 1)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/check-availability/batch", []models.AvailabilityLine{}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/check-availability/batch", `[`), http.StatusBadRequest)
+}
+
+func TestUpsertInventoryBatchPartial(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	skuA := s.seedSKU(t, "sku_a")
+	skuB := s.seedSKU(t, "sku_b")
+	s.seedInventory(t, hub.ID, skuA.ID, 1)
+
+	batch := []models.Inventory{
+		{HubID: hub.ID, SKUID: skuA.ID, Quantity: 10},
+		{HubID: hub.ID, SKUID: skuB.ID, Quantity: 20},
+		{HubID: hub.ID, SKUID: 99, Quantity: 1},
+		{HubID: hub.ID, SKUID: skuB.ID, Quantity: 30},
+		{HubID: hub.ID, SKUID: skuB.ID, Quantity: -1},
+	}
+	statuses, _ := decodeBatchResults(t, s.do(t, http.MethodPost, "/inventory/batch?partial=true", batch))
+	assertStatuses(t, statuses,
+		models.BatchItemUpdated, models.BatchItemDuplicate, models.BatchItemInvalid, models.BatchItemCreated, models.BatchItemInvalid)
+
+	inventory, _ := s.inventoryRepo.GetInventoryByHubAndSKU(context.Background(), hub.ID, skuB.ID)
+	if inventory.Quantity != 30 {
+		t.Fatalf("quantity = %d, want last occurrence 30", inventory.Quantity)
+	}
+}
+
+func TestStockThresholdsRaiseAlerts(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 20)
+
+	thresholds := gin.H{"hub_id": hub.ID, "sku_id": sku.ID, "reorder_point": 10, "safety_stock": 4}
+	assertStatus(t, s.do(t, http.MethodPut, "/inventory/thresholds", thresholds), http.StatusOK)
+	s.seedInventory(t, hub.ID, sku.ID, 15)
+	if len(s.alerts.published) != 0 {
+		t.Fatalf("published %+v above the reorder point", s.alerts.published)
+	}
+
+	reduce := func(quantity int) {
+		t.Helper()
+		body := gin.H{"hub_id": hub.ID, "sku_id": sku.ID, "quantity_to_reduce": quantity}
+		assertStatus(t, s.do(t, http.MethodPost, "/inventory/atomic/reduce", body), http.StatusOK)
+	}
+	reduce(7)
+	reduce(1)
+	reduce(5)
+	reduce(2)
+
+	published := s.alerts.published
+	if len(published) != 3 || published[0].Level != models.StockLow ||
+		published[1].Level != models.StockBelowSafety || published[2].Level != models.StockOutOfStock {
+		t.Fatalf("published = %+v, want low_stock, below_safety_stock then out_of_stock", published)
+	}
+	if published[0].BelowSafetyStock || !published[1].BelowSafetyStock || published[1].Quantity != 2 {
+		t.Errorf("alerts = %+v, want only the second below safety stock at quantity 2", published)
+	}
+
+	var alerts []models.InventoryAlert
+	decode(t, s.do(t, http.MethodGet, "/inventory/alerts", nil), &alerts)
+	if len(alerts) != 1 || alerts[0].Level != models.StockOutOfStock {
+		t.Fatalf("open alerts = %+v, want only out_of_stock", alerts)
+	}
+
+	s.seedInventory(t, hub.ID, sku.ID, 50)
+	decode(t, s.do(t, http.MethodGet, "/inventory/alerts", nil), &alerts)
+	if len(alerts) != 0 {
+		t.Fatalf("open alerts after restock = %+v, want none", alerts)
+	}
+	decode(t, s.do(t, http.MethodGet, "/inventory/alerts?include_resolved=true&level=low_stock", nil), &alerts)
+	if len(alerts) != 1 || alerts[0].ResolvedAt == nil {
+		t.Fatalf("resolved low stock alerts = %+v, want one", alerts)
+	}
+}
+
+func TestSafetyStockWithoutReorderPoint(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, hub.ID, sku.ID, 10)
+
+	thresholds := gin.H{"hub_id": hub.ID, "sku_id": sku.ID, "safety_stock": 4}
+	assertStatus(t, s.do(t, http.MethodPut, "/inventory/thresholds", thresholds), http.StatusOK)
+	body := gin.H{"hub_id": hub.ID, "sku_id": sku.ID, "quantity_to_reduce": 7}
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/atomic/reduce", body), http.StatusOK)
+
+	if len(s.alerts.published) != 1 || s.alerts.published[0].Level != models.StockBelowSafety {
+		t.Fatalf("published = %+v, want a below_safety_stock alert", s.alerts.published)
+	}
+}
+
+func TestSetStockThresholdsValidation(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+
+	rec := s.do(t, http.MethodPut, "/inventory/thresholds", gin.H{"hub_id": hub.ID, "sku_id": sku.ID, "reorder_point": 2})
+	assertStatus(t, rec, http.StatusOK)
+	var inventory models.Inventory
+	decode(t, rec, &inventory)
+	if inventory.Quantity != 0 || inventory.ReorderPoint != 2 {
+		t.Fatalf("inventory = %+v, want an empty row with reorder point 2", inventory)
+	}
+	if len(s.alerts.published) != 1 || s.alerts.published[0].Level != models.StockOutOfStock {
+		t.Fatalf("published = %+v, want the empty pair reported out of stock", s.alerts.published)
+	}
+
+	assertError(t, s.do(t, http.MethodPut, "/inventory/thresholds", gin.H{"hub_id": hub.ID, "sku_id": sku.ID, "reorder_point": 2, "safety_stock": 5}),
+		http.StatusBadRequest, constants.ErrCodeValidation)
+	assertStatus(t, s.do(t, http.MethodPut, "/inventory/thresholds", gin.H{"hub_id": hub.ID, "sku_id": 99, "reorder_point": 2}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPut, "/inventory/thresholds", `{`), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory/alerts?level=critical", nil), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory/alerts?include_resolved=maybe", nil), http.StatusBadRequest)
+}
+
+func TestGetAvailability(t *testing.T) {
+	s := newTestServer(t)
+	mumbai := s.seedHub(t, "hub_mumbai")
+	delhi := models.Hub{Name: "hub_delhi", Address: "addr", City: "Delhi", State: "Delhi", Country: "India"}
+	if err := s.hubRepo.CreateHub(context.Background(), &delhi); err != nil {
+		t.Fatalf("seed hub: %v", err)
+	}
+	skuA := s.seedSKU(t, "sku_a")
+	s.seedSKU(t, "sku_b")
+	s.seedInventory(t, mumbai.ID, skuA.ID, 3)
+	s.seedInventory(t, delhi.ID, skuA.ID, 5)
+
+	var availability []models.SKUAvailability
+	decode(t, s.do(t, http.MethodGet, "/inventory/availability?sku_codes=sku_a,sku_b,missing", nil), &availability)
+	if len(availability) != 2 {
+		t.Fatalf("availability = %+v, want sku_a and sku_b", availability)
+	}
+	a, b := availability[0], availability[1]
+	if a.SKUCode != "sku_a" || a.Total != 8 || !a.InStock || len(a.Hubs) != 2 || a.Hubs[0].HubName != "hub_delhi" {
+		t.Errorf("sku_a = %+v, want 8 across two hubs, largest first", a)
+	}
+	if b.Total != 0 || b.InStock || len(b.Hubs) != 0 {
+		t.Errorf("sku_b = %+v, want out of stock", b)
+	}
+
+	decode(t, s.do(t, http.MethodPost, "/inventory/availability", gin.H{"sku_codes": []string{"sku_a"}, "state": "delhi"}), &availability)
+	if len(availability) != 1 || availability[0].Total != 5 {
+		t.Fatalf("availability in Delhi = %+v, want 5", availability)
+	}
+
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory/availability", nil), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/availability", `{`), http.StatusBadRequest)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/availability?sku_codes=a,b,c,d,e,f", nil), http.StatusBadRequest, constants.ErrCodeValidation)
+}
+
+func TestGetValuation(t *testing.T) {
+	s := newTestServer(t)
+	hubA, hubB := s.seedHub(t, "hub_a"), s.seedHub(t, "hub_b")
+	priced := s.seedSKU(t, "sku_a")
+	unpriced := models.SKU{Code: "sku_b", Name: "sku_b", TenantId: "tenant_1", SellerId: "seller_1"}
+	if err := s.skuRepo.CreateSku(context.Background(), &unpriced); err != nil {
+		t.Fatalf("seed sku: %v", err)
+	}
+	s.seedInventory(t, hubA.ID, priced.ID, 3)
+	s.seedInventory(t, hubA.ID, unpriced.ID, 4)
+	s.seedInventory(t, hubB.ID, unpriced.ID, 2)
+
+	rec := s.do(t, http.MethodGet, "/inventory/valuation?group_by=hub", nil)
+	assertStatus(t, rec, http.StatusOK)
+	var report models.ValuationReport
+	decode(t, rec, &report)
+	if report.TotalQuantity != 9 || report.UnpricedQuantity != 6 || report.TotalValue != 30 || len(report.Rows) != 2 {
+		t.Fatalf("report = %+v, want 9 units of which 6 unpriced, valued 30", report)
+	}
+	if a := report.Rows[0]; a.HubID != hubA.ID || a.Value == nil || *a.Value != 30 || a.UnpricedSKUs != 1 {
+		t.Errorf("hub_a row = %+v", a)
+	}
+	if b := report.Rows[1]; b.HubID != hubB.ID || b.Value != nil || b.UnpricedQuantity != 2 {
+		t.Errorf("hub_b row = %+v, want a null value", b)
+	}
+
+	rec = s.do(t, http.MethodGet, "/inventory/valuation?group_by=hub,tenant&format=csv", nil)
+	assertStatus(t, rec, http.StatusOK)
+	want := "hub_id,hub_name,tenant_id,quantity,unpriced_quantity,unpriced_skus,value\n" +
+		"1,hub_a,tenant_1,7,4,1,30.00\n" +
+		"2,hub_b,tenant_1,2,2,1,\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("csv =\n%s\nwant\n%s", got, want)
+	}
+
+	assertError(t, s.do(t, http.MethodGet, "/inventory/valuation?group_by=warehouse", nil), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/valuation?format=xml", nil), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+}
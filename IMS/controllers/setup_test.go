@@ -0,0 +1,218 @@
+package controllers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/controllers"
+	"github.com/Trishank-Omniful/Onboarding-Task/middleware"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/ratelimit"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository/memory"
+	"github.com/Trishank-Omniful/Onboarding-Task/routes"
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+	"github.com/gin-gonic/gin"
+)
+
+type testServer struct {
+	router        *gin.Engine
+	hubRepo       *memory.HubRepository
+	skuRepo       *memory.SkuRepository
+	inventoryRepo *memory.InventoryRepository
+	snapshotRepo  *memory.SnapshotRepository
+	alerts        *alertRecorder
+}
+
+type alertRecorder struct {
+	published []models.InventoryAlert
+}
+
+func (r *alertRecorder) PublishAlerts(_ context.Context, alerts []models.InventoryAlert) error {
+	r.published = append(r.published, alerts...)
+	return nil
+}
+
+// testMaxBatchSize is kept small so the batch limit is cheap to exceed.
+const testMaxBatchSize = 5
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	hubRepo := memory.NewHubRepository()
+	skuRepo := memory.NewSkuRepository()
+	inventoryRepo := memory.NewInventoryRepository(skuRepo, hubRepo)
+	alerts := &alertRecorder{}
+	inventoryRepo.Alerts = alerts
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	IMS := router.Group("/api/v1/ims")
+	routes.RegisterHubRoutes(IMS, controllers.NewHubController(hubRepo, testMaxBatchSize))
+	routes.RegisterSkuRoutes(IMS, controllers.NewSkuController(skuRepo, testMaxBatchSize))
+	routes.RegisterInventoryRoutes(IMS, controllers.NewInventoryController(inventoryRepo, testMaxBatchSize))
+	routes.RegisterTransferRoutes(IMS, controllers.NewTransferController(memory.NewTransferRepository(inventoryRepo)))
+	routes.RegisterCycleCountRoutes(IMS, controllers.NewCycleCountController(memory.NewCycleCountRepository(inventoryRepo), testMaxBatchSize))
+	snapshotRepo := memory.NewSnapshotRepository(inventoryRepo)
+	routes.RegisterSnapshotRoutes(IMS, controllers.NewSnapshotController(snapshotRepo))
+
+	return &testServer{
+		router:        router,
+		hubRepo:       hubRepo,
+		skuRepo:       skuRepo,
+		inventoryRepo: inventoryRepo,
+		snapshotRepo:  snapshotRepo,
+		alerts:        alerts,
+	}
+}
+
+func TestDefaultRateLimitGroupsListRegisteredRoutes(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), config.Default().RateLimit)
+	if err := limiter.CheckRoutes(newTestServer(t).router.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (s *testServer) do(t *testing.T, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return s.doWithHeaders(t, method, path, body, nil)
+}
+
+func (s *testServer) doWithHeaders(t *testing.T, method, path string, body interface{}, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload []byte
+	switch b := body.(type) {
+	case nil:
+	case string:
+		payload = []byte(b)
+	default:
+		var err error
+		if payload, err = json.Marshal(b); err != nil {
+			t.Fatalf("marshal request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, "/api/v1/ims"+path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *testServer) seedHub(t *testing.T, name string) models.Hub {
+	t.Helper()
+	hub := models.Hub{Name: name, Address: name + " address", City: "Mumbai", Country: "India"}
+	if err := s.hubRepo.CreateHub(context.Background(), &hub); err != nil {
+		t.Fatalf("seed hub: %v", err)
+	}
+	return hub
+}
+
+func (s *testServer) seedSKU(t *testing.T, code string) models.SKU {
+	t.Helper()
+	sku := models.SKU{Code: code, Name: code + " name", TenantId: "tenant_1", SellerId: "seller_1", Price: models.ToNullFloat64(10)}
+	if err := s.skuRepo.CreateSku(context.Background(), &sku); err != nil {
+		t.Fatalf("seed sku: %v", err)
+	}
+	return sku
+}
+
+func (s *testServer) seedInventory(t *testing.T, hubID, skuID uint, quantity int) {
+	t.Helper()
+	if err := s.inventoryRepo.UpsertInventory(context.Background(), &models.Inventory{HubID: hubID, SKUID: skuID, Quantity: quantity}); err != nil {
+		t.Fatalf("seed inventory: %v", err)
+	}
+}
+
+func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, want, rec.Body.String())
+	}
+}
+
+func assertError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) middleware.ErrorResponse {
+	t.Helper()
+	assertStatus(t, rec, status)
+	var resp middleware.ErrorResponse
+	decode(t, rec, &resp)
+	if resp.Code != code {
+		t.Fatalf("code = %q, want %q; body: %s", resp.Code, code, rec.Body.String())
+	}
+	return resp
+}
+
+func assertBatchErrors(t *testing.T, rec *httptest.ResponseRecorder, wantIndexes ...int) validators.BatchErrors {
+	t.Helper()
+	assertStatus(t, rec, http.StatusBadRequest)
+	var resp struct {
+		Code    string                 `json:"code"`
+		Details validators.BatchErrors `json:"details"`
+	}
+	decode(t, rec, &resp)
+	if resp.Code != constants.ErrCodeValidation {
+		t.Fatalf("code = %q, want %q", resp.Code, constants.ErrCodeValidation)
+	}
+	if len(resp.Details) != len(wantIndexes) {
+		t.Fatalf("got %d invalid items, want %d; body: %s", len(resp.Details), len(wantIndexes), rec.Body.String())
+	}
+	for i, index := range wantIndexes {
+		if resp.Details[i].Index != index {
+			t.Fatalf("invalid item %d has index %d, want %d", i, resp.Details[i].Index, index)
+		}
+	}
+	return resp.Details
+}
+
+func decodeBatchResults(t *testing.T, rec *httptest.ResponseRecorder) ([]models.BatchItemStatus, map[models.BatchItemStatus]int) {
+	t.Helper()
+	assertStatus(t, rec, http.StatusOK)
+	var resp struct {
+		Summary map[models.BatchItemStatus]int `json:"summary"`
+		Results []models.BatchItemResult       `json:"results"`
+	}
+	decode(t, rec, &resp)
+
+	statuses := make([]models.BatchItemStatus, len(resp.Results))
+	for i, result := range resp.Results {
+		if result.Index != i {
+			t.Fatalf("results[%d].Index = %d", i, result.Index)
+		}
+		statuses[i] = result.Status
+	}
+	return statuses, resp.Summary
+}
+
+func assertStatuses(t *testing.T, got []models.BatchItemStatus, want ...models.BatchItemStatus) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("statuses = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("statuses = %v, want %v", got, want)
+		}
+	}
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+	}
+}
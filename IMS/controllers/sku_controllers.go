@@ -1,8 +1,7 @@
 package controllers
 
 import (
-	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -11,22 +10,22 @@ import (
 	"github.com/Trishank-Omniful/Onboarding-Task/repository"
 	"github.com/Trishank-Omniful/Onboarding-Task/validators"
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type SkuController struct {
-	Repo *repository.SkuRepository
+	Repo         repository.SkuRepositoryInterface
+	MaxBatchSize int
 }
 
-func NewSkuController(repo *repository.SkuRepository) *SkuController {
-	return &SkuController{Repo: repo}
+func NewSkuController(repo repository.SkuRepositoryInterface, maxBatchSize int) *SkuController {
+	return &SkuController{Repo: repo, MaxBatchSize: maxBatchSize}
 }
 
 func (ctrl *SkuController) GetAllSkus(c *gin.Context) {
-	skus, err := ctrl.Repo.GetAllSkus()
+	skus, err := ctrl.Repo.GetAllSkus(c.Request.Context())
 	if err != nil {
-		log.Print("Failed to get all SKUs: ", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrGetAllSKUs})
+		slog.ErrorContext(c.Request.Context(), "failed to get all SKUs", "error", err)
+		respondError(c, err, constants.ErrGetAllSKUs)
 		return
 	}
 	c.JSON(http.StatusOK, skus)
@@ -35,21 +34,18 @@ func (ctrl *SkuController) GetAllSkus(c *gin.Context) {
 func (ctrl *SkuController) GetSkuById(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		log.Print("Invalid ID Parameter: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidID})
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
 		return
 	}
 
-	sku, err := ctrl.Repo.GetSkuById(uint(id))
-
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSKUNotFound})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+	sku, err := ctrl.Repo.GetSkuById(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
+	setETag(c, sku.Version)
 	c.JSON(http.StatusOK, sku)
 }
 
@@ -57,83 +53,103 @@ func (ctrl *SkuController) CreateSku(c *gin.Context) {
 	var sku models.SKU
 	err := c.ShouldBindJSON(&sku)
 	if err != nil {
-		log.Print("Issue While Parsing JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": constants.ErrParsingJSON,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid json body", "error", err)
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	if err := validators.ValidateSKU(&sku); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		respondValidation(c, err)
 		return
 	}
 
-	err = ctrl.Repo.CreateSku(&sku)
+	err = ctrl.Repo.CreateSku(c.Request.Context(), &sku)
 	if err != nil {
-		log.Print("Failed to create SKU: ", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": constants.ErrSKUCreate,
-		})
+		slog.ErrorContext(c.Request.Context(), "failed to create SKU", "error", err)
+		respondError(c, err, constants.ErrSKUCreate)
 		return
 	}
+	setETag(c, sku.Version)
 	c.JSON(http.StatusCreated, sku)
 }
 
 func (ctrl *SkuController) UpdateSku(c *gin.Context) {
 	var updatedData models.SKU
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		log.Print("Issue While Converting Id to uint: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
 		return
 	}
 	err = c.ShouldBindJSON(&updatedData)
 	if err != nil {
-		log.Print("Issue While Parsing JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid json body", "error", err)
+		respondInvalidJSON(c, err)
 		return
 	}
 
 	updatedData.ID = uint(id)
-	err = ctrl.Repo.UpdateSku(&updatedData)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSKUNotFound})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+	updatedData.Version = ifMatchVersion(c)
+	err = ctrl.Repo.UpdateSku(c.Request.Context(), &updatedData)
+	if err != nil {
+		respondError(c, err, constants.ErrSKUUpdate)
 		return
 	}
 
+	setETag(c, updatedData.Version)
 	c.JSON(http.StatusOK, updatedData)
 }
 
 func (ctrl *SkuController) DeleteSku(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		log.Print("Invalid ID Parameter: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidID})
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
 		return
 	}
 
-	err = ctrl.Repo.DeleteSku(uint(id))
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSKUNotFound})
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidForceFlag, err)
 		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+	}
+
+	err = ctrl.Repo.DeleteSku(c.Request.Context(), uint(id), force)
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "SKU deleted successfully"})
 }
 
+func (ctrl *SkuController) RestoreSku(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "invalid id parameter", "error", err)
+		respondInvalidID(c, err)
+		return
+	}
+
+	sku, err := ctrl.Repo.RestoreSku(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
+		return
+	}
+
+	setETag(c, sku.Version)
+	c.JSON(http.StatusOK, sku)
+}
+
+func (ctrl *SkuController) GetDeletedSkus(c *gin.Context) {
+	deleted, err := ctrl.Repo.GetDeletedSkus(c.Request.Context())
+	if err != nil {
+		respondError(c, err, constants.ErrGetAllSKUs)
+		return
+	}
+	c.JSON(http.StatusOK, deleted)
+}
+
 func (ctrl *SkuController) GetSkusByTenantAndSeller(c *gin.Context) {
 	var body struct {
 		TenantID string   `json:"tenant_id"`
@@ -142,13 +158,13 @@ func (ctrl *SkuController) GetSkusByTenantAndSeller(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		respondInvalidJSON(c, err)
 		return
 	}
 
-	skus, err := ctrl.Repo.GetSkusByTenantAndSeller(body.TenantID, body.SellerID, body.SkuCodes)
+	skus, err := ctrl.Repo.GetSkusByTenantAndSeller(c.Request.Context(), body.TenantID, body.SellerID, body.SkuCodes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get SKUs"})
+		respondError(c, err, "Failed to get SKUs")
 		return
 	}
 
@@ -159,36 +175,35 @@ func (ctrl *SkuController) CreateSKUsBatch(c *gin.Context) {
 	var skus []models.SKU
 	err := c.ShouldBindJSON(&skus)
 	if err != nil {
-		log.Print("Issue While Parsing JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": constants.ErrParsingJSON,
-		})
+		slog.WarnContext(c.Request.Context(), "invalid json body", "error", err)
+		respondInvalidJSON(c, err)
 		return
 	}
 
-	if err := validators.ValidateBatchSize(len(skus)); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if err := validators.ValidateBatchSize(len(skus), ctrl.MaxBatchSize); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	partial, err := isPartialBatch(c)
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidPartialFlag, err)
+		return
+	}
+	if partial {
+		ctrl.createSKUsBatchPartial(c, skus)
 		return
 	}
 
-	for i, sku := range skus {
-		if err := validators.ValidateSKU(&sku); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":     err.Error(),
-				"sku_index": i,
-			})
-			return
-		}
+	if err := validators.ValidateSKUs(skus); err != nil {
+		respondValidation(c, err)
+		return
 	}
 
-	err = ctrl.Repo.CreateSKUsBatch(skus)
+	err = ctrl.Repo.CreateSKUsBatch(c.Request.Context(), skus)
 	if err != nil {
-		log.Print("Batch SKU creation failed: ", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": constants.ErrBatchOperation,
-		})
+		slog.ErrorContext(c.Request.Context(), "batch SKU creation failed", "error", err)
+		respondError(c, err, constants.ErrBatchOperation)
 		return
 	}
 
@@ -198,6 +213,27 @@ func (ctrl *SkuController) CreateSKUsBatch(c *gin.Context) {
 	})
 }
 
+func (ctrl *SkuController) createSKUsBatchPartial(c *gin.Context, skus []models.SKU) {
+	results, positions := partitionBatch(len(skus), func(i int) error {
+		return validators.ValidateSKU(&skus[i])
+	})
+
+	valid := make([]models.SKU, len(positions))
+	for j, i := range positions {
+		valid[j] = skus[i]
+	}
+
+	outcomes, err := ctrl.Repo.CreateSKUsBatchPartial(c.Request.Context(), valid)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "partial batch SKU creation failed", "error", err)
+		respondError(c, err, constants.ErrBatchOperation)
+		return
+	}
+	mergeBatchResults(results, positions, outcomes)
+
+	respondBatchResults(c, "SKU batch processed", results)
+}
+
 func (ctrl *SkuController) GetSKUsByIDs(c *gin.Context) {
 	var request struct {
 		IDs []uint `json:"ids"`
@@ -205,13 +241,13 @@ func (ctrl *SkuController) GetSKUsByIDs(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
-	skus, err := ctrl.Repo.GetSKUsByIDs(request.IDs)
+	skus, err := ctrl.Repo.GetSKUsByIDs(c.Request.Context(), request.IDs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
@@ -225,13 +261,13 @@ func (ctrl *SkuController) GetSKUsByCodes(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrParsingJSON})
+		respondInvalidJSON(c, err)
 		return
 	}
 
-	skus, err := ctrl.Repo.GetSKUsByCodes(request.Codes)
+	skus, err := ctrl.Repo.GetSKUsByCodes(c.Request.Context(), request.Codes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrServerError})
+		respondError(c, err, constants.ErrServerError)
 		return
 	}
 
@@ -0,0 +1,215 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+)
+
+func validSKU(code string) models.SKU {
+	return models.SKU{Code: code, Name: code + " name", TenantId: "tenant_1", SellerId: "seller_1"}
+}
+
+func TestGetAllSkus(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+
+	rec := s.do(t, http.MethodGet, "/sku", nil)
+	assertStatus(t, rec, http.StatusOK)
+
+	var skus []models.SKU
+	decode(t, rec, &skus)
+	if len(skus) != 1 {
+		t.Fatalf("got %d skus, want 1", len(skus))
+	}
+}
+
+func TestGetSkuById(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+
+	assertStatus(t, s.do(t, http.MethodGet, "/sku/1", nil), http.StatusOK)
+	assertError(t, s.do(t, http.MethodGet, "/sku/99", nil), http.StatusNotFound, "SKU_NOT_FOUND")
+	assertStatus(t, s.do(t, http.MethodGet, "/sku/abc", nil), http.StatusBadRequest)
+}
+
+func TestCreateSku(t *testing.T) {
+	s := newTestServer(t)
+
+	missingSeller := validSKU("sku_b")
+	missingSeller.SellerId = ""
+
+	tests := []struct {
+		name string
+		body interface{}
+		want int
+	}{
+		{"valid", validSKU("sku_a"), http.StatusCreated},
+		{"malformed json", `{"code":`, http.StatusBadRequest},
+		{"missing seller", missingSeller, http.StatusBadRequest},
+		{"duplicate code", validSKU("sku_a"), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertStatus(t, s.do(t, http.MethodPost, "/sku", tt.body), tt.want)
+		})
+	}
+}
+
+func TestUpdateSku(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+
+	ifMatch := map[string]string{"If-Match": `"1"`}
+	assertStatus(t, s.doWithHeaders(t, http.MethodPut, "/sku/1", models.SKU{Category: "Shoes"}, ifMatch), http.StatusOK)
+	sku, err := s.skuRepo.GetSkuById(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("get sku: %v", err)
+	}
+	if sku.Category != "Shoes" || sku.Code != "sku_a" {
+		t.Fatalf("sku = %+v, want category updated and code kept", sku)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPut, "/sku/abc", models.SKU{}), http.StatusBadRequest)
+	assertError(t, s.doWithHeaders(t, http.MethodPut, "/sku/99", models.SKU{Category: "Shoes"}, ifMatch), http.StatusNotFound, "SKU_NOT_FOUND")
+	assertError(t, s.doWithHeaders(t, http.MethodPut, "/sku/1", models.SKU{Category: "Bags"}, ifMatch), http.StatusPreconditionFailed, constants.ErrCodeVersionMismatch)
+	assertStatus(t, s.do(t, http.MethodPut, "/sku/1", `{`), http.StatusBadRequest)
+}
+
+func TestDeleteSku(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+
+	assertStatus(t, s.do(t, http.MethodDelete, "/sku/1", nil), http.StatusOK)
+	assertStatus(t, s.do(t, http.MethodGet, "/sku/1", nil), http.StatusNotFound)
+	assertError(t, s.do(t, http.MethodDelete, "/sku/1", nil), http.StatusNotFound, "SKU_NOT_FOUND")
+	assertStatus(t, s.do(t, http.MethodDelete, "/sku/abc", nil), http.StatusBadRequest)
+}
+
+func TestDeleteAndRestoreSkuWithStock(t *testing.T) {
+	s := newTestServer(t)
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, s.seedHub(t, "hub_a").ID, sku.ID, 3)
+	s.seedInventory(t, s.seedHub(t, "hub_b").ID, sku.ID, 2)
+
+	resp := assertError(t, s.do(t, http.MethodDelete, "/sku/1", nil), http.StatusConflict, "SKU_HAS_STOCK")
+	if details, _ := resp.Details.(map[string]interface{}); details["stock"] != float64(5) {
+		t.Errorf("details = %v, want stock summed across hubs", resp.Details)
+	}
+	assertStatus(t, s.do(t, http.MethodDelete, "/sku/1?force=true", nil), http.StatusOK)
+
+	var deleted []models.SKU
+	decode(t, s.do(t, http.MethodGet, "/sku/deleted", nil), &deleted)
+	if len(deleted) != 1 || deleted[0].Code != "sku_a" {
+		t.Fatalf("deleted skus = %+v, want sku_a", deleted)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/1/restore", nil), http.StatusOK)
+	inventory, err := s.inventoryRepo.GetInventoryByHubAndSKU(context.Background(), 1, sku.ID)
+	if err != nil || inventory.Quantity != 3 {
+		t.Fatalf("inventory after restore = %+v, %v; want quantity 3", inventory, err)
+	}
+}
+
+func TestGetSkusByTenantAndSeller(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+	other := validSKU("sku_b")
+	other.TenantId = "tenant_2"
+	if err := s.skuRepo.CreateSku(context.Background(), &other); err != nil {
+		t.Fatalf("seed sku: %v", err)
+	}
+
+	rec := s.do(t, http.MethodPost, "/sku/filter", map[string]string{"tenant_id": "tenant_2"})
+	assertStatus(t, rec, http.StatusOK)
+
+	var skus []models.SKU
+	decode(t, rec, &skus)
+	if len(skus) != 1 || skus[0].Code != "sku_b" {
+		t.Fatalf("skus = %+v, want only sku_b", skus)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/filter", `{`), http.StatusBadRequest)
+}
+
+func TestCreateSKUsBatch(t *testing.T) {
+	s := newTestServer(t)
+
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/batch", []models.SKU{validSKU("sku_a"), validSKU("sku_b")}), http.StatusCreated)
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/batch", []models.SKU{}), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/batch", `[`), http.StatusBadRequest)
+
+	errs := assertBatchErrors(t, s.do(t, http.MethodPost, "/sku/batch", []models.SKU{validSKU("sku_c"), {Code: "sku_d"}}), 1)
+	if len(errs[0].Errors) != 3 {
+		t.Fatalf("errors = %+v, want name, tenant_id and seller_id", errs[0].Errors)
+	}
+
+	assertError(t, s.do(t, http.MethodPost, "/sku/batch", []models.SKU{validSKU("sku_e"), validSKU("sku_a")}), http.StatusConflict, "SKU_ALREADY_EXISTS")
+}
+
+func TestGetSKUsByIDsAndCodes(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+	s.seedSKU(t, "sku_b")
+
+	var skus []models.SKU
+	rec := s.do(t, http.MethodPost, "/sku/batch/ids", map[string][]uint{"ids": {1}})
+	assertStatus(t, rec, http.StatusOK)
+	decode(t, rec, &skus)
+	if len(skus) != 1 || skus[0].Code != "sku_a" {
+		t.Fatalf("skus by id = %+v, want only sku_a", skus)
+	}
+
+	rec = s.do(t, http.MethodPost, "/sku/batch/codes", map[string][]string{"codes": {"sku_b", "missing"}})
+	assertStatus(t, rec, http.StatusOK)
+	decode(t, rec, &skus)
+	if len(skus) != 1 || skus[0].Code != "sku_b" {
+		t.Fatalf("skus by code = %+v, want only sku_b", skus)
+	}
+
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/batch/ids", `{`), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodPost, "/sku/batch/codes", `{`), http.StatusBadRequest)
+}
+
+func TestCreateSkuReportsEveryFieldError(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := s.do(t, http.MethodPost, "/sku", models.SKU{Price: models.ToNullFloat64(-1)})
+	assertError(t, rec, http.StatusBadRequest, constants.ErrCodeValidation)
+
+	var resp struct {
+		Details validators.ValidationErrors `json:"details"`
+	}
+	decode(t, rec, &resp)
+
+	var fields []string
+	for _, fieldErr := range resp.Details {
+		fields = append(fields, fieldErr.Field)
+	}
+	want := []string{"code", "name", "tenant_id", "seller_id", "price"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Fatalf("fields = %v, want %v", fields, want)
+	}
+}
+
+func TestCreateSKUsBatchPartial(t *testing.T) {
+	s := newTestServer(t)
+	s.seedSKU(t, "sku_a")
+
+	negative := validSKU("sku_c")
+	negative.Price = models.ToNullFloat64(-5)
+
+	batch := []models.SKU{validSKU("sku_a"), validSKU("sku_b"), negative}
+	statuses, _ := decodeBatchResults(t, s.do(t, http.MethodPost, "/sku/batch?partial=true", batch))
+	assertStatuses(t, statuses, models.BatchItemDuplicate, models.BatchItemCreated, models.BatchItemInvalid)
+
+	skus, _ := s.skuRepo.GetAllSkus(context.Background())
+	if len(skus) != 2 {
+		t.Fatalf("got %d skus, want 2", len(skus))
+	}
+}
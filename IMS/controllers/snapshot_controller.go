@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/gin-gonic/gin"
+)
+
+type SnapshotController struct {
+	Repo repository.SnapshotRepositoryInterface
+}
+
+func NewSnapshotController(repo repository.SnapshotRepositoryInterface) *SnapshotController {
+	return &SnapshotController{Repo: repo}
+}
+
+// GetStockAsOf reports and values the stock held at the close of ?date=.
+func (ctrl *SnapshotController) GetStockAsOf(c *gin.Context) {
+	date, err := queryDate(c, "date")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, err.Error(), err)
+		return
+	}
+	filter, ok := snapshotFilter(c)
+	if !ok {
+		return
+	}
+
+	report, err := ctrl.Repo.GetStockAsOf(c.Request.Context(), date, filter)
+	if err != nil {
+		respondError(c, err, constants.ErrSnapshotView)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetStockChange lists the hub/SKU pairs whose stock changed between the
+// close of ?from= and the close of ?to=.
+func (ctrl *SnapshotController) GetStockChange(c *gin.Context) {
+	from, err := queryDate(c, "from")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, err.Error(), err)
+		return
+	}
+	to, err := queryDate(c, "to")
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, err.Error(), err)
+		return
+	}
+	if to.Before(from) {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidDateRange, nil)
+		return
+	}
+	filter, ok := snapshotFilter(c)
+	if !ok {
+		return
+	}
+
+	report, err := ctrl.Repo.GetStockChange(c.Request.Context(), from, to, filter)
+	if err != nil {
+		respondError(c, err, constants.ErrSnapshotView)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func snapshotFilter(c *gin.Context) (repository.SnapshotFilter, bool) {
+	var filter repository.SnapshotFilter
+	var err error
+	if filter.HubID, err = optionalID(c, "hub_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return filter, false
+	}
+	if filter.SKUID, err = optionalID(c, "sku_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid sku_id format", err)
+		return filter, false
+	}
+	return filter, true
+}
+
+// queryDate parses a required YYYY-MM-DD query parameter.
+func queryDate(c *gin.Context, name string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, c.Query(name))
+	if err != nil {
+		return date, fmt.Errorf("%s must be a date like 2006-01-02", name)
+	}
+	return date, nil
+}
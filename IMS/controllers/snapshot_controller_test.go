@@ -0,0 +1,75 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+func (s *testServer) snapshot(t *testing.T, day string) {
+	t.Helper()
+	date, _ := time.Parse(time.DateOnly, day)
+	if _, err := s.snapshotRepo.TakeSnapshot(context.Background(), date); err != nil {
+		t.Fatalf("take snapshot: %v", err)
+	}
+}
+
+func TestStockAsOfAndChange(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	priced := s.seedSKU(t, "sku_a")
+	unpriced := models.SKU{Code: "sku_b", Name: "sku_b", TenantId: "tenant_1", SellerId: "seller_1"}
+	if err := s.skuRepo.CreateSku(context.Background(), &unpriced); err != nil {
+		t.Fatalf("seed sku: %v", err)
+	}
+
+	var empty models.StockReport
+	decode(t, s.do(t, http.MethodGet, "/inventory/snapshots?date=2026-09-30", nil), &empty)
+	if empty.SnapshotDate != nil || len(empty.Positions) != 0 {
+		t.Fatalf("report before any snapshot = %+v", empty)
+	}
+
+	s.seedInventory(t, hub.ID, priced.ID, 5)
+	s.seedInventory(t, hub.ID, unpriced.ID, 2)
+	s.snapshot(t, "2026-09-30")
+	s.seedInventory(t, hub.ID, priced.ID, 8)
+	s.snapshot(t, "2026-10-15")
+
+	rec := s.do(t, http.MethodGet, "/inventory/snapshots?date=2026-10-01", nil)
+	assertStatus(t, rec, http.StatusOK)
+	var report models.StockReport
+	decode(t, rec, &report)
+	if report.SnapshotDate == nil || report.SnapshotDate.Format(time.DateOnly) != "2026-09-30" {
+		t.Fatalf("snapshot_date = %v, want the month-end snapshot", report.SnapshotDate)
+	}
+	if report.TotalValue != 50 || report.UnpricedQuantity != 2 || len(report.Positions) != 2 {
+		t.Fatalf("report = %+v, want 5 x 10 valued and 2 unpriced", report)
+	}
+	if report.Positions[1].Value != nil {
+		t.Errorf("unpriced position = %+v, want a null value", report.Positions[1])
+	}
+
+	var change models.StockChangeReport
+	decode(t, s.do(t, http.MethodGet, "/inventory/snapshots/changes?from=2026-09-30&to=2026-10-31", nil), &change)
+	if len(change.Changes) != 1 || change.Changes[0].SKUID != priced.ID || change.Changes[0].Change != 3 {
+		t.Fatalf("changes = %+v, want sku_a up by 3", change.Changes)
+	}
+
+	decode(t, s.do(t, http.MethodGet, "/inventory/snapshots/changes?from=2026-01-01&to=2026-09-30&sku_id=2", nil), &change)
+	if change.FromSnapshotDate != nil || len(change.Changes) != 1 || change.Changes[0].FromQuantity != 0 || change.Changes[0].ToQuantity != 2 {
+		t.Fatalf("changes from before the first snapshot = %+v", change)
+	}
+}
+
+func TestStockReportValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	assertError(t, s.do(t, http.MethodGet, "/inventory/snapshots", nil), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/snapshots?date=30-09-2026", nil), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/snapshots?date=2026-09-30&hub_id=x", nil), http.StatusBadRequest, constants.ErrCodeInvalidID)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/snapshots/changes?from=2026-10-31&to=2026-09-30", nil), http.StatusBadRequest, constants.ErrCodeInvalidRequest)
+}
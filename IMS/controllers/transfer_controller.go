@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+	"github.com/gin-gonic/gin"
+)
+
+type TransferController struct {
+	Repo repository.TransferRepositoryInterface
+}
+
+func NewTransferController(repo repository.TransferRepositoryInterface) *TransferController {
+	return &TransferController{Repo: repo}
+}
+
+// CreateTransfer dispatches stock from the source hub. The transfer stays in
+// transit until received, unless the request asks to receive it at once.
+func (ctrl *TransferController) CreateTransfer(c *gin.Context) {
+	var request struct {
+		SKUID            uint   `json:"sku_id"`
+		SourceHubID      uint   `json:"source_hub_id"`
+		DestinationHubID uint   `json:"destination_hub_id"`
+		Quantity         int    `json:"quantity"`
+		Reference        string `json:"reference"`
+		Receive          bool   `json:"receive"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondInvalidJSON(c, err)
+		return
+	}
+
+	transfer := models.InventoryTransfer{
+		SKUID:            request.SKUID,
+		SourceHubID:      request.SourceHubID,
+		DestinationHubID: request.DestinationHubID,
+		Quantity:         request.Quantity,
+		Reference:        request.Reference,
+	}
+	if err := validators.ValidateTransfer(&transfer); err != nil {
+		respondValidation(c, err)
+		return
+	}
+
+	if err := ctrl.Repo.CreateTransfer(c.Request.Context(), &transfer, request.Receive); err != nil {
+		respondError(c, err, constants.ErrTransferCreate)
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "inventory transfer dispatched",
+		"transfer_id", transfer.ID, "sku_id", transfer.SKUID, "quantity", transfer.Quantity,
+		"source_hub_id", transfer.SourceHubID, "destination_hub_id", transfer.DestinationHubID, "status", transfer.Status)
+	c.JSON(http.StatusCreated, transfer)
+}
+
+func (ctrl *TransferController) GetTransfer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalidID(c, err)
+		return
+	}
+
+	transfer, err := ctrl.Repo.GetTransfer(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err, constants.ErrTransferView)
+		return
+	}
+	c.JSON(http.StatusOK, transfer)
+}
+
+func (ctrl *TransferController) ListTransfers(c *gin.Context) {
+	filter := repository.TransferFilter{Status: models.TransferStatus(c.Query("status"))}
+	switch filter.Status {
+	case "", models.TransferInTransit, models.TransferReceived, models.TransferCancelled:
+	default:
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, "status must be in_transit, received or cancelled", nil)
+		return
+	}
+
+	var err error
+	if filter.HubID, err = optionalID(c, "hub_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return
+	}
+	if filter.SKUID, err = optionalID(c, "sku_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid sku_id format", err)
+		return
+	}
+	if filter.Limit, err = historyLimit(c); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, fmt.Sprintf("limit must be between 1 and %d", constants.MaxHistoryLimit), err)
+		return
+	}
+
+	transfers, err := ctrl.Repo.ListTransfers(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, constants.ErrTransferView)
+		return
+	}
+	c.JSON(http.StatusOK, transfers)
+}
+
+func (ctrl *TransferController) ReceiveTransfer(c *gin.Context) {
+	ctrl.settle(c, ctrl.Repo.ReceiveTransfer)
+}
+
+func (ctrl *TransferController) CancelTransfer(c *gin.Context) {
+	ctrl.settle(c, ctrl.Repo.CancelTransfer)
+}
+
+func (ctrl *TransferController) settle(c *gin.Context, step func(ctx context.Context, id uint) (*models.InventoryTransfer, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalidID(c, err)
+		return
+	}
+
+	transfer, err := step(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err, constants.ErrServerError)
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "inventory transfer settled", "transfer_id", transfer.ID, "status", transfer.Status)
+	c.JSON(http.StatusOK, transfer)
+}
+
+// optionalID parses an ID query parameter, returning 0 when it is absent.
+func optionalID(c *gin.Context, name string) (uint, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	return uint(id), err
+}
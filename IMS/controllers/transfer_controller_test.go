@@ -0,0 +1,157 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *testServer) quantity(t *testing.T, hubID, skuID uint) int {
+	t.Helper()
+	inventory, err := s.inventoryRepo.GetInventoryByHubAndSKU(context.Background(), hubID, skuID)
+	if err != nil {
+		t.Fatalf("get inventory: %v", err)
+	}
+	return inventory.Quantity
+}
+
+func TestTransferDispatchAndReceive(t *testing.T) {
+	s := newTestServer(t)
+	source, destination := s.seedHub(t, "hub_a"), s.seedHub(t, "hub_b")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, source.ID, sku.ID, 10)
+
+	body := gin.H{"sku_id": sku.ID, "source_hub_id": source.ID, "destination_hub_id": destination.ID, "quantity": 4}
+	rec := s.do(t, http.MethodPost, "/inventory/transfers", body)
+	assertStatus(t, rec, http.StatusCreated)
+	var transfer models.InventoryTransfer
+	decode(t, rec, &transfer)
+	if transfer.Status != models.TransferInTransit {
+		t.Fatalf("status = %q, want in_transit", transfer.Status)
+	}
+	if got := s.quantity(t, source.ID, sku.ID); got != 6 {
+		t.Fatalf("source quantity = %d, want 6 once dispatched", got)
+	}
+	if got := s.quantity(t, destination.ID, sku.ID); got != 0 {
+		t.Fatalf("destination quantity = %d, want 0 while in transit", got)
+	}
+
+	assertError(t, s.do(t, http.MethodDelete, "/hub/2", nil), http.StatusConflict, "HUB_HAS_STOCK")
+
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/transfers/1/receive", nil), http.StatusOK)
+	if got := s.quantity(t, destination.ID, sku.ID); got != 4 {
+		t.Fatalf("destination quantity = %d, want 4 once received", got)
+	}
+	assertError(t, s.do(t, http.MethodPost, "/inventory/transfers/1/receive", nil), http.StatusConflict, constants.ErrCodeTransferNotInTransit)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/transfers/1/cancel", nil), http.StatusConflict, constants.ErrCodeTransferNotInTransit)
+
+	var history []models.InventoryMovement
+	decode(t, s.do(t, http.MethodGet, "/inventory/history?sku_id=1", nil), &history)
+	if len(history) != 2 {
+		t.Fatalf("history = %+v, want both legs", history)
+	}
+	in, out := history[0], history[1]
+	if out.Reason != models.MovementTransferOut || out.HubID != source.ID || out.Quantity != -4 || out.QuantityAfter != 6 {
+		t.Errorf("dispatch leg = %+v", out)
+	}
+	if in.Reason != models.MovementTransferIn || in.HubID != destination.ID || in.Quantity != 4 || in.QuantityAfter != 4 {
+		t.Errorf("receive leg = %+v", in)
+	}
+}
+
+func TestTransferCancelReturnsStock(t *testing.T) {
+	s := newTestServer(t)
+	source, destination := s.seedHub(t, "hub_a"), s.seedHub(t, "hub_b")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, source.ID, sku.ID, 5)
+
+	body := gin.H{"sku_id": sku.ID, "source_hub_id": source.ID, "destination_hub_id": destination.ID, "quantity": 5}
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/transfers", body), http.StatusCreated)
+	assertError(t, s.do(t, http.MethodPost, "/inventory/transfers", body), http.StatusConflict, constants.ErrCodeInsufficientInventory)
+
+	var transfer models.InventoryTransfer
+	decode(t, s.do(t, http.MethodPost, "/inventory/transfers/1/cancel", nil), &transfer)
+	if transfer.Status != models.TransferCancelled || transfer.CancelledAt == nil {
+		t.Fatalf("transfer = %+v, want cancelled", transfer)
+	}
+	if got := s.quantity(t, source.ID, sku.ID); got != 5 {
+		t.Fatalf("source quantity = %d, want the stock returned", got)
+	}
+}
+
+func TestTransferToDeletedHubCannotBeReceived(t *testing.T) {
+	s := newTestServer(t)
+	source, destination := s.seedHub(t, "hub_a"), s.seedHub(t, "hub_b")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, source.ID, sku.ID, 5)
+
+	body := gin.H{"sku_id": sku.ID, "source_hub_id": source.ID, "destination_hub_id": destination.ID, "quantity": 3}
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/transfers", body), http.StatusCreated)
+	assertStatus(t, s.do(t, http.MethodDelete, "/hub/2?force=true", nil), http.StatusOK)
+
+	assertError(t, s.do(t, http.MethodPost, "/inventory/transfers/1/receive", nil), http.StatusBadRequest, constants.ErrCodeInvalidReference)
+	assertError(t, s.do(t, http.MethodPost, "/inventory", models.Inventory{HubID: destination.ID, SKUID: sku.ID, Quantity: 1}),
+		http.StatusBadRequest, constants.ErrCodeInvalidReference)
+	assertStatus(t, s.do(t, http.MethodPost, "/inventory/transfers/1/cancel", nil), http.StatusOK)
+	if got := s.quantity(t, source.ID, sku.ID); got != 5 {
+		t.Fatalf("source quantity = %d, want the stock returned", got)
+	}
+}
+
+func TestTransferReceivedImmediately(t *testing.T) {
+	s := newTestServer(t)
+	source, destination := s.seedHub(t, "hub_a"), s.seedHub(t, "hub_b")
+	sku := s.seedSKU(t, "sku_a")
+	s.seedInventory(t, source.ID, sku.ID, 5)
+	s.seedInventory(t, destination.ID, sku.ID, 1)
+
+	body := gin.H{"sku_id": sku.ID, "source_hub_id": source.ID, "destination_hub_id": destination.ID, "quantity": 2, "receive": true}
+	var transfer models.InventoryTransfer
+	decode(t, s.do(t, http.MethodPost, "/inventory/transfers", body), &transfer)
+	if transfer.Status != models.TransferReceived {
+		t.Fatalf("status = %q, want received", transfer.Status)
+	}
+	if src, dst := s.quantity(t, source.ID, sku.ID), s.quantity(t, destination.ID, sku.ID); src != 3 || dst != 3 {
+		t.Fatalf("quantities = %d/%d, want 3/3", src, dst)
+	}
+
+	var listed []models.InventoryTransfer
+	decode(t, s.do(t, http.MethodGet, "/inventory/transfers?status=received&hub_id=2", nil), &listed)
+	if len(listed) != 1 {
+		t.Fatalf("listed = %+v, want the received transfer", listed)
+	}
+	decode(t, s.do(t, http.MethodPost, "/inventory/transfers", body), &transfer)
+	decode(t, s.do(t, http.MethodGet, "/inventory/transfers?limit=1", nil), &listed)
+	if len(listed) != 1 || listed[0].ID != transfer.ID {
+		t.Fatalf("listed = %+v, want only the latest transfer", listed)
+	}
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory/transfers?status=lost", nil), http.StatusBadRequest)
+	assertStatus(t, s.do(t, http.MethodGet, "/inventory/transfers?limit=0", nil), http.StatusBadRequest)
+	assertError(t, s.do(t, http.MethodGet, "/inventory/transfers/9", nil), http.StatusNotFound, "TRANSFER_NOT_FOUND")
+}
+
+func TestCreateTransferValidation(t *testing.T) {
+	s := newTestServer(t)
+	hub := s.seedHub(t, "hub_a")
+	sku := s.seedSKU(t, "sku_a")
+
+	tests := []struct {
+		name string
+		body interface{}
+		code string
+	}{
+		{"same hub", gin.H{"sku_id": sku.ID, "source_hub_id": hub.ID, "destination_hub_id": hub.ID, "quantity": 1}, constants.ErrCodeValidation},
+		{"zero quantity", gin.H{"sku_id": sku.ID, "source_hub_id": hub.ID, "destination_hub_id": 2}, constants.ErrCodeValidation},
+		{"unknown hub", gin.H{"sku_id": sku.ID, "source_hub_id": hub.ID, "destination_hub_id": 99, "quantity": 1}, constants.ErrCodeInvalidReference},
+		{"malformed json", `{"sku_id":`, constants.ErrCodeInvalidJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, s.do(t, http.MethodPost, "/inventory/transfers", tt.body), http.StatusBadRequest, tt.code)
+		})
+	}
+}
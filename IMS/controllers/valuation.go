@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/csvio"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// GetValuation values current stock at SKU prices. ?group_by= takes a
+// comma-separated subset of hub, category, tenant and seller (all four by
+// default) and ?format=csv returns the rows as a CSV download instead of JSON.
+func (ctrl *InventoryController) GetValuation(c *gin.Context) {
+	groupBy, err := valuationGroups(c.DefaultQuery("group_by", "hub,category,tenant,seller"))
+	if err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, err.Error(), err)
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		respondBadRequest(c, constants.ErrCodeInvalidRequest, constants.ErrInvalidReportFormat, nil)
+		return
+	}
+	filter := repository.ValuationFilter{
+		Category: c.Query("category"),
+		TenantID: c.Query("tenant_id"),
+		SellerID: c.Query("seller_id"),
+	}
+	if filter.HubID, err = optionalID(c, "hub_id"); err != nil {
+		respondBadRequest(c, constants.ErrCodeInvalidID, "Invalid hub_id format", err)
+		return
+	}
+
+	rows, err := ctrl.Repo.GetValuation(c.Request.Context(), groupBy, filter)
+	if err != nil {
+		respondError(c, err, constants.ErrValuationView)
+		return
+	}
+	report := models.NewValuationReport(groupBy, rows)
+	if format == "csv" {
+		writeValuationCSV(c, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func valuationGroups(value string) ([]models.ValuationDimension, error) {
+	var groupBy []models.ValuationDimension
+	seen := make(map[models.ValuationDimension]bool)
+	for _, name := range strings.Split(value, ",") {
+		dimension := models.ValuationDimension(strings.TrimSpace(name))
+		valid := false
+		for _, d := range models.ValuationDimensions {
+			valid = valid || d == dimension
+		}
+		if !valid {
+			return nil, fmt.Errorf("group_by %q must be hub, category, tenant or seller", dimension)
+		}
+		if !seen[dimension] {
+			seen[dimension] = true
+			groupBy = append(groupBy, dimension)
+		}
+	}
+	return groupBy, nil
+}
+
+// writeValuationCSV writes one line per row with the grouped columns first.
+// Groups without priced stock have an empty value cell.
+func writeValuationCSV(c *gin.Context, report *models.ValuationReport) {
+	var header []string
+	for _, dimension := range report.GroupBy {
+		switch dimension {
+		case models.ValuationByHub:
+			header = append(header, "hub_id", "hub_name")
+		case models.ValuationByCategory:
+			header = append(header, "category")
+		case models.ValuationByTenant:
+			header = append(header, "tenant_id")
+		case models.ValuationBySeller:
+			header = append(header, "seller_id")
+		}
+	}
+	header = append(header, "quantity", "unpriced_quantity", "unpriced_skus", "value")
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="inventory-valuation.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(header)
+	for _, row := range report.Rows {
+		var record []string
+		for _, dimension := range report.GroupBy {
+			switch dimension {
+			case models.ValuationByHub:
+				record = append(record, strconv.FormatUint(uint64(row.HubID), 10), csvio.EscapeCell(row.HubName))
+			case models.ValuationByCategory:
+				record = append(record, csvio.EscapeCell(row.Category))
+			case models.ValuationByTenant:
+				record = append(record, csvio.EscapeCell(row.TenantID))
+			case models.ValuationBySeller:
+				record = append(record, csvio.EscapeCell(row.SellerID))
+			}
+		}
+		value := ""
+		if row.Value != nil {
+			value = strconv.FormatFloat(*row.Value, 'f', 2, 64)
+		}
+		record = append(record, strconv.Itoa(row.Quantity), strconv.Itoa(row.UnpricedQuantity), strconv.Itoa(row.UnpricedSKUs), value)
+		_ = w.Write(record)
+	}
+	w.Flush()
+}
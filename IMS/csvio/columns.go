@@ -0,0 +1,119 @@
+package csvio
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+var HubColumns = []Column[models.Hub]{
+	{Name: "id", Format: func(h *models.Hub) string { return formatUint(h.ID) }},
+	text("name", func(h *models.Hub) *string { return &h.Name }),
+	text("address", func(h *models.Hub) *string { return &h.Address }),
+	text("city", func(h *models.Hub) *string { return &h.City }),
+	text("state", func(h *models.Hub) *string { return &h.State }),
+	text("country", func(h *models.Hub) *string { return &h.Country }),
+	text("postal_code", func(h *models.Hub) *string { return &h.PostalCode }),
+	text("contact_name", func(h *models.Hub) *string { return &h.ContactName }),
+	text("contact_email", func(h *models.Hub) *string { return &h.ContactEmail }),
+}
+
+// SKUColumns leave price empty for SKUs without one, and an empty price cell
+// imports as no price.
+var SKUColumns = []Column[models.SKU]{
+	{Name: "id", Format: func(s *models.SKU) string { return formatUint(s.ID) }},
+	text("code", func(s *models.SKU) *string { return &s.Code }),
+	text("name", func(s *models.SKU) *string { return &s.Name }),
+	text("description", func(s *models.SKU) *string { return &s.Description }),
+	text("tenant_id", func(s *models.SKU) *string { return &s.TenantId }),
+	text("seller_id", func(s *models.SKU) *string { return &s.SellerId }),
+	text("category", func(s *models.SKU) *string { return &s.Category }),
+	{
+		Name: "price",
+		Format: func(s *models.SKU) string {
+			if !s.Price.Valid {
+				return ""
+			}
+			return strconv.FormatFloat(s.Price.Float64, 'f', -1, 64)
+		},
+		Parse: func(s *models.SKU, value string) error {
+			if value == "" {
+				s.Price = sql.NullFloat64{}
+				return nil
+			}
+			price, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("price %q is not a number", value)
+			}
+			s.Price = models.ToNullFloat64(price)
+			return nil
+		},
+	},
+}
+
+// InventoryColumns import by hub and SKU ID. The hub name and SKU code are
+// exported for readability only.
+var InventoryColumns = []Column[models.Inventory]{
+	{
+		Name:   "hub_id",
+		Format: func(i *models.Inventory) string { return formatUint(i.HubID) },
+		Parse:  func(i *models.Inventory, value string) error { return parseUint("hub_id", value, &i.HubID) },
+	},
+	{Name: "hub_name", Format: func(i *models.Inventory) string { return i.Hub.Name }},
+	{
+		Name:   "sku_id",
+		Format: func(i *models.Inventory) string { return formatUint(i.SKUID) },
+		Parse:  func(i *models.Inventory, value string) error { return parseUint("sku_id", value, &i.SKUID) },
+	},
+	{Name: "sku_code", Format: func(i *models.Inventory) string { return i.SKU.Code }},
+	integer("quantity", func(i *models.Inventory) *int { return &i.Quantity }),
+	{Name: "reorder_point", Format: func(i *models.Inventory) string { return strconv.Itoa(i.ReorderPoint) }},
+	{Name: "safety_stock", Format: func(i *models.Inventory) string { return strconv.Itoa(i.SafetyStock) }},
+}
+
+func text[T any](name string, field func(*T) *string) Column[T] {
+	return Column[T]{
+		Name:   name,
+		Format: func(item *T) string { return *field(item) },
+		Parse: func(item *T, value string) error {
+			*field(item) = value
+			return nil
+		},
+	}
+}
+
+func integer[T any](name string, field func(*T) *int) Column[T] {
+	return Column[T]{
+		Name:   name,
+		Format: func(item *T) string { return strconv.Itoa(*field(item)) },
+		Parse: func(item *T, value string) error {
+			if value == "" {
+				return nil
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%s %q is not a whole number", name, value)
+			}
+			*field(item) = n
+			return nil
+		},
+	}
+}
+
+func parseUint(name, value string, dst *uint) error {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a valid ID", name, value)
+	}
+	*dst = uint(n)
+	return nil
+}
+
+func formatUint(n uint) string {
+	return strconv.FormatUint(uint64(n), 10)
+}
@@ -0,0 +1,170 @@
+// Package csvio maps CSV rows onto models by column name for the
+// spreadsheet import and export endpoints.
+package csvio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+)
+
+// Column is one CSV column of T. Columns without Parse are only exported.
+type Column[T any] struct {
+	Name   string
+	Format func(*T) string
+	Parse  func(*T, string) error
+}
+
+// formulaPrefixes are the leading characters that make a spreadsheet read a
+// cell as a formula, plus the quote used to escape them.
+const formulaPrefixes = "=+-@\t\r'"
+
+// EscapeCell prefixes cell with a quote when a spreadsheet would otherwise
+// evaluate it, so an exported name like =HYPERLINK(...) stays text. Numbers
+// such as -5 are left as they are.
+func EscapeCell(cell string) string {
+	if cell == "" || !strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+// unescapeCell undoes EscapeCell, so exported files import unchanged.
+func unescapeCell(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// Reader streams records of T from CSV that starts with a header row.
+type Reader[T any] struct {
+	csv     *csv.Reader
+	columns []Column[T]
+	index   []int
+}
+
+// NewReader reads the header and matches it to the importable columns,
+// ignoring case and surrounding spaces. mapping renames columns:
+// mapping["name"] = "Hub Name" reads the name column from "Hub Name".
+// Columns missing from the file are left at their zero value; unknown
+// headers are ignored.
+func NewReader[T any](r io.Reader, columns []Column[T], mapping map[string]string) (*Reader[T], error) {
+	records := csv.NewReader(r)
+	records.FieldsPerRecord = -1
+	records.ReuseRecord = true
+
+	header, err := records.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the file is empty, a header row is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	importable := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		importable[column.Name] = column.Parse != nil
+	}
+	for column := range mapping {
+		if !importable[column] {
+			return nil, fmt.Errorf("cannot map %q, it is not an importable column", column)
+		}
+	}
+
+	reader := &Reader[T]{csv: records}
+	for _, column := range columns {
+		if column.Parse == nil {
+			continue
+		}
+		name, mapped := mapping[column.Name]
+		if !mapped {
+			name = column.Name
+		}
+		i, ok := positions[strings.ToLower(strings.TrimSpace(name))]
+		if !ok && mapped {
+			return nil, fmt.Errorf("column %q mapped to %q is not in the header", column.Name, name)
+		}
+		if ok {
+			reader.columns = append(reader.columns, column)
+			reader.index = append(reader.index, i)
+		}
+	}
+	if len(reader.columns) == 0 {
+		return nil, fmt.Errorf("the header has none of the importable columns")
+	}
+	return reader, nil
+}
+
+// Read returns the next record and its line number in the file. Cells
+// escaped by EscapeCell are read back unescaped. Cells that do not parse are reported as validators.ValidationErrors with the record;
+// a malformed file returns a *csv.ParseError and io.EOF marks the end.
+func (r *Reader[T]) Read() (T, int, error) {
+	var item T
+	record, err := r.csv.Read()
+	if err != nil {
+		return item, 0, err
+	}
+	line, _ := r.csv.FieldPos(0)
+
+	var errs validators.ValidationErrors
+	for j, column := range r.columns {
+		i := r.index[j]
+		if i >= len(record) {
+			continue
+		}
+		if err := column.Parse(&item, unescapeCell(strings.TrimSpace(record[i]))); err != nil {
+			errs = append(errs, validators.FieldError{Field: column.Name, Rule: validators.RuleFormat, Message: err.Error()})
+		}
+	}
+	if len(errs) > 0 {
+		return item, line, errs
+	}
+	return item, line, nil
+}
+
+// Writer streams records of T as CSV with a header row. Cells are escaped
+// with EscapeCell.
+type Writer[T any] struct {
+	csv     *csv.Writer
+	columns []Column[T]
+	record  []string
+}
+
+func NewWriter[T any](w io.Writer, columns []Column[T]) *Writer[T] {
+	return &Writer[T]{csv: csv.NewWriter(w), columns: columns, record: make([]string, len(columns))}
+}
+
+func (w *Writer[T]) WriteHeader() error {
+	for i, column := range w.columns {
+		w.record[i] = column.Name
+	}
+	return w.csv.Write(w.record)
+}
+
+func (w *Writer[T]) Write(item *T) error {
+	for i, column := range w.columns {
+		w.record[i] = EscapeCell(column.Format(item))
+	}
+	return w.csv.Write(w.record)
+}
+
+// Flush writes buffered rows to the underlying writer.
+func (w *Writer[T]) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,95 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+)
+
+func TestReaderMapsColumnsAndReportsBadCells(t *testing.T) {
+	input := "\ufeffCode,Title,Tenant,seller_id,PRICE,ignored\n" +
+		"sku_a,Sku A,tenant_1,seller_1,9.5,x\n" +
+		"sku_b,Sku B,tenant_1,seller_1,free,x\n" +
+		"sku_c,Sku C,tenant_1,seller_1,,x\n"
+	reader, err := NewReader(strings.NewReader(input), SKUColumns, map[string]string{"name": "Title", "tenant_id": "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sku, line, err := reader.Read()
+	if err != nil || line != 2 || sku.Code != "sku_a" || sku.Name != "Sku A" || sku.TenantId != "tenant_1" || sku.Price.Float64 != 9.5 {
+		t.Fatalf("line %d = %+v, %v", line, sku, err)
+	}
+
+	_, line, err = reader.Read()
+	var fieldErrs validators.ValidationErrors
+	if line != 3 || !errors.As(err, &fieldErrs) || fieldErrs[0].Field != "price" {
+		t.Fatalf("line %d err = %v, want a price format error", line, err)
+	}
+
+	sku, _, err = reader.Read()
+	if err != nil || sku.Price.Valid {
+		t.Fatalf("empty price = %+v, %v, want no price", sku.Price, err)
+	}
+	if _, _, err = reader.Read(); err != io.EOF {
+		t.Fatalf("err = %v, want EOF", err)
+	}
+}
+
+func TestNewReaderRejectsBadMappings(t *testing.T) {
+	for name, mapping := range map[string]map[string]string{
+		"export only column": {"id": "ID"},
+		"unknown column":     {"colour": "Colour"},
+		"missing header":     {"name": "Title"},
+	} {
+		if _, err := NewReader(strings.NewReader("name,address\n"), HubColumns, mapping); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+	if _, err := NewReader(strings.NewReader("foo,bar\n"), HubColumns, nil); err == nil {
+		t.Error("header without importable columns: want an error")
+	}
+}
+
+func TestFormulaCellsAreEscapedAndReadBack(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, SKUColumns)
+	_ = w.WriteHeader()
+	_ = w.Write(&models.SKU{Code: "sku_a", Name: `=HYPERLINK("http://evil","x")`, Description: "-5", TenantId: "@t", SellerId: "+s"})
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,code,name,description,tenant_id,seller_id,category,price\n" +
+		"0,sku_a,\"'=HYPERLINK(\"\"http://evil\"\",\"\"x\"\")\",-5,'@t,'+s,,\n"
+	if out.String() != want {
+		t.Fatalf("csv = %q, want %q", out.String(), want)
+	}
+
+	reader, err := NewReader(&out, SKUColumns, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sku, _, err := reader.Read()
+	if err != nil || sku.Name != `=HYPERLINK("http://evil","x")` || sku.TenantId != "@t" || sku.SellerId != "+s" || sku.Description != "-5" {
+		t.Fatalf("read back %+v, %v, want the original cells", sku, err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, SKUColumns)
+	_ = w.WriteHeader()
+	_ = w.Write(&models.SKU{Code: "sku_a", Name: "A, the first", TenantId: "t", SellerId: "s"})
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,code,name,description,tenant_id,seller_id,category,price\n0,sku_a,\"A, the first\",,t,s,,\n"
+	if out.String() != want {
+		t.Fatalf("csv = %q, want %q", out.String(), want)
+	}
+}
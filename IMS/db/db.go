@@ -1,11 +1,12 @@
 package db
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 
-	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/metrics"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -14,84 +15,62 @@ var (
 	db *gorm.DB
 )
 
-func Connect() {
-	dsn := fmt.Sprintf(`host=%s user=%s password=%s dbname=%s port=%s sslmode=disable`,
-		os.Getenv("POSTGRES_HOST"),
-		os.Getenv("POSTGRES_USER"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("POSTGRES_DB"),
-		os.Getenv("POSTGRES_PORT"))
-
-	connect, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-
+// Connect opens the pool and pings Postgres, so an unreachable database is
+// reported here instead of surfacing as a nil *gorm.DB on the first query.
+func Connect(ctx context.Context, cfg config.PostgresConfig) error {
+	connect, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{TranslateError: true})
 	if err != nil {
-		log.Print("Cannot Connect to DB: ", err)
-		return
+		return fmt.Errorf("connect to postgres: %w", err)
 	}
 
-	db = connect
-	log.Print("Database Connected Successfully")
-}
-
-func Migrate() {
-	log.Println("Migrating...")
-	err := db.AutoMigrate(&models.Hub{}, &models.SKU{}, &models.Inventory{})
+	if err := connect.Use(metrics.GormPlugin{}); err != nil {
+		return fmt.Errorf("register query metrics: %w", err)
+	}
+	if err := connect.Use(tracingPlugin{}); err != nil {
+		return fmt.Errorf("register query tracing: %w", err)
+	}
 
+	sqlDB, err := connect.DB()
 	if err != nil {
-		log.Print("Failed to Auto Migrate: ", err)
+		return fmt.Errorf("configure postgres pool: %w", err)
 	}
-	log.Print("Migration Success")
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return fmt.Errorf("ping postgres: %w", err)
+	}
+
+	db = connect
+	slog.InfoContext(ctx, "postgres connected", "host", cfg.Host, "database", cfg.Database)
+	return nil
 }
 
 func GetDB() *gorm.DB {
 	return db
 }
 
-func DropAll() {
-	log.Println("Dropping all tables...")
-	err := db.Migrator().DropTable(&models.Inventory{}, &models.SKU{}, &models.Hub{})
+func Ping(ctx context.Context) error {
+	if db == nil {
+		return fmt.Errorf("postgres not connected")
+	}
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Println("Failed to drop tables: ", err)
-		return
+		return err
 	}
-	log.Println("All tables dropped successfully")
+	return sqlDB.PingContext(ctx)
 }
 
-func Seed() {
-
-	log.Println("Seeding The Database...")
-
-	for i := 1; i <= 100; i++ {
-
-		hub := models.Hub{
-			Name:         fmt.Sprintf("test_name_%d", i),
-			Address:      fmt.Sprintf("test_address_%d", i),
-			City:         fmt.Sprintf("city_%d", i),
-			State:        fmt.Sprintf("state_%d", i),
-			Country:      "India",
-			PostalCode:   fmt.Sprintf("400%03d", i),
-			ContactName:  fmt.Sprintf("contact_%d", i),
-			ContactEmail: fmt.Sprintf("contact_%d@example.com", i),
-		}
-		db.Create(&hub)
-
-		sku := models.SKU{
-			Code:        fmt.Sprintf("sku_code_%d", i),
-			Name:        fmt.Sprintf("sku_name_%d", i),
-			Description: fmt.Sprintf("Description for SKU %d", i),
-			TenantId:    fmt.Sprintf("tenant_%d", i),
-			SellerId:    fmt.Sprintf("seller_%d", i),
-			Category:    "General",
-			Price:       models.ToNullFloat64(float64(100 + i)),
-		}
-		db.Create(&sku)
-
-		inventory := models.Inventory{
-			HubID:    hub.ID,
-			SKUID:    sku.ID,
-			Quantity: 10 * i,
-		}
-		db.Create(&inventory)
+func Close() error {
+	if db == nil {
+		return nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
 	}
-	log.Println("Seeded Hubs, SKUs, and Inventory records.")
+	return sqlDB.Close()
 }
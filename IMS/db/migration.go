@@ -1,46 +1,112 @@
 package db
 
 import (
-	"log"
-	"os"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
 
-	"github.com/omniful/go_commons/db/sql/migration"
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
-func NativeMigrationUp() {
-	migrator, err := migration.InitializeMigrate("file://migrations/", os.Getenv("POSTGRES_URL"))
+type MigrationStatus struct {
+	Version   uint
+	Dirty     bool
+	Available []uint
+}
+
+// Pending returns the available versions that have not been applied yet.
+func (s MigrationStatus) Pending() []uint {
+	var pending []uint
+	for _, v := range s.Available {
+		if v > s.Version {
+			pending = append(pending, v)
+		}
+	}
+	return pending
+}
+
+func newMigrator(cfg config.PostgresConfig) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations.FS, ".")
 	if err != nil {
-		log.Fatalf("Failed to initialize migrator: %v", err)
+		return nil, fmt.Errorf("load migrations: %w", err)
 	}
-	err = migrator.Up()
+	m, err := migrate.NewWithSourceInstance("iofs", source, cfg.URL())
 	if err != nil {
-		log.Fatalf("Failed to apply migrations: %v", err)
+		return nil, fmt.Errorf("initialize migrator: %w", err)
 	}
-	log.Println("Migrations applied successfully!")
+	return m, nil
 }
 
-func NativeMigrationDown() {
-	migrator, err := migration.InitializeMigrate("file://migrations/", os.Getenv("POSTGRES_URL"))
+func withMigrator(cfg config.PostgresConfig, run func(*migrate.Migrate) error) error {
+	m, err := newMigrator(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize migrator: %v", err)
+		return err
 	}
-	err = migrator.Down()
-	if err != nil {
-		log.Fatalf("Failed to apply migrations: %v", err)
+	defer m.Close()
+
+	if err := run(m); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
 	}
-	log.Println("Migrations applied successfully!")
+	return nil
 }
 
-func CleanDirtyMigration() {
-	migrator, err := migration.InitializeMigrate("file://migrations/", os.Getenv("POSTGRES_URL"))
-	if err != nil {
-		log.Fatalf("Init failed: %v", err)
+func MigrateUp(cfg config.PostgresConfig) error {
+	return withMigrator(cfg, func(m *migrate.Migrate) error { return m.Up() })
+}
+
+// MigrateDown rolls back the given number of applied migrations.
+func MigrateDown(cfg config.PostgresConfig, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
 	}
+	return withMigrator(cfg, func(m *migrate.Migrate) error { return m.Steps(-steps) })
+}
 
-	err = migrator.ForceVersion(1)
+// ForceMigrationVersion marks version as applied and clears the dirty flag
+// without running any SQL. Use -1 to mark the schema as empty.
+func ForceMigrationVersion(cfg config.PostgresConfig, version int) error {
+	return withMigrator(cfg, func(m *migrate.Migrate) error { return m.Force(version) })
+}
+
+func GetMigrationStatus(cfg config.PostgresConfig) (MigrationStatus, error) {
+	available, err := availableMigrations()
 	if err != nil {
-		log.Fatalf("Failed to force clean version: %v", err)
+		return MigrationStatus{}, err
 	}
+	status := MigrationStatus{Available: available}
 
-	log.Println("Forced to clean version 1")
+	err = withMigrator(cfg, func(m *migrate.Migrate) error {
+		version, dirty, err := m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return nil
+		}
+		status.Version, status.Dirty = version, dirty
+		return err
+	})
+	return status, err
+}
+
+func availableMigrations() ([]uint, error) {
+	files, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]uint, 0, len(files))
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(file, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+		versions = append(versions, uint(version))
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions, nil
 }
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/migrations"
+)
+
+func TestMigrationsAreSequentialAndReversible(t *testing.T) {
+	versions, err := availableMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, v := range versions {
+		if v != uint(i+1) {
+			t.Fatalf("migration versions = %v, want 1..%d without gaps", versions, len(versions))
+		}
+	}
+
+	ups, _ := fs.Glob(migrations.FS, "*.up.sql")
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		if _, err := fs.Stat(migrations.FS, down); err != nil {
+			t.Errorf("%s has no matching %s", up, down)
+		}
+	}
+}
+
+func TestMigrationStatusPending(t *testing.T) {
+	status := MigrationStatus{Version: 1, Available: []uint{1, 2, 3}}
+	if got := status.Pending(); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Fatalf("Pending() = %v, want [2 3]", got)
+	}
+}
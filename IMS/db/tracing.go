@@ -0,0 +1,75 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// tracingPlugin opens a client span for every statement gorm runs, parented
+// on the context passed to WithContext.
+type tracingPlugin struct{}
+
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", startSpan("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", endSpan),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", startSpan("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", endSpan),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", startSpan("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", endSpan),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", startSpan("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", endSpan),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan),
+	)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := tracing.Tracer().Start(db.Statement.Context, "postgres "+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "postgresql"),
+				attribute.String("db.operation", operation),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,15 @@
+// Package events announces inventory changes to other services.
+package events
+
+import (
+	"context"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+// AlertPublisher announces newly opened stock alerts. Alerts are stored
+// before they are published, so a lost message drops the notification but
+// not the alert, which stays listed under GET /inventory/alerts.
+type AlertPublisher interface {
+	PublishAlerts(ctx context.Context, alerts []models.InventoryAlert) error
+}
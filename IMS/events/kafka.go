@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher writes alerts to the alerts topic keyed by hub and SKU, so
+// the alerts of one pair stay ordered. Writes are asynchronous: inventory
+// requests never wait on the broker, and delivery failures are logged.
+type KafkaPublisher struct {
+	Writer *kafka.Writer
+}
+
+func NewKafkaPublisher(cfg config.KafkaConfig) *KafkaPublisher {
+	topic := cfg.AlertsTopic
+	return &KafkaPublisher{Writer: &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		Async:        true,
+		Transport:    &kafka.Transport{ClientID: cfg.ClientID},
+		Completion: func(messages []kafka.Message, err error) {
+			if err != nil {
+				slog.Error("failed to publish stock alerts", "topic", topic, "count", len(messages), "error", err)
+			}
+		},
+	}}
+}
+
+func (p *KafkaPublisher) PublishAlerts(ctx context.Context, alerts []models.InventoryAlert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	var headers []kafka.Header
+	for key, value := range tracing.InjectHeaders(ctx) {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	messages := make([]kafka.Message, len(alerts))
+	for i, alert := range alerts {
+		value, err := json.Marshal(alert)
+		if err != nil {
+			return fmt.Errorf("encode stock alert: %w", err)
+		}
+		messages[i] = kafka.Message{
+			Key:     []byte(fmt.Sprintf("%d:%d", alert.HubID, alert.SKUID)),
+			Value:   value,
+			Headers: headers,
+		}
+	}
+	return p.Writer.WriteMessages(ctx, messages...)
+}
+
+// Close flushes pending alerts.
+func (p *KafkaPublisher) Close() error {
+	return p.Writer.Close()
+}
@@ -0,0 +1,103 @@
+// Package jobs runs scheduled work inside the server process.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+)
+
+// DailySnapshot takes the inventory snapshot of the previous UTC day at RunAt
+// past every UTC midnight. Every replica runs it; the snapshot of a day is
+// overwritten rather than duplicated, so that is harmless.
+type DailySnapshot struct {
+	Repo  repository.SnapshotRepositoryInterface
+	RunAt time.Duration
+	stop  context.CancelFunc
+	done  chan struct{}
+}
+
+func NewDailySnapshot(repo repository.SnapshotRepositoryInterface, runAt time.Duration) *DailySnapshot {
+	return &DailySnapshot{Repo: repo, RunAt: runAt}
+}
+
+func (j *DailySnapshot) Start() {
+	ctx, stop := context.WithCancel(context.Background())
+	j.stop, j.done = stop, make(chan struct{})
+	go func() {
+		defer close(j.done)
+		for {
+			next := nextRun(time.Now(), j.RunAt)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+			// The snapshot runs on its own context, so Close stops the
+			// schedule without cutting short a snapshot in progress.
+			j.run(context.Background(), SnapshotDate(next))
+		}
+	}()
+}
+
+// Close stops the schedule and waits up to ctx for a running snapshot to
+// finish.
+func (j *DailySnapshot) Close(ctx context.Context) error {
+	if j.stop == nil {
+		return nil
+	}
+	j.stop()
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (j *DailySnapshot) run(ctx context.Context, date time.Time) {
+	start := time.Now()
+	rows, err := j.Repo.TakeSnapshot(ctx, date)
+	if err != nil {
+		slog.ErrorContext(ctx, "inventory snapshot failed", "date", date.Format(time.DateOnly), "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "inventory snapshot taken",
+		"date", date.Format(time.DateOnly), "rows", rows, "duration", time.Since(start).String())
+}
+
+// CheckManualSnapshot guards a snapshot taken outside the schedule. A snapshot
+// records current stock, so it can only stand for the day that closed last,
+// and an existing snapshot of that day is kept unless force is set.
+func CheckManualSnapshot(ctx context.Context, repo repository.SnapshotRepositoryInterface, date, now time.Time, force bool) error {
+	if closed := SnapshotDate(now); !date.Equal(closed) {
+		return fmt.Errorf("a snapshot records current stock, so only %s can be taken now", closed.Format(time.DateOnly))
+	}
+	exists, err := repo.HasSnapshot(ctx, date)
+	if err != nil {
+		return err
+	}
+	if exists && !force {
+		return fmt.Errorf("a snapshot of %s already exists, pass -force to overwrite it", date.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// SnapshotDate is the day a snapshot taken at t records: the UTC day before.
+func SnapshotDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day()-1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextRun returns the first time after now that is runAt past a UTC midnight.
+func nextRun(now time.Time, runAt time.Duration) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(runAt)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
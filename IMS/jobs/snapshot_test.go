@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository/memory"
+)
+
+func TestNextRun(t *testing.T) {
+	runAt := 5 * time.Minute
+	tests := []struct {
+		now, want string
+	}{
+		{"2026-09-30T23:00:00Z", "2026-10-01T00:05:00Z"},
+		{"2026-10-01T00:04:59Z", "2026-10-01T00:05:00Z"},
+		{"2026-10-01T00:05:00Z", "2026-10-02T00:05:00Z"},
+		{"2026-10-01T03:00:00+05:30", "2026-10-01T00:05:00Z"},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		if got := nextRun(now, runAt).Format(time.RFC3339); got != tt.want {
+			t.Errorf("nextRun(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestSnapshotDateIsThePreviousUTCDay(t *testing.T) {
+	run, _ := time.Parse(time.RFC3339, "2026-10-01T00:05:00Z")
+	if got := SnapshotDate(run).Format(time.DateOnly); got != "2026-09-30" {
+		t.Fatalf("SnapshotDate = %s, want month end 2026-09-30", got)
+	}
+}
+
+func TestCheckManualSnapshot(t *testing.T) {
+	ctx := context.Background()
+	hubs, skus := memory.NewHubRepository(), memory.NewSkuRepository()
+	inventory := memory.NewInventoryRepository(skus, hubs)
+	hub := models.Hub{Name: "hub_a", Address: "addr", City: "Mumbai", Country: "India"}
+	sku := models.SKU{Code: "sku_a", Name: "SKU A", TenantId: "tenant_1", SellerId: "seller_1"}
+	if err := hubs.CreateHub(ctx, &hub); err != nil {
+		t.Fatal(err)
+	}
+	if err := skus.CreateSku(ctx, &sku); err != nil {
+		t.Fatal(err)
+	}
+	if err := inventory.UpsertInventory(ctx, &models.Inventory{HubID: hub.ID, SKUID: sku.ID, Quantity: 5}); err != nil {
+		t.Fatal(err)
+	}
+	repo := memory.NewSnapshotRepository(inventory)
+	now, _ := time.Parse(time.RFC3339, "2026-10-01T09:00:00Z")
+	yesterday := SnapshotDate(now)
+
+	if err := CheckManualSnapshot(ctx, repo, yesterday.AddDate(0, 0, -1), now, true); err == nil {
+		t.Error("accepted a snapshot of an older day")
+	}
+	if err := CheckManualSnapshot(ctx, repo, yesterday.AddDate(0, 0, 1), now, false); err == nil {
+		t.Error("accepted a snapshot of the day still open")
+	}
+	if err := CheckManualSnapshot(ctx, repo, yesterday, now, false); err != nil {
+		t.Fatalf("rejected yesterday's first snapshot: %v", err)
+	}
+
+	if _, err := repo.TakeSnapshot(ctx, yesterday); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckManualSnapshot(ctx, repo, yesterday, now, false); err == nil {
+		t.Error("accepted overwriting an existing snapshot without force")
+	}
+	if err := CheckManualSnapshot(ctx, repo, yesterday, now, true); err != nil {
+		t.Errorf("rejected a forced overwrite: %v", err)
+	}
+}
@@ -1,91 +1,199 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"log"
-	"time"
-
-	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"log/slog"
+	stdhttp "net/http"
+	"os"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/cache"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/lifecycle"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
 	"github.com/Trishank-Omniful/Onboarding-Task/controllers"
 	"github.com/Trishank-Omniful/Onboarding-Task/db"
+	"github.com/Trishank-Omniful/Onboarding-Task/events"
+	"github.com/Trishank-Omniful/Onboarding-Task/jobs"
+	"github.com/Trishank-Omniful/Onboarding-Task/metrics"
 	"github.com/Trishank-Omniful/Onboarding-Task/middleware"
+	"github.com/Trishank-Omniful/Onboarding-Task/ratelimit"
 	"github.com/Trishank-Omniful/Onboarding-Task/repository"
 	"github.com/Trishank-Omniful/Onboarding-Task/routes"
-	"github.com/gin-gonic/gin"
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
 	"github.com/omniful/go_commons/http"
 	"github.com/omniful/go_commons/redis"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func main() {
+	// .env is a convenience for local runs; containers set the environment
+	// directly and ship without one.
 	err := godotenv.Load(".env")
-	if err != nil {
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Print("No .env file, using the environment only")
+	} else if err != nil {
 		log.Fatal("Cannot load env", err)
 	}
 
-	db.Connect()
-
-	// Uncomment this line to drop all tables
-	db.DropAll()
-
-	// Uncomment this line to run migrations
-	db.Migrate()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+	if err := logger.Init(os.Stdout, "IMS", cfg.Logging); err != nil {
+		log.Fatal("Invalid logging configuration: ", err)
+	}
 
-	// Uncomment this line to clean Dirty migrations
-	// db.CleanDirtyMigration()
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] != "serve" {
+		os.Exit(runCommand(cfg, args))
+	}
+	serve(cfg)
+}
 
-	// Uncomment this line to run native Down migrations
-	// db.NativeMigrationDown()
+func serve(cfg *config.Config) {
+	ctx := context.Background()
+	startup := lifecycle.RetryPolicy{
+		Timeout:        cfg.Startup.Timeout,
+		InitialBackoff: cfg.Startup.InitialBackoff,
+		MaxBackoff:     cfg.Startup.MaxBackoff,
+	}
 
-	// Uncomment this line to run native Up migrations
-	// db.NativeMigrationUp()
+	shutdownTracing, err := tracing.Init(ctx, "IMS", cfg.Tracing)
+	if err != nil {
+		fatal("cannot start IMS", err)
+	}
 
-	// Uncomment this line to Seed the Database with Dummy Data
-	db.Seed()
+	err = lifecycle.Retry(ctx, "Postgres", startup, func(ctx context.Context) error {
+		return db.Connect(ctx, cfg.Postgres)
+	})
+	if err != nil {
+		fatal("cannot start IMS", err)
+	}
+	warnOnPendingMigrations(cfg)
 
 	gormDB := db.GetDB()
 
-	config := &redis.Config{
-		Hosts:       []string{constants.RedisHost},
-		PoolSize:    constants.RedisPoolSize,
-		MinIdleConn: constants.RedisMinIdleConn,
+	client := redis.NewClient(&redis.Config{
+		Hosts:       cfg.Redis.Hosts,
+		PoolSize:    cfg.Redis.PoolSize,
+		MinIdleConn: cfg.Redis.MinIdleConn,
+	})
+	redisCache := cache.NewRedisCache(client)
+	if err := lifecycle.Retry(ctx, "Redis", startup, redisCache.Ping); err != nil {
+		db.Close()
+		fatal("cannot start IMS", err)
 	}
+	slog.Info("redis connected", "hosts", cfg.Redis.Hosts)
+
+	// The go_commons client exposes no Lua API, so the rate limiter reaches the
+	// same Redis through go-redis to take tokens atomically.
+	limiterRedis := goredis.NewUniversalClient(&goredis.UniversalOptions{
+		Addrs:        cfg.Redis.Hosts,
+		PoolSize:     cfg.Redis.PoolSize,
+		MinIdleConns: cfg.Redis.MinIdleConn,
+	})
 
-	client := redis.NewClient(config)
-	log.Print("Redis Connected at PORT:6379")
-
-	log.Print("Starting IMS at PORT:8000")
+	appCache := cache.NewTieredCache(
+		cache.NewLRUCache(cfg.Cache.LocalCapacity),
+		redisCache,
+		cfg.Cache.LocalTTL,
+	)
 
 	server := http.InitializeServer(
-		constants.ServerPort,
-		time.Duration(constants.ServerReadTimeout)*time.Second,
-		time.Duration(constants.ServerWriteTimeout)*time.Second,
-		time.Duration(constants.ServerIdleTimeout)*time.Second,
+		cfg.Server.Port,
+		cfg.Server.ReadTimeout,
+		cfg.Server.WriteTimeout,
+		cfg.Server.IdleTimeout,
 		false,
 	)
 
+	server.Engine.Use(otelgin.Middleware("IMS", otelgin.WithFilter(tracing.SkipProbes)))
+	server.Engine.Use(middleware.RequestIDMiddleware())
+	server.Engine.Use(middleware.MetricsMiddleware())
 	server.Engine.Use(middleware.CORSMiddleware())
 	server.Engine.Use(middleware.LoggingMiddleware())
 	server.Engine.Use(middleware.ValidationMiddleware())
+	server.Engine.Use(middleware.ErrorHandler())
 
-	server.GET("/health", func(ctx *gin.Context) {
-		ctx.JSON(200, gin.H{"status": "ok", "service": "IMS"})
-	})
+	health := lifecycle.NewHealth("IMS", cfg.Server.ReadinessTimeout)
+	health.AddCheck("postgres", db.Ping)
+	health.AddCheck("redis", redisCache.Ping)
+	health.Register(server.Engine)
+	server.GET("/metrics", metrics.Handler())
 
-	hubRepo := repository.NewHubRepository(gormDB, client)
-	hubController := controllers.NewHubController(hubRepo)
+	hubRepo := repository.NewHubRepository(gormDB, appCache, cfg.Cache.HubTTL)
+	hubController := controllers.NewHubController(hubRepo, cfg.Batch.MaxSize)
 	IMS := server.Engine.Group("/api/v1/ims")
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		store, err := ratelimit.NewRedisStore(ctx, limiterRedis)
+		if err != nil {
+			db.Close()
+			fatal("cannot start IMS", err)
+		}
+		limiter = ratelimit.NewLimiter(store, cfg.RateLimit)
+		IMS.Use(middleware.RateLimitMiddleware(limiter))
+	}
 	routes.RegisterHubRoutes(IMS, hubController)
 
-	skuRepo := repository.NewSkuRepository(gormDB, client)
-	skuController := controllers.NewSkuController(skuRepo)
+	skuRepo := repository.NewSkuRepository(gormDB, appCache, cfg.Cache.SKUTTL)
+	skuController := controllers.NewSkuController(skuRepo, cfg.Batch.MaxSize)
 	routes.RegisterSkuRoutes(IMS, skuController)
 
-	inventoryRepo := repository.NewInventoryRepository(gormDB, client, skuRepo, hubRepo)
-	inventoryController := controllers.NewInventoryController(inventoryRepo)
+	alerts := events.NewKafkaPublisher(cfg.Kafka)
+	inventoryRepo := repository.NewInventoryRepository(gormDB, appCache, skuRepo, hubRepo, alerts)
+	inventoryController := controllers.NewInventoryController(inventoryRepo, cfg.Batch.MaxSize)
 	routes.RegisterInventoryRoutes(IMS, inventoryController)
 
-	if err := server.StartServer("IMS"); err != nil {
-		log.Fatal("Could Not start Server: ", err)
+	transferRepo := repository.NewTransferRepository(gormDB, alerts)
+	routes.RegisterTransferRoutes(IMS, controllers.NewTransferController(transferRepo))
+
+	cycleCountRepo := repository.NewCycleCountRepository(gormDB, alerts)
+	routes.RegisterCycleCountRoutes(IMS, controllers.NewCycleCountController(cycleCountRepo, cfg.Batch.MaxSize))
+
+	snapshotRepo := repository.NewSnapshotRepository(gormDB)
+	routes.RegisterSnapshotRoutes(IMS, controllers.NewSnapshotController(snapshotRepo))
+
+	if limiter != nil {
+		if err := limiter.CheckRoutes(server.Engine.Routes()); err != nil {
+			db.Close()
+			fatal("cannot start IMS", err)
+		}
 	}
 
+	snapshots := jobs.NewDailySnapshot(snapshotRepo, cfg.Snapshot.RunAt)
+	if cfg.Snapshot.Enabled {
+		snapshots.Start()
+	}
+
+	// The go_commons server has no shutdown hook, so its engine is served by a
+	// stdlib server that can drain in-flight requests on SIGTERM.
+	srv := &stdhttp.Server{
+		Addr:         cfg.Server.Port,
+		Handler:      server.Engine,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+	err = lifecycle.Serve("IMS", srv, health, cfg.Server.ShutdownTimeout,
+		lifecycle.Closer{Name: "Tracing", Close: shutdownTracing},
+		lifecycle.Closer{Name: "Postgres", Close: func(context.Context) error { return db.Close() }},
+		lifecycle.Closer{Name: "Redis", Close: func(context.Context) error { return redisCache.Close() }},
+		lifecycle.Closer{Name: "Rate limit Redis", Close: func(context.Context) error { return limiterRedis.Close() }},
+		lifecycle.Closer{Name: "Kafka", Close: func(context.Context) error { return alerts.Close() }},
+		lifecycle.Closer{Name: "Snapshots", Close: snapshots.Close},
+	)
+	if err != nil {
+		fatal("server failed", err)
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ims"
+
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+
+	ReservationSuccess      = "success"
+	ReservationInsufficient = "insufficient_stock"
+	ReservationNotFound     = "not_found"
+	ReservationError        = "error"
+)
+
+// Registry holds every IMS collector. A dedicated registry keeps tests and
+// the CLI free of the global default registry.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template and status code.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Repository cache lookups by entity and result.",
+	}, []string{"entity", "result"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Postgres statement latency by operation and table.",
+		Buckets:   prometheus.ExponentialBuckets(.0005, 2, 14),
+	}, []string{"operation", "table"})
+
+	InventoryReservations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "inventory_reservations_total",
+		Help:      "Inventory reservation attempts by result.",
+	}, []string{"result"})
+
+	RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected with 429 by rate limit group.",
+	}, []string{"group"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		CacheRequests,
+		DBQueryDuration,
+		InventoryReservations,
+		RateLimitedRequests,
+	)
+}
+
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
@@ -0,0 +1,41 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/metrics"
+	"github.com/Trishank-Omniful/Onboarding-Task/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsMiddlewareLabelsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.MetricsMiddleware())
+	router.GET("/hubs/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/metrics", metrics.Handler())
+
+	for _, path := range []string{"/hubs/1", "/hubs/2", "/nope"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if n := testutil.CollectAndCount(metrics.HTTPRequestDuration); n != 2 {
+		t.Errorf("got %d series, want one for /hubs/:id and one for unmatched paths", n)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`ims_http_request_duration_seconds_count{method="GET",route="/hubs/:id",status="204"} 2`,
+		`ims_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics is missing %s", want)
+		}
+	}
+}
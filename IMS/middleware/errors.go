@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Error   string      `json:"error"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// ErrorHandler renders the last error attached with c.Error as an
+// ErrorResponse. Domain errors keep their code and message; anything else
+// becomes a 500 using the string set as the error's Meta, if any.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		ginErr := c.Errors.Last()
+		var domainErr *repository.Error
+		if errors.As(ginErr.Err, &domainErr) {
+			c.JSON(statusFor(domainErr.Kind), ErrorResponse{
+				Code:    domainErr.Code,
+				Error:   domainErr.Message,
+				Details: domainErr.Details,
+			})
+			return
+		}
+
+		slog.ErrorContext(c.Request.Context(), "unhandled error", "error", ginErr.Err)
+		message := constants.ErrServerError
+		if fallback, ok := ginErr.Meta.(string); ok && fallback != "" {
+			message = fallback
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Code: constants.ErrCodeInternal, Error: message})
+	}
+}
+
+func statusFor(kind error) int {
+	switch {
+	case errors.Is(kind, repository.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(kind, repository.ErrConflict), errors.Is(kind, repository.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(kind, repository.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(kind, repository.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(kind, repository.ErrPreconditionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return http.StatusInternalServerError
+}
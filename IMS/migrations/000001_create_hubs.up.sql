@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS hubs (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    name VARCHAR(255) NOT NULL,
+    address VARCHAR(512) NOT NULL,
+    city VARCHAR(100),
+    state VARCHAR(100),
+    country VARCHAR(100),
+    postal_code VARCHAR(10),
+    contact_name VARCHAR(255),
+    contact_email VARCHAR(255)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hubs_name ON hubs (name);
+CREATE INDEX IF NOT EXISTS idx_hubs_deleted_at ON hubs (deleted_at);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS skus (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    code VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    tenant_id VARCHAR(255) NOT NULL,
+    seller_id VARCHAR(255) NOT NULL,
+    category VARCHAR(100),
+    price DOUBLE PRECISION
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skus_code ON skus (code);
+CREATE INDEX IF NOT EXISTS idx_skus_tenant_id ON skus (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_skus_seller_id ON skus (seller_id);
+CREATE INDEX IF NOT EXISTS idx_skus_deleted_at ON skus (deleted_at);
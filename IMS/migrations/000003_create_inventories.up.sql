@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS inventories (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    hub_id BIGINT NOT NULL REFERENCES hubs (id) ON DELETE CASCADE,
+    sku_id BIGINT NOT NULL REFERENCES skus (id) ON DELETE CASCADE,
+    quantity BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sku_hub ON inventories (hub_id, sku_id);
+CREATE INDEX IF NOT EXISTS idx_inventories_deleted_at ON inventories (deleted_at);
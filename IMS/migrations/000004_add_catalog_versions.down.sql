@@ -0,0 +1,2 @@
+ALTER TABLE skus DROP COLUMN IF EXISTS version;
+ALTER TABLE hubs DROP COLUMN IF EXISTS version;
@@ -0,0 +1,2 @@
+ALTER TABLE hubs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE skus ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
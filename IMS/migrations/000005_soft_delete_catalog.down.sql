@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_skus_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skus_code ON skus (code);
+DROP INDEX IF EXISTS idx_hubs_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hubs_name ON hubs (name);
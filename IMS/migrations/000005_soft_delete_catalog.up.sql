@@ -0,0 +1,14 @@
+-- Deleted hubs and SKUs no longer reserve their name or code.
+DROP INDEX IF EXISTS idx_hubs_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hubs_name ON hubs (name) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_skus_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skus_code ON skus (code) WHERE deleted_at IS NULL;
+
+-- Inventory now follows its hub or SKU into the trash; catch up rows left
+-- behind by earlier deletes.
+UPDATE inventories SET deleted_at = hubs.deleted_at
+FROM hubs
+WHERE inventories.hub_id = hubs.id AND hubs.deleted_at IS NOT NULL AND inventories.deleted_at IS NULL;
+UPDATE inventories SET deleted_at = skus.deleted_at
+FROM skus
+WHERE inventories.sku_id = skus.id AND skus.deleted_at IS NOT NULL AND inventories.deleted_at IS NULL;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS inventory_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    sku_id BIGINT NOT NULL REFERENCES skus (id),
+    source_hub_id BIGINT NOT NULL REFERENCES hubs (id),
+    destination_hub_id BIGINT NOT NULL REFERENCES hubs (id),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL,
+    reference VARCHAR(255),
+    dispatched_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    CHECK (source_hub_id <> destination_hub_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_transfers_sku_id ON inventory_transfers (sku_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_transfers_source_hub_id ON inventory_transfers (source_hub_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_transfers_destination_hub_id ON inventory_transfers (destination_hub_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_transfers_status ON inventory_transfers (status);
+
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    hub_id BIGINT NOT NULL REFERENCES hubs (id),
+    sku_id BIGINT NOT NULL REFERENCES skus (id),
+    quantity BIGINT NOT NULL,
+    quantity_after BIGINT NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    transfer_id BIGINT REFERENCES inventory_transfers (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_hub_sku ON inventory_movements (hub_id, sku_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_transfer_id ON inventory_movements (transfer_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS inventory_alerts;
+ALTER TABLE inventories DROP COLUMN IF EXISTS safety_stock;
+ALTER TABLE inventories DROP COLUMN IF EXISTS reorder_point;
@@ -0,0 +1,19 @@
+ALTER TABLE inventories ADD COLUMN IF NOT EXISTS reorder_point BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE inventories ADD COLUMN IF NOT EXISTS safety_stock BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS inventory_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    hub_id BIGINT NOT NULL REFERENCES hubs (id),
+    sku_id BIGINT NOT NULL REFERENCES skus (id),
+    level VARCHAR(20) NOT NULL,
+    quantity BIGINT NOT NULL,
+    reorder_point BIGINT NOT NULL,
+    safety_stock BIGINT NOT NULL,
+    below_safety_stock BOOLEAN NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+-- One open alert per hub/SKU pair.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inventory_alerts_open ON inventory_alerts (hub_id, sku_id) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_inventory_alerts_created_at ON inventory_alerts (created_at);
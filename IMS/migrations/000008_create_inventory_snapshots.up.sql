@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS inventory_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    snapshot_date DATE NOT NULL,
+    hub_id BIGINT NOT NULL REFERENCES hubs (id),
+    sku_id BIGINT NOT NULL REFERENCES skus (id),
+    quantity BIGINT NOT NULL,
+    unit_price DOUBLE PRECISION
+);
+
+-- One row per pair and day; re-running a day's snapshot overwrites it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inventory_snapshots_pair ON inventory_snapshots (snapshot_date, hub_id, sku_id);
@@ -0,0 +1,3 @@
+ALTER TABLE inventory_movements DROP COLUMN IF EXISTS cycle_count_id;
+DROP TABLE IF EXISTS cycle_count_lines;
+DROP TABLE IF EXISTS cycle_counts;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS cycle_counts (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    hub_id BIGINT NOT NULL REFERENCES hubs (id),
+    category VARCHAR(100),
+    status VARCHAR(20) NOT NULL,
+    approved_by VARCHAR(255),
+    approved_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_cycle_counts_hub_id ON cycle_counts (hub_id);
+CREATE INDEX IF NOT EXISTS idx_cycle_counts_status ON cycle_counts (status);
+
+CREATE TABLE IF NOT EXISTS cycle_count_lines (
+    id BIGSERIAL PRIMARY KEY,
+    cycle_count_id BIGINT NOT NULL REFERENCES cycle_counts (id) ON DELETE CASCADE,
+    sku_id BIGINT NOT NULL REFERENCES skus (id),
+    system_quantity BIGINT NOT NULL,
+    counted_quantity BIGINT,
+    variance BIGINT,
+    counted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cycle_count_lines_sku ON cycle_count_lines (cycle_count_id, sku_id);
+
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS cycle_count_id BIGINT REFERENCES cycle_counts (id);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_cycle_count_id ON inventory_movements (cycle_count_id);
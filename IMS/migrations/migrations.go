@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS holds the versioned SQL migrations so the ims binary can run them
+// without the migrations directory being present on disk.
+//
+//go:embed *.sql
+var FS embed.FS
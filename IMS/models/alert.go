@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type StockLevel string
+
+const (
+	StockOK          StockLevel = "ok"
+	StockLow         StockLevel = "low_stock"
+	StockBelowSafety StockLevel = "below_safety_stock"
+	StockOutOfStock  StockLevel = "out_of_stock"
+)
+
+// InventoryAlert records a hub/SKU pair falling to its reorder point or
+// below its safety stock. At most one alert per pair is open; it is resolved
+// when the level changes again, and a new alert is opened if the new level
+// is not ok.
+type InventoryAlert struct {
+	ID               uint       `gorm:"primarykey" json:"id"`
+	CreatedAt        time.Time  `gorm:"not null" json:"created_at"`
+	HubID            uint       `gorm:"not null;uniqueIndex:idx_inventory_alerts_open,where:resolved_at IS NULL" json:"hub_id"`
+	SKUID            uint       `gorm:"column:sku_id;not null;uniqueIndex:idx_inventory_alerts_open,where:resolved_at IS NULL" json:"sku_id"`
+	Level            StockLevel `gorm:"type:varchar(20);not null" json:"level"`
+	Quantity         int        `gorm:"not null" json:"quantity"`
+	ReorderPoint     int        `gorm:"not null" json:"reorder_point"`
+	SafetyStock      int        `gorm:"not null" json:"safety_stock"`
+	BelowSafetyStock bool       `gorm:"not null" json:"below_safety_stock"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+}
+
+func NewInventoryAlert(inventory Inventory, level StockLevel) InventoryAlert {
+	return InventoryAlert{
+		HubID:            inventory.HubID,
+		SKUID:            inventory.SKUID,
+		Level:            level,
+		Quantity:         inventory.Quantity,
+		ReorderPoint:     inventory.ReorderPoint,
+		SafetyStock:      inventory.SafetyStock,
+		BelowSafetyStock: inventory.Quantity < inventory.SafetyStock,
+	}
+}
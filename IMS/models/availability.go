@@ -0,0 +1,83 @@
+package models
+
+// SKUAvailability is the on-hand stock of one SKU, in total and per hub.
+// Hubs without stock are left out.
+type SKUAvailability struct {
+	SKUID   uint              `json:"sku_id"`
+	SKUCode string            `json:"sku_code"`
+	Total   int               `json:"total"`
+	InStock bool              `json:"in_stock"`
+	Hubs    []HubAvailability `json:"hubs"`
+}
+
+type HubAvailability struct {
+	HubID      uint   `json:"hub_id"`
+	HubName    string `json:"hub_name"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postal_code"`
+	Quantity   int    `json:"quantity"`
+}
+
+func NewSKUAvailability(sku SKU, hubs []HubAvailability) SKUAvailability {
+	if hubs == nil {
+		hubs = []HubAvailability{}
+	}
+	total := 0
+	for _, hub := range hubs {
+		total += hub.Quantity
+	}
+	return SKUAvailability{SKUID: sku.ID, SKUCode: sku.Code, Total: total, InStock: total > 0, Hubs: hubs}
+}
+
+// AvailabilityLine asks whether a hub holds RequiredQuantity of a SKU. The
+// hub and the SKU are each given by ID or, when the ID is zero, by code; a
+// hub's code is its name.
+type AvailabilityLine struct {
+	HubID            uint   `json:"hub_id,omitempty"`
+	HubCode          string `json:"hub_code,omitempty"`
+	SKUID            uint   `json:"sku_id,omitempty"`
+	SKUCode          string `json:"sku_code,omitempty"`
+	RequiredQuantity int    `json:"required_quantity"`
+}
+
+type LineAvailability struct {
+	Index             int    `json:"index"`
+	HubID             uint   `json:"hub_id"`
+	HubCode           string `json:"hub_code"`
+	SKUID             uint   `json:"sku_id"`
+	SKUCode           string `json:"sku_code"`
+	RequiredQuantity  int    `json:"required_quantity"`
+	AvailableQuantity int    `json:"available_quantity"`
+	Available         bool   `json:"available"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// AvailabilityCheck answers a batch of lines. Lines with a Reason could not be
+// resolved. CanFulfil holds when every line resolved and the stock of each
+// hub/SKU pair covers the combined quantity of its lines; lines that are each
+// available on their own can still add up to more than the pair holds.
+type AvailabilityCheck struct {
+	CanFulfil bool               `json:"can_fulfil"`
+	Lines     []LineAvailability `json:"lines"`
+}
+
+func NewAvailabilityCheck(lines []LineAvailability) AvailabilityCheck {
+	demand := make(map[[2]uint]int, len(lines))
+	canFulfil := true
+	for i := range lines {
+		line := &lines[i]
+		line.Available = line.Reason == "" && line.AvailableQuantity >= line.RequiredQuantity
+		if line.Reason != "" {
+			canFulfil = false
+			continue
+		}
+		key := [2]uint{line.HubID, line.SKUID}
+		demand[key] += line.RequiredQuantity
+		if demand[key] > line.AvailableQuantity {
+			canFulfil = false
+		}
+	}
+	return AvailabilityCheck{CanFulfil: canFulfil, Lines: lines}
+}
@@ -0,0 +1,20 @@
+package models
+
+type BatchItemStatus string
+
+const (
+	BatchItemCreated   BatchItemStatus = "created"
+	BatchItemUpdated   BatchItemStatus = "updated"
+	BatchItemDuplicate BatchItemStatus = "duplicate"
+	BatchItemInvalid   BatchItemStatus = "invalid"
+	// BatchItemValid marks an item that passed a dry run.
+	BatchItemValid BatchItemStatus = "valid"
+)
+
+type BatchItemResult struct {
+	Index  int             `json:"index"`
+	Status BatchItemStatus `json:"status"`
+	ID     uint            `json:"id,omitempty"`
+	Reason string          `json:"reason,omitempty"`
+	Errors interface{}     `json:"errors,omitempty"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+type CycleCountStatus string
+
+const (
+	CycleCountOpen      CycleCountStatus = "open"
+	CycleCountApproved  CycleCountStatus = "approved"
+	CycleCountCancelled CycleCountStatus = "cancelled"
+)
+
+const MovementCycleCount MovementReason = "cycle_count"
+
+// CycleCount audits the stock of a hub, optionally only SKUs of one
+// category. Counts can be submitted and corrected while it is open; stock
+// only changes once a manager approves the variance.
+type CycleCount struct {
+	ID          uint             `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	HubID       uint             `gorm:"not null;index" json:"hub_id"`
+	Category    string           `gorm:"type:varchar(100)" json:"category,omitempty"`
+	Status      CycleCountStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	ApprovedBy  string           `gorm:"type:varchar(255)" json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time       `json:"approved_at,omitempty"`
+	CancelledAt *time.Time       `json:"cancelled_at,omitempty"`
+	Lines       []CycleCountLine `gorm:"foreignKey:CycleCountID" json:"lines,omitempty"`
+}
+
+// CycleCountLine is one SKU of a count. SystemQuantity is the stock when the
+// line was last counted, or when the task was created until then, and
+// Variance is CountedQuantity minus SystemQuantity. Approval adds Variance
+// to the stock, so movements between counting and approval are kept.
+type CycleCountLine struct {
+	ID              uint       `gorm:"primarykey" json:"-"`
+	CycleCountID    uint       `gorm:"not null;uniqueIndex:idx_cycle_count_lines_sku" json:"-"`
+	SKUID           uint       `gorm:"column:sku_id;not null;uniqueIndex:idx_cycle_count_lines_sku" json:"sku_id"`
+	SystemQuantity  int        `gorm:"not null" json:"system_quantity"`
+	CountedQuantity *int       `json:"counted_quantity"`
+	Variance        *int       `json:"variance"`
+	CountedAt       *time.Time `json:"counted_at,omitempty"`
+}
+
+// Count records a counted quantity against the current system quantity.
+func (l *CycleCountLine) Count(counted, system int, at time.Time) {
+	variance := counted - system
+	l.SystemQuantity = system
+	l.CountedQuantity, l.Variance, l.CountedAt = &counted, &variance, &at
+}
+
+// CycleCountEntry is a counted quantity submitted by an operator.
+type CycleCountEntry struct {
+	SKUID           uint `json:"sku_id"`
+	CountedQuantity int  `json:"counted_quantity"`
+}
+
+// CycleCountVariance summarises a count for review: the lines whose counted
+// quantity differs from the system, and how many lines are still uncounted.
+type CycleCountVariance struct {
+	CycleCountID uint             `json:"cycle_count_id"`
+	Status       CycleCountStatus `json:"status"`
+	Counted      int              `json:"counted"`
+	Uncounted    int              `json:"uncounted"`
+	NetVariance  int              `json:"net_variance"`
+	Lines        []CycleCountLine `json:"lines"`
+}
+
+func NewCycleCountVariance(count CycleCount) CycleCountVariance {
+	summary := CycleCountVariance{CycleCountID: count.ID, Status: count.Status, Lines: []CycleCountLine{}}
+	for _, line := range count.Lines {
+		if line.Variance == nil {
+			summary.Uncounted++
+			continue
+		}
+		summary.Counted++
+		if *line.Variance != 0 {
+			summary.NetVariance += *line.Variance
+			summary.Lines = append(summary.Lines, line)
+		}
+	}
+	return summary
+}
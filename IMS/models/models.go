@@ -8,7 +8,7 @@ import (
 
 type Hub struct {
 	gorm.Model
-	Name         string `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"`
+	Name         string `gorm:"type:varchar(255);not null;uniqueIndex:idx_hubs_name,where:deleted_at IS NULL" json:"name"`
 	Address      string `gorm:"type:varchar(512);not null" json:"address"`
 	City         string `gorm:"type:varchar(100)" json:"city"`
 	State        string `gorm:"type:varchar(100)" json:"state"`
@@ -16,17 +16,20 @@ type Hub struct {
 	PostalCode   string `gorm:"type:varchar(10)" json:"postal_code"`
 	ContactName  string `gorm:"type:varchar(255)" json:"contact_name"`
 	ContactEmail string `gorm:"type:varchar(255)" json:"contact_email"`
+	// Version is bumped on every update and served as the ETag.
+	Version uint `gorm:"not null;default:1" json:"version"`
 }
 
 type SKU struct {
 	gorm.Model
-	Code        string          `gorm:"type:varchar(255);not null;uniqueIndex" json:"code"`
+	Code        string          `gorm:"type:varchar(255);not null;uniqueIndex:idx_skus_code,where:deleted_at IS NULL" json:"code"`
 	Name        string          `gorm:"type:varchar(255);not null" json:"name"`
 	Description string          `gorm:"type:text" json:"description"`
 	TenantId    string          `gorm:"type:varchar(255);not null;index" json:"tenant_id"`
 	SellerId    string          `gorm:"type:varchar(255);not null;index" json:"seller_id"`
 	Category    string          `gorm:"type:varchar(100)" json:"category"`
 	Price       sql.NullFloat64 `json:"price"`
+	Version     uint            `gorm:"not null;default:1" json:"version"`
 }
 
 type Inventory struct {
@@ -36,4 +39,24 @@ type Inventory struct {
 	SKUID    uint `gorm:"column:sku_id;not null;uniqueIndex:idx_sku_hub" json:"sku_id"`
 	SKU      SKU  `gorm:"foreignKey:SKUID;constraint:OnDelete:CASCADE" json:"sku"`
 	Quantity int  `gorm:"not null;default:0" json:"quantity"`
+	// ReorderPoint and SafetyStock are set through the thresholds endpoint;
+	// inventory upserts leave them alone. Zero disables alerting.
+	ReorderPoint int `gorm:"not null;default:0" json:"reorder_point"`
+	SafetyStock  int `gorm:"not null;default:0" json:"safety_stock"`
+}
+
+// StockLevel places Quantity against the thresholds, most severe first.
+// Pairs with neither a reorder point nor a safety stock are always ok.
+func (i Inventory) StockLevel() StockLevel {
+	switch {
+	case i.ReorderPoint == 0 && i.SafetyStock == 0:
+		return StockOK
+	case i.Quantity <= 0:
+		return StockOutOfStock
+	case i.Quantity < i.SafetyStock:
+		return StockBelowSafety
+	case i.Quantity <= i.ReorderPoint:
+		return StockLow
+	}
+	return StockOK
 }
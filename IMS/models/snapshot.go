@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// InventorySnapshot is the quantity of a hub/SKU pair at the close of
+// SnapshotDate (UTC). UnitPrice is the SKU price when the snapshot was taken,
+// so later price changes do not move past valuations; it is null for SKUs
+// without a price.
+type InventorySnapshot struct {
+	ID           uint      `gorm:"primarykey" json:"-"`
+	CreatedAt    time.Time `gorm:"not null" json:"taken_at"`
+	SnapshotDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_inventory_snapshots_pair" json:"snapshot_date"`
+	HubID        uint      `gorm:"not null;uniqueIndex:idx_inventory_snapshots_pair" json:"hub_id"`
+	SKUID        uint      `gorm:"column:sku_id;not null;uniqueIndex:idx_inventory_snapshots_pair" json:"sku_id"`
+	Quantity     int       `gorm:"not null" json:"quantity"`
+	UnitPrice    *float64  `json:"unit_price"`
+}
+
+// StockPosition is one pair of a StockReport. Value is null when the SKU had
+// no price.
+type StockPosition struct {
+	HubID     uint     `json:"hub_id"`
+	SKUID     uint     `json:"sku_id"`
+	Quantity  int      `json:"quantity"`
+	UnitPrice *float64 `json:"unit_price"`
+	Value     *float64 `json:"value"`
+}
+
+// StockReport is stock as of a date, read from the latest snapshot taken on
+// or before it. SnapshotDate is null when there is no such snapshot.
+// TotalValue only covers priced SKUs; UnpricedQuantity counts the rest.
+type StockReport struct {
+	AsOf             time.Time       `json:"as_of"`
+	SnapshotDate     *time.Time      `json:"snapshot_date"`
+	TotalValue       float64         `json:"total_value"`
+	UnpricedQuantity int             `json:"unpriced_quantity"`
+	Positions        []StockPosition `json:"positions"`
+}
+
+func NewStockReport(asOf time.Time, snapshotDate *time.Time, snapshots []InventorySnapshot) *StockReport {
+	report := &StockReport{AsOf: asOf, SnapshotDate: snapshotDate, Positions: make([]StockPosition, len(snapshots))}
+	for i, s := range snapshots {
+		position := StockPosition{HubID: s.HubID, SKUID: s.SKUID, Quantity: s.Quantity, UnitPrice: s.UnitPrice}
+		if s.UnitPrice != nil {
+			value := float64(s.Quantity) * *s.UnitPrice
+			position.Value = &value
+			report.TotalValue += value
+		} else {
+			report.UnpricedQuantity += s.Quantity
+		}
+		report.Positions[i] = position
+	}
+	return report
+}
+
+// StockChange is the movement of a pair between two snapshots. A pair absent
+// from a snapshot counts as zero.
+type StockChange struct {
+	HubID        uint `json:"hub_id"`
+	SKUID        uint `json:"sku_id"`
+	FromQuantity int  `json:"from_quantity"`
+	ToQuantity   int  `json:"to_quantity"`
+	Change       int  `json:"change"`
+}
+
+// StockChangeReport lists the pairs whose quantity differs between the
+// latest snapshots on or before From and To.
+type StockChangeReport struct {
+	From             time.Time     `json:"from"`
+	To               time.Time     `json:"to"`
+	FromSnapshotDate *time.Time    `json:"from_snapshot_date"`
+	ToSnapshotDate   *time.Time    `json:"to_snapshot_date"`
+	Changes          []StockChange `json:"changes"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type TransferStatus string
+
+const (
+	TransferInTransit TransferStatus = "in_transit"
+	TransferReceived  TransferStatus = "received"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// InventoryTransfer moves Quantity units of a SKU between hubs. Dispatch
+// takes the stock out of the source hub and leaves it in transit until the
+// destination receives it or the transfer is cancelled and the stock returns.
+type InventoryTransfer struct {
+	ID               uint           `gorm:"primarykey" json:"id"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	SKUID            uint           `gorm:"column:sku_id;not null;index" json:"sku_id"`
+	SourceHubID      uint           `gorm:"not null;index" json:"source_hub_id"`
+	DestinationHubID uint           `gorm:"not null;index" json:"destination_hub_id"`
+	Quantity         int            `gorm:"not null" json:"quantity"`
+	Status           TransferStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	Reference        string         `gorm:"type:varchar(255)" json:"reference,omitempty"`
+	DispatchedAt     *time.Time     `json:"dispatched_at,omitempty"`
+	ReceivedAt       *time.Time     `json:"received_at,omitempty"`
+	CancelledAt      *time.Time     `json:"cancelled_at,omitempty"`
+}
+
+type MovementReason string
+
+const (
+	MovementTransferOut    MovementReason = "transfer_out"
+	MovementTransferIn     MovementReason = "transfer_in"
+	MovementTransferReturn MovementReason = "transfer_return"
+)
+
+// InventoryMovement is one entry of the append-only inventory history: a
+// signed change to a hub/SKU quantity and the quantity it left behind.
+type InventoryMovement struct {
+	ID            uint           `gorm:"primarykey" json:"id"`
+	CreatedAt     time.Time      `gorm:"not null" json:"created_at"`
+	HubID         uint           `gorm:"not null" json:"hub_id"`
+	SKUID         uint           `gorm:"column:sku_id;not null" json:"sku_id"`
+	Quantity      int            `gorm:"not null" json:"quantity"`
+	QuantityAfter int            `gorm:"not null" json:"quantity_after"`
+	Reason        MovementReason `gorm:"type:varchar(50);not null" json:"reason"`
+	TransferID    *uint          `gorm:"index" json:"transfer_id,omitempty"`
+	CycleCountID  *uint          `gorm:"index" json:"cycle_count_id,omitempty"`
+}
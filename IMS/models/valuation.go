@@ -0,0 +1,52 @@
+package models
+
+// ValuationDimension is a column a valuation report can be grouped by.
+type ValuationDimension string
+
+const (
+	ValuationByHub      ValuationDimension = "hub"
+	ValuationByCategory ValuationDimension = "category"
+	ValuationByTenant   ValuationDimension = "tenant"
+	ValuationBySeller   ValuationDimension = "seller"
+)
+
+var ValuationDimensions = []ValuationDimension{ValuationByHub, ValuationByCategory, ValuationByTenant, ValuationBySeller}
+
+// ValuationRow is the stock of one group. Columns the report is not grouped
+// by are left empty. Value only covers SKUs with a price and is null when
+// none of the group's stock is priced; unpriced stock is counted separately
+// instead of being valued at zero.
+type ValuationRow struct {
+	HubID            uint     `json:"hub_id"`
+	HubName          string   `json:"hub_name"`
+	Category         string   `json:"category"`
+	TenantID         string   `json:"tenant_id"`
+	SellerID         string   `json:"seller_id"`
+	Quantity         int      `json:"quantity"`
+	UnpricedQuantity int      `json:"unpriced_quantity"`
+	UnpricedSKUs     int      `gorm:"column:unpriced_skus" json:"unpriced_skus"`
+	Value            *float64 `json:"value"`
+}
+
+type ValuationReport struct {
+	GroupBy          []ValuationDimension `json:"group_by"`
+	TotalQuantity    int                  `json:"total_quantity"`
+	UnpricedQuantity int                  `json:"unpriced_quantity"`
+	TotalValue       float64              `json:"total_value"`
+	Rows             []ValuationRow       `json:"rows"`
+}
+
+func NewValuationReport(groupBy []ValuationDimension, rows []ValuationRow) *ValuationReport {
+	report := &ValuationReport{GroupBy: groupBy, Rows: rows}
+	if report.Rows == nil {
+		report.Rows = []ValuationRow{}
+	}
+	for _, row := range rows {
+		report.TotalQuantity += row.Quantity
+		report.UnpricedQuantity += row.UnpricedQuantity
+		if row.Value != nil {
+			report.TotalValue += *row.Value
+		}
+	}
+	return report
+}
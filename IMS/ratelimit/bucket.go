@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+)
+
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// bucket is the token-bucket state MemoryStore keeps per key; UpdatedMS is
+// zero for a new bucket, which starts full.
+type bucket struct {
+	Tokens    float64
+	UpdatedMS int64
+}
+
+// take refills b for the time elapsed since its last update and removes one
+// token if available. redisScript implements the same steps in Lua.
+func take(b *bucket, limit config.RateLimit, now time.Time) Result {
+	nowMS := now.UnixMilli()
+	burst := float64(limit.Burst)
+	if b.UpdatedMS == 0 {
+		b.Tokens = burst
+	} else if elapsed := nowMS - b.UpdatedMS; elapsed > 0 {
+		b.Tokens = math.Min(burst, b.Tokens+float64(elapsed)*limit.RequestsPerSecond/1000)
+	}
+	b.UpdatedMS = nowMS
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return Result{Allowed: true, Remaining: int(b.Tokens)}
+	}
+	wait := math.Ceil((1 - b.Tokens) * 1000 / limit.RequestsPerSecond)
+	return Result{RetryAfter: time.Duration(wait) * time.Millisecond}
+}
+
+// ttl is how long an idle bucket takes to refill completely, after which its
+// state is indistinguishable from a new bucket and can expire.
+func ttl(limit config.RateLimit) time.Duration {
+	return time.Duration(float64(limit.Burst)/limit.RequestsPerSecond*float64(time.Second)) + time.Second
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultGroup = "default"
+
+type Limiter struct {
+	Store  Store
+	Config config.RateLimitConfig
+	groups map[string]config.RateLimitGroup
+	now    func() time.Time
+}
+
+func NewLimiter(store Store, cfg config.RateLimitConfig) *Limiter {
+	groups := make(map[string]config.RateLimitGroup)
+	for _, g := range cfg.Groups {
+		for _, route := range g.Routes {
+			groups[route] = g
+		}
+	}
+	return &Limiter{Store: store, Config: cfg, groups: groups, now: time.Now}
+}
+
+// Resolve returns the group a route template belongs to and the limit that
+// applies to tenant within it.
+func (l *Limiter) Resolve(tenant, route string) (string, config.RateLimit) {
+	name, limit := defaultGroup, l.Config.Default
+	if g, ok := l.groups[route]; ok {
+		name, limit = g.Name, g.RateLimit
+	}
+	if override, ok := l.Config.Tenants[tenant][name]; ok {
+		limit = override
+	}
+	return name, limit
+}
+
+// CheckRoutes reports the first grouped route that is not among routes. A
+// typo in a group's route list would otherwise leave that route on the
+// default limit without any sign of it.
+func (l *Limiter) CheckRoutes(routes gin.RoutesInfo) error {
+	registered := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		registered[r.Path] = true
+	}
+	for _, g := range l.Config.Groups {
+		for _, route := range g.Routes {
+			if !registered[route] {
+				return fmt.Errorf("rate_limit group %q lists %s, which is not a registered route", g.Name, route)
+			}
+		}
+	}
+	return nil
+}
+
+// Allow takes a token from the bucket shared by tenant and route's group,
+// and from the bucket of the client IP in that group. The tenant comes from
+// a header the caller chooses, so the IP bucket stops a client from getting
+// fresh buckets by changing it. The IP bucket is checked first so a client
+// it rejects does not drain the tenant's.
+func (l *Limiter) Allow(ctx context.Context, tenant, clientIP, route string) (string, config.RateLimit, Result, error) {
+	group, limit := l.Resolve(tenant, route)
+	now := l.now()
+	byIP, err := l.Store.Take(ctx, "ratelimit:"+group+":ip:"+clientIP, limit, now)
+	if err != nil || !byIP.Allowed || tenant == "ip:"+clientIP {
+		return group, limit, byIP, err
+	}
+	result, err := l.Store.Take(ctx, "ratelimit:"+group+":"+tenant, limit, now)
+	if err == nil && byIP.Remaining < result.Remaining {
+		result.Remaining = byIP.Remaining
+	}
+	return group, limit, result, err
+}
@@ -0,0 +1,48 @@
+package ratelimit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/middleware"
+	"github.com/Trishank-Omniful/Onboarding-Task/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddlewareAnswers429WithRetryAfter(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.Groups[0].RateLimit = config.RateLimit{RequestsPerSecond: 0.5, Burst: 1}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1/ims")
+	api.Use(middleware.RateLimitMiddleware(ratelimit.NewLimiter(ratelimit.NewMemoryStore(), cfg)))
+	api.POST("/inventory/batch", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/ims/inventory/batch", nil)
+		req.Header.Set("X-Tenant-ID", "tenant_1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(); w.Code != http.StatusOK {
+		t.Fatalf("first request = %d, want 200", w.Code)
+	}
+	w := send()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2 seconds at 0.5 req/s", got)
+	}
+	var body middleware.ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Code != "RATE_LIMITED" {
+		t.Errorf("code = %q, want RATE_LIMITED", body.Code)
+	}
+}
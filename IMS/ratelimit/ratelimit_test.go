@@ -0,0 +1,190 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestTakeRefillsAtTheConfiguredRate(t *testing.T) {
+	limit := config.RateLimit{RequestsPerSecond: 2, Burst: 3}
+	start := time.UnixMilli(1_000_000)
+	var b bucket
+
+	for i := 0; i < 3; i++ {
+		if r := take(&b, limit, start); !r.Allowed {
+			t.Fatalf("request %d rejected, want the burst of 3 allowed", i)
+		}
+	}
+	r := take(&b, limit, start)
+	if r.Allowed || r.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("got %+v, want a rejection retrying after one token's refill", r)
+	}
+
+	if r := take(&b, limit, start.Add(500*time.Millisecond)); !r.Allowed || r.Remaining != 0 {
+		t.Errorf("got %+v after refilling one token", r)
+	}
+	if r := take(&b, limit, start.Add(time.Hour)); !r.Allowed || r.Remaining != 2 {
+		t.Errorf("got %+v, want the bucket capped at its burst", r)
+	}
+}
+
+func TestResolveUsesGroupThenTenantOverride(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.Tenants = map[string]map[string]config.RateLimit{
+		"tenant_big": {"batch": {RequestsPerSecond: 20, Burst: 40}},
+	}
+	l := NewLimiter(NewMemoryStore(), cfg)
+
+	if group, limit := l.Resolve("tenant_1", "/api/v1/ims/inventory/batch"); group != "batch" || limit.Burst != 5 {
+		t.Errorf("got %s %+v, want the batch group limit", group, limit)
+	}
+	if _, limit := l.Resolve("tenant_big", "/api/v1/ims/inventory/batch"); limit.Burst != 40 {
+		t.Errorf("got %+v, want the tenant override", limit)
+	}
+	if group, limit := l.Resolve("tenant_big", "/api/v1/ims/hub/:id"); group != "default" || limit != cfg.Default {
+		t.Errorf("got %s %+v, want the default limit for ungrouped routes", group, limit)
+	}
+}
+
+func TestTenantsHaveSeparateBuckets(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.Default = config.RateLimit{RequestsPerSecond: 1, Burst: 1}
+	l := NewLimiter(NewMemoryStore(), cfg)
+	l.now = func() time.Time { return time.UnixMilli(1_000_000) }
+
+	ctx := context.Background()
+	if _, _, r, _ := l.Allow(ctx, "tenant_1", "10.0.0.1", "/api/v1/ims/hub"); !r.Allowed {
+		t.Fatal("first request rejected")
+	}
+	if _, _, r, _ := l.Allow(ctx, "tenant_1", "10.0.0.2", "/api/v1/ims/hub"); r.Allowed {
+		t.Error("second request from the same tenant allowed past a burst of 1")
+	}
+	if _, _, r, _ := l.Allow(ctx, "tenant_2", "10.0.0.3", "/api/v1/ims/hub"); !r.Allowed {
+		t.Error("another tenant was throttled by tenant_1's bucket")
+	}
+}
+
+func TestClientIPIsLimitedWhateverTenantItClaims(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.Default = config.RateLimit{RequestsPerSecond: 1, Burst: 1}
+	l := NewLimiter(NewMemoryStore(), cfg)
+	l.now = func() time.Time { return time.UnixMilli(1_000_000) }
+
+	ctx := context.Background()
+	if _, _, r, _ := l.Allow(ctx, "tenant_1", "10.0.0.1", "/api/v1/ims/hub"); !r.Allowed {
+		t.Fatal("first request rejected")
+	}
+	if _, _, r, _ := l.Allow(ctx, "tenant_2", "10.0.0.1", "/api/v1/ims/hub"); r.Allowed {
+		t.Error("switching X-Tenant-ID gave the same IP a fresh bucket")
+	}
+	if _, _, r, _ := l.Allow(ctx, "tenant_2", "10.0.0.2", "/api/v1/ims/hub"); !r.Allowed {
+		t.Error("the request rejected for its IP drained tenant_2's bucket")
+	}
+}
+
+// fakeScripter answers like a Redis whose script cache can be flushed,
+// recording every script call the store makes.
+type fakeScripter struct {
+	loaded  map[string]bool
+	loadErr error
+	reply   interface{}
+	calls   []string
+	keys    []string
+	args    []interface{}
+}
+
+func (f *fakeScripter) ScriptLoad(_ context.Context, script string) *redis.StringCmd {
+	if f.loadErr != nil {
+		return redis.NewStringResult("", f.loadErr)
+	}
+	sha := redis.NewScript(script).Hash()
+	f.loaded[sha] = true
+	return redis.NewStringResult(sha, nil)
+}
+
+func (f *fakeScripter) ScriptExists(_ context.Context, hashes ...string) *redis.BoolSliceCmd {
+	exists := make([]bool, len(hashes))
+	for i, h := range hashes {
+		exists[i] = f.loaded[h]
+	}
+	return redis.NewBoolSliceResult(exists, nil)
+}
+
+func (f *fakeScripter) EvalSha(_ context.Context, sha string, keys []string, args ...interface{}) *redis.Cmd {
+	f.calls = append(f.calls, "evalsha")
+	if !f.loaded[sha] {
+		return redis.NewCmdResult(nil, errors.New("NOSCRIPT No matching script. Please use EVAL."))
+	}
+	f.keys, f.args = keys, args
+	return redis.NewCmdResult(f.reply, nil)
+}
+
+func (f *fakeScripter) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	f.calls = append(f.calls, "eval")
+	f.loaded[redis.NewScript(script).Hash()] = true
+	f.keys, f.args = keys, args
+	return redis.NewCmdResult(f.reply, nil)
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	limit := config.RateLimit{RequestsPerSecond: 2, Burst: 3}
+	now := time.UnixMilli(1_000_000)
+
+	if _, err := NewRedisStore(ctx, &fakeScripter{loaded: map[string]bool{}, loadErr: errors.New("NOPERM")}); err == nil {
+		t.Fatal("NewRedisStore succeeded against a Redis that refuses scripts")
+	}
+
+	f := &fakeScripter{loaded: map[string]bool{}, reply: []interface{}{int64(1), int64(2), int64(0)}}
+	s, err := NewRedisStore(ctx, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.Take(ctx, "ratelimit:default:tenant_1", limit, now)
+	if err != nil || !r.Allowed || r.Remaining != 2 {
+		t.Fatalf("Take = %+v, %v; want allowed with 2 remaining", r, err)
+	}
+	wantArgs := []interface{}{2.0, 3, int64(1_000_000), ttl(limit).Milliseconds()}
+	if !reflect.DeepEqual(f.keys, []string{"ratelimit:default:tenant_1"}) || !reflect.DeepEqual(f.args, wantArgs) {
+		t.Fatalf("script called with keys %v args %v, want args %v", f.keys, f.args, wantArgs)
+	}
+
+	f.loaded, f.calls = map[string]bool{}, nil
+	f.reply = []interface{}{int64(0), int64(0), int64(500)}
+	r, err = s.Take(ctx, "ratelimit:default:tenant_1", limit, now)
+	if err != nil || r.Allowed || r.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("Take = %+v, %v; want a rejection retrying after 500ms", r, err)
+	}
+	if !reflect.DeepEqual(f.calls, []string{"evalsha", "eval"}) {
+		t.Fatalf("calls = %v, want EVAL after a flushed script cache", f.calls)
+	}
+
+	f.reply = "OK"
+	if _, err := s.Take(ctx, "ratelimit:default:tenant_1", limit, now); err == nil {
+		t.Fatal("Take accepted a malformed reply")
+	}
+}
+
+func TestCheckRoutesRejectsUnknownGroupRoutes(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), config.RateLimitConfig{Groups: []config.RateLimitGroup{{
+		Name:   "batch",
+		Routes: []string{"/api/v1/ims/hub/batch", "/api/v1/ims/hubs/batch"},
+	}}})
+	routes := gin.RoutesInfo{{Method: "POST", Path: "/api/v1/ims/hub/batch"}}
+
+	if err := l.CheckRoutes(routes); err == nil || !strings.Contains(err.Error(), "/api/v1/ims/hubs/batch") {
+		t.Fatalf("CheckRoutes = %v, want an error naming the unknown route", err)
+	}
+	l.Config.Groups[0].Routes = l.Config.Groups[0].Routes[:1]
+	if err := l.CheckRoutes(routes); err != nil {
+		t.Fatalf("CheckRoutes = %v, want nil when every route is registered", err)
+	}
+}
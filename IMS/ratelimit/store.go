@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/go-redis/redis/v8"
+)
+
+type Store interface {
+	Take(ctx context.Context, key string, limit config.RateLimit, now time.Time) (Result, error)
+}
+
+const redisScript = `
+local burst = tonumber(ARGV[2])
+local rate = tonumber(ARGV[1])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_ms')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+elseif now > updated then
+  tokens = math.min(burst, tokens + (now - updated) * rate / 1000)
+end
+local allowed, retry = 0, 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_ms', now)
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return {allowed, math.floor(tokens), retry}
+`
+
+var tokenBucket = redis.NewScript(redisScript)
+
+// Scripter is the part of a go-redis client the store needs; both the single
+// node and cluster clients satisfy it.
+type Scripter interface {
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+	EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	ScriptExists(ctx context.Context, hashes ...string) *redis.BoolSliceCmd
+	ScriptLoad(ctx context.Context, script string) *redis.StringCmd
+}
+
+// RedisStore keeps buckets in Redis so every instance shares one limit per
+// tenant. The refill-and-take runs as a single Lua script, so concurrent
+// requests on different instances cannot spend the same token.
+type RedisStore struct {
+	Client Scripter
+}
+
+// NewRedisStore loads the bucket script up front, so a Redis that refuses
+// scripting stops the service at startup instead of failing every request.
+func NewRedisStore(ctx context.Context, client Scripter) (*RedisStore, error) {
+	if err := tokenBucket.Load(ctx, client).Err(); err != nil {
+		return nil, fmt.Errorf("load rate limit script: %w", err)
+	}
+	return &RedisStore{Client: client}, nil
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit config.RateLimit, now time.Time) (Result, error) {
+	reply, err := tokenBucket.Run(ctx, s.Client, []string{key},
+		limit.RequestsPerSecond, limit.Burst, now.UnixMilli(), ttl(limit).Milliseconds()).Result()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit script: %w", err)
+	}
+	return parseReply(reply)
+}
+
+func parseReply(reply interface{}) (Result, error) {
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 3 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	var n [3]int64
+	for i, v := range values {
+		if n[i], ok = v.(int64); !ok {
+			return Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+		}
+	}
+	return Result{Allowed: n[0] == 1, Remaining: int(n[1]), RetryAfter: time.Duration(n[2]) * time.Millisecond}, nil
+}
+
+// MemoryStore keeps buckets in process. It backs tests and single-instance
+// runs without Redis.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit config.RateLimit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{}
+		s.buckets[key] = b
+	}
+	return take(b, limit, now), nil
+}
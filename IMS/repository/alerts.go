@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/events"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AlertFilter narrows GetInventoryAlerts; zero fields match everything.
+// Resolved alerts are left out unless IncludeResolved is set.
+type AlertFilter struct {
+	HubID           uint
+	SKUID           uint
+	Level           models.StockLevel
+	IncludeResolved bool
+	Limit           int
+}
+
+// SetStockThresholds stores the reorder point and safety stock of a hub/SKU
+// pair, creating an empty inventory row if there is none, and re-evaluates
+// its alert. inventory is filled with the stored row. A missing or deleted
+// hub or SKU is an INVALID_REFERENCE.
+func (r *InventoryRepository) SetStockThresholds(ctx context.Context, inventory *models.Inventory) error {
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireLiveParents(tx, []uint{inventory.HubID}, []uint{inventory.SKUID}); err != nil {
+			return err
+		}
+		row := models.Inventory{
+			HubID:        inventory.HubID,
+			SKUID:        inventory.SKUID,
+			ReorderPoint: inventory.ReorderPoint,
+			SafetyStock:  inventory.SafetyStock,
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reorder_point", "safety_stock", "updated_at"}),
+		}).Create(&row).Error
+		if err != nil {
+			return TranslateError(err, constants.EntityInventory)
+		}
+		if err := tx.Where("hub_id = ? AND sku_id = ?", inventory.HubID, inventory.SKUID).First(inventory).Error; err != nil {
+			return TranslateError(err, constants.EntityInventory)
+		}
+		opened, err = evaluateStock(tx, [][2]uint{{inventory.HubID, inventory.SKUID}})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	publishAlerts(ctx, r.Alerts, opened)
+	return nil
+}
+
+// GetInventoryAlerts lists alerts, newest first.
+func (r *InventoryRepository) GetInventoryAlerts(ctx context.Context, filter AlertFilter) ([]models.InventoryAlert, error) {
+	query := r.DB.WithContext(ctx).Model(&models.InventoryAlert{})
+	if !filter.IncludeResolved {
+		query = query.Where("resolved_at IS NULL")
+	}
+	if filter.HubID != 0 {
+		query = query.Where("hub_id = ?", filter.HubID)
+	}
+	if filter.SKUID != 0 {
+		query = query.Where("sku_id = ?", filter.SKUID)
+	}
+	if filter.Level != "" {
+		query = query.Where("level = ?", filter.Level)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var alerts []models.InventoryAlert
+	err := query.Order("id DESC").Find(&alerts).Error
+	return alerts, err
+}
+
+// evaluateStock keeps the open alert of each hub/SKU pair in step with its
+// stock level: when the level changes the open alert is resolved and, unless
+// the pair is back to ok, an alert for the new level is opened. It runs in the
+// transaction that changed the stock and returns the alerts it opened. If a
+// concurrent transaction opened the same alert first, that one is kept.
+// Alerts are inserted one at a time: a batch insert that skips conflicts
+// returns fewer IDs than rows, with no way to tell which rows they belong to.
+func evaluateStock(tx *gorm.DB, keys [][2]uint) ([]models.InventoryAlert, error) {
+	var opened []models.InventoryAlert
+	for start := 0; start < len(keys); start += constants.UpsertChunkSize {
+		end := min(start+constants.UpsertChunkSize, len(keys))
+		pairs := make([][]interface{}, 0, end-start)
+		for _, key := range keys[start:end] {
+			pairs = append(pairs, []interface{}{key[0], key[1]})
+		}
+
+		var inventories []models.Inventory
+		err := tx.Select("hub_id", "sku_id", "quantity", "reorder_point", "safety_stock").
+			Where("(hub_id, sku_id) IN ?", pairs).Find(&inventories).Error
+		if err != nil {
+			return nil, TranslateError(err, constants.EntityInventory)
+		}
+		var open []models.InventoryAlert
+		if err := tx.Where("resolved_at IS NULL AND (hub_id, sku_id) IN ?", pairs).Find(&open).Error; err != nil {
+			return nil, err
+		}
+		current := make(map[[2]uint]models.InventoryAlert, len(open))
+		for _, alert := range open {
+			current[[2]uint{alert.HubID, alert.SKUID}] = alert
+		}
+
+		var resolve []uint
+		var create []models.InventoryAlert
+		for _, inventory := range inventories {
+			level := inventory.StockLevel()
+			alert, ok := current[[2]uint{inventory.HubID, inventory.SKUID}]
+			if ok && alert.Level == level {
+				continue
+			}
+			if ok {
+				resolve = append(resolve, alert.ID)
+			}
+			if level != models.StockOK {
+				create = append(create, models.NewInventoryAlert(inventory, level))
+			}
+		}
+
+		if len(resolve) > 0 {
+			err := tx.Model(&models.InventoryAlert{}).Where("id IN ?", resolve).Update("resolved_at", time.Now()).Error
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, alert := range create {
+			result := tx.Clauses(clause.OnConflict{
+				Columns:     []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+				TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "resolved_at IS NULL"}}},
+				DoNothing:   true,
+			}).Create(&alert)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+			if result.RowsAffected == 1 {
+				opened = append(opened, alert)
+			}
+		}
+	}
+	return opened, nil
+}
+
+// publishAlerts announces alerts after their transaction committed. The
+// alerts are already stored, so a failed publish is logged, not returned.
+func publishAlerts(ctx context.Context, publisher events.AlertPublisher, alerts []models.InventoryAlert) {
+	if publisher == nil || len(alerts) == 0 {
+		return
+	}
+	if err := publisher.PublishAlerts(ctx, alerts); err != nil {
+		slog.ErrorContext(ctx, "failed to publish stock alerts", "count", len(alerts), "error", err)
+	}
+}
+
+func inventoryKeys(inventories []models.Inventory) [][2]uint {
+	keys := make([][2]uint, len(inventories))
+	for i, inventory := range inventories {
+		keys[i] = [2]uint{inventory.HubID, inventory.SKUID}
+	}
+	return keys
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+// AvailabilityFilter limits availability to hubs in a country and/or state,
+// compared case-insensitively. Empty fields match every hub.
+type AvailabilityFilter struct {
+	Country string
+	State   string
+}
+
+// GetAvailabilityBySKUCodes totals the stock of each known SKU across hubs in
+// one query. Unknown codes are left out; known SKUs without stock are listed
+// with a zero total.
+func (r *InventoryRepository) GetAvailabilityBySKUCodes(ctx context.Context, codes []string, filter AvailabilityFilter) ([]models.SKUAvailability, error) {
+	skus, err := r.SKURepo.GetSKUsByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.DB.WithContext(ctx).Table("inventories").
+		Select("inventories.sku_id, hubs.id AS hub_id, hubs.name AS hub_name, hubs.city, hubs.state, hubs.country, hubs.postal_code, inventories.quantity").
+		Joins("JOIN hubs ON hubs.id = inventories.hub_id AND hubs.deleted_at IS NULL").
+		Joins("JOIN skus ON skus.id = inventories.sku_id AND skus.deleted_at IS NULL").
+		Where("skus.code IN ? AND inventories.deleted_at IS NULL AND inventories.quantity > 0", codes)
+	if filter.Country != "" {
+		query = query.Where("LOWER(hubs.country) = LOWER(?)", filter.Country)
+	}
+	if filter.State != "" {
+		query = query.Where("LOWER(hubs.state) = LOWER(?)", filter.State)
+	}
+
+	var rows []struct {
+		SKUID uint `gorm:"column:sku_id"`
+		models.HubAvailability
+	}
+	if err := query.Order("inventories.quantity DESC, hubs.id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	stock := make(map[uint][]models.HubAvailability)
+	for _, row := range rows {
+		stock[row.SKUID] = append(stock[row.SKUID], row.HubAvailability)
+	}
+	availability := make([]models.SKUAvailability, len(skus))
+	for i, sku := range skus {
+		availability[i] = models.NewSKUAvailability(sku, stock[sku.ID])
+	}
+	return availability, nil
+}
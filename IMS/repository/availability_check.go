@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+// CheckAvailabilityBatch resolves every line's hub and SKU and reads its
+// stock in one query. Lines naming a missing hub or SKU are reported
+// unavailable with a reason rather than failing the batch.
+func (r *InventoryRepository) CheckAvailabilityBatch(ctx context.Context, lines []models.AvailabilityLine) (*models.AvailabilityCheck, error) {
+	if len(lines) == 0 {
+		check := models.NewAvailabilityCheck([]models.LineAvailability{})
+		return &check, nil
+	}
+
+	values := make([]string, len(lines))
+	args := make([]interface{}, 0, 5*len(lines))
+	for i, line := range lines {
+		values[i] = "(?::int, ?::bigint, ?::text, ?::bigint, ?::text)"
+		args = append(args, i, line.HubID, line.HubCode, line.SKUID, line.SKUCode)
+	}
+
+	var rows []struct {
+		Line     int
+		HubID    uint
+		HubCode  string
+		SKUID    uint `gorm:"column:sku_id"`
+		SKUCode  string
+		Quantity int
+	}
+	err := r.DB.WithContext(ctx).Raw(`
+SELECT l.line,
+       COALESCE(hi.id, hn.id, 0) AS hub_id, COALESCE(hi.name, hn.name, '') AS hub_code,
+       COALESCE(si.id, sn.id, 0) AS sku_id, COALESCE(si.code, sn.code, '') AS sku_code,
+       COALESCE(inv.quantity, 0) AS quantity
+FROM (VALUES `+strings.Join(values, ", ")+`) AS l (line, hub_id, hub_code, sku_id, sku_code)
+LEFT JOIN hubs hi ON hi.id = l.hub_id AND hi.deleted_at IS NULL
+LEFT JOIN hubs hn ON l.hub_id = 0 AND hn.name = l.hub_code AND hn.deleted_at IS NULL
+LEFT JOIN skus si ON si.id = l.sku_id AND si.deleted_at IS NULL
+LEFT JOIN skus sn ON l.sku_id = 0 AND sn.code = l.sku_code AND sn.deleted_at IS NULL
+LEFT JOIN inventories inv ON inv.hub_id = COALESCE(hi.id, hn.id)
+                         AND inv.sku_id = COALESCE(si.id, sn.id)
+                         AND inv.deleted_at IS NULL
+ORDER BY l.line`, args...).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.LineAvailability, len(rows))
+	for i, row := range rows {
+		line := lines[row.Line]
+		results[i] = models.LineAvailability{
+			Index:             row.Line,
+			HubID:             row.HubID,
+			HubCode:           row.HubCode,
+			SKUID:             row.SKUID,
+			SKUCode:           row.SKUCode,
+			RequiredQuantity:  line.RequiredQuantity,
+			AvailableQuantity: row.Quantity,
+		}
+		if row.HubID == 0 || row.SKUID == 0 {
+			results[i] = unresolvedLine(row.Line, line)
+		}
+	}
+	check := models.NewAvailabilityCheck(results)
+	return &check, nil
+}
+
+// unresolvedLine echoes a line whose hub or SKU does not exist.
+func unresolvedLine(index int, line models.AvailabilityLine) models.LineAvailability {
+	return models.LineAvailability{
+		Index:            index,
+		HubID:            line.HubID,
+		HubCode:          line.HubCode,
+		SKUID:            line.SKUID,
+		SKUCode:          line.SKUCode,
+		RequiredQuantity: line.RequiredQuantity,
+		Reason:           constants.ErrInvalidReference,
+	}
+}
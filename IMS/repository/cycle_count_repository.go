@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/events"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CycleCountFilter narrows ListCycleCounts; zero fields match everything.
+type CycleCountFilter struct {
+	HubID  uint
+	Status models.CycleCountStatus
+}
+
+type CycleCountRepository struct {
+	DB     *gorm.DB
+	Alerts events.AlertPublisher
+}
+
+func NewCycleCountRepository(db *gorm.DB, alerts events.AlertPublisher) *CycleCountRepository {
+	return &CycleCountRepository{DB: db, Alerts: alerts}
+}
+
+// CreateCycleCount opens a count with one line for every SKU the hub holds a
+// row for, limited to count.Category when set.
+func (r *CycleCountRepository) CreateCycleCount(ctx context.Context, count *models.CycleCount) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hubs int64
+		if err := tx.Model(&models.Hub{}).Where("id = ?", count.HubID).Count(&hubs).Error; err != nil {
+			return err
+		}
+		if hubs == 0 {
+			return NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, nil)
+		}
+
+		count.ID = 0
+		count.Status = models.CycleCountOpen
+		count.ApprovedBy, count.ApprovedAt, count.CancelledAt = "", nil, nil
+		count.Lines = nil
+		if err := tx.Create(count).Error; err != nil {
+			return TranslateError(err, constants.EntityCycleCount)
+		}
+
+		lines := tx.Table("inventories").
+			Select("CAST(? AS BIGINT), inventories.sku_id, inventories.quantity", count.ID).
+			Joins("JOIN skus ON skus.id = inventories.sku_id AND skus.deleted_at IS NULL").
+			Where("inventories.hub_id = ? AND inventories.deleted_at IS NULL", count.HubID)
+		if count.Category != "" {
+			lines = lines.Where("skus.category = ?", count.Category)
+		}
+		err := tx.Exec("INSERT INTO cycle_count_lines (cycle_count_id, sku_id, system_quantity) ?", lines).Error
+		if err != nil {
+			return TranslateError(err, constants.EntityCycleCount)
+		}
+		return loadLines(tx, count)
+	})
+}
+
+func (r *CycleCountRepository) GetCycleCount(ctx context.Context, id uint) (*models.CycleCount, error) {
+	var count models.CycleCount
+	db := r.DB.WithContext(ctx)
+	if err := db.First(&count, id).Error; err != nil {
+		return nil, TranslateError(err, constants.EntityCycleCount)
+	}
+	if err := loadLines(db, &count); err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// ListCycleCounts lists counts without their lines, newest first.
+func (r *CycleCountRepository) ListCycleCounts(ctx context.Context, filter CycleCountFilter) ([]models.CycleCount, error) {
+	query := r.DB.WithContext(ctx).Model(&models.CycleCount{})
+	if filter.HubID != 0 {
+		query = query.Where("hub_id = ?", filter.HubID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var counts []models.CycleCount
+	err := query.Order("id DESC").Find(&counts).Error
+	return counts, err
+}
+
+// SubmitCounts records counted quantities against the current stock of the
+// hub. Recounting a SKU replaces its previous count. SKUs without a line are
+// added, as long as they exist and match the count's category.
+func (r *CycleCountRepository) SubmitCounts(ctx context.Context, id uint, entries []models.CycleCountEntry) (*models.CycleCount, error) {
+	var count models.CycleCount
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenCycleCount(tx, id, &count); err != nil {
+			return err
+		}
+
+		skuIDs := make([]uint, len(entries))
+		for i, entry := range entries {
+			skuIDs[i] = entry.SKUID
+		}
+		skus := tx.Model(&models.SKU{}).Where("id IN ?", skuIDs)
+		if count.Category != "" {
+			skus = skus.Where("category = ?", count.Category)
+		}
+		var known int64
+		if err := skus.Count(&known).Error; err != nil {
+			return err
+		}
+		if int(known) != len(entries) {
+			return NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, nil)
+		}
+
+		var inventories []models.Inventory
+		err := tx.Select("sku_id", "quantity").Where("hub_id = ? AND sku_id IN ?", count.HubID, skuIDs).Find(&inventories).Error
+		if err != nil {
+			return TranslateError(err, constants.EntityInventory)
+		}
+		system := make(map[uint]int, len(inventories))
+		for _, inventory := range inventories {
+			system[inventory.SKUID] = inventory.Quantity
+		}
+
+		now := time.Now()
+		lines := make([]models.CycleCountLine, len(entries))
+		for i, entry := range entries {
+			lines[i] = models.CycleCountLine{CycleCountID: count.ID, SKUID: entry.SKUID}
+			lines[i].Count(entry.CountedQuantity, system[entry.SKUID], now)
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "cycle_count_id"}, {Name: "sku_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"system_quantity", "counted_quantity", "variance", "counted_at"}),
+		}).Create(&lines).Error
+		if err != nil {
+			return TranslateError(err, constants.EntityCycleCount)
+		}
+		if err := tx.Model(&count).Update("updated_at", now).Error; err != nil {
+			return err
+		}
+		return loadLines(tx, &count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// ApproveCycleCount posts the variance of every counted line as a
+// cycle_count movement and closes the count. It fails without changing
+// anything if stock has since dropped below what a negative variance takes.
+func (r *CycleCountRepository) ApproveCycleCount(ctx context.Context, id uint, approvedBy string) (*models.CycleCount, error) {
+	var count models.CycleCount
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenCycleCount(tx, id, &count); err != nil {
+			return err
+		}
+		var lines []models.CycleCountLine
+		if err := tx.Where("cycle_count_id = ? AND variance <> 0", count.ID).Order("sku_id").Find(&lines).Error; err != nil {
+			return err
+		}
+
+		keys := make([][2]uint, len(lines))
+		skuIDs := make([]uint, len(lines))
+		for i, line := range lines {
+			keys[i] = [2]uint{count.HubID, line.SKUID}
+			skuIDs[i] = line.SKUID
+		}
+		var inventories []models.Inventory
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("sku_id", "quantity").
+			Where("hub_id = ? AND sku_id IN ?", count.HubID, skuIDs).Find(&inventories).Error
+		if err != nil {
+			return TranslateError(err, constants.EntityInventory)
+		}
+		current := make(map[uint]int, len(inventories))
+		for _, inventory := range inventories {
+			current[inventory.SKUID] = inventory.Quantity
+		}
+
+		for _, line := range lines {
+			if current[line.SKUID]+*line.Variance < 0 {
+				return NewInsufficientStockError(current[line.SKUID], -*line.Variance)
+			}
+			after, err := addStock(tx, count.HubID, line.SKUID, *line.Variance)
+			if err != nil {
+				return err
+			}
+			movement := models.InventoryMovement{
+				HubID:         count.HubID,
+				SKUID:         line.SKUID,
+				Quantity:      *line.Variance,
+				QuantityAfter: after,
+				Reason:        models.MovementCycleCount,
+				CycleCountID:  &count.ID,
+			}
+			if err := tx.Create(&movement).Error; err != nil {
+				return TranslateError(err, constants.EntityInventory)
+			}
+		}
+
+		now := time.Now()
+		count.Status, count.ApprovedBy, count.ApprovedAt = models.CycleCountApproved, approvedBy, &now
+		if err := tx.Model(&count).Select("status", "approved_by", "approved_at").Updates(&count).Error; err != nil {
+			return TranslateError(err, constants.EntityCycleCount)
+		}
+		if opened, err = evaluateStock(tx, keys); err != nil {
+			return err
+		}
+		return loadLines(tx, &count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishAlerts(ctx, r.Alerts, opened)
+	return &count, nil
+}
+
+// CancelCycleCount closes an open count without touching stock.
+func (r *CycleCountRepository) CancelCycleCount(ctx context.Context, id uint) (*models.CycleCount, error) {
+	var count models.CycleCount
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenCycleCount(tx, id, &count); err != nil {
+			return err
+		}
+		now := time.Now()
+		count.Status, count.CancelledAt = models.CycleCountCancelled, &now
+		if err := tx.Model(&count).Select("status", "cancelled_at").Updates(&count).Error; err != nil {
+			return TranslateError(err, constants.EntityCycleCount)
+		}
+		return loadLines(tx, &count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+func lockOpenCycleCount(tx *gorm.DB, id uint, count *models.CycleCount) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(count, id).Error; err != nil {
+		return TranslateError(err, constants.EntityCycleCount)
+	}
+	if count.Status != models.CycleCountOpen {
+		return NewCycleCountStateError(count.Status)
+	}
+	return nil
+}
+
+func loadLines(db *gorm.DB, count *models.CycleCount) error {
+	count.Lines = []models.CycleCountLine{}
+	return db.Where("cycle_count_id = ?", count.ID).Order("sku_id").Find(&count.Lines).Error
+}
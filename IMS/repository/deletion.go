@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// parent describes how a hub or SKU is referenced from inventories.
+type parent struct {
+	entity string
+	// column is the inventories column pointing at this parent, other the
+	// column and table of the second parent of each inventory row.
+	column, otherColumn, otherTable string
+	// transfers matches the transfers touching this parent, given @id.
+	transfers string
+}
+
+var (
+	hubParent = parent{
+		entity: constants.EntityHub, column: "hub_id", otherColumn: "sku_id", otherTable: "skus",
+		transfers: "source_hub_id = @id OR destination_hub_id = @id",
+	}
+	skuParent = parent{
+		entity: constants.EntitySKU, column: "sku_id", otherColumn: "hub_id", otherTable: "hubs",
+		transfers: "sku_id = @id",
+	}
+)
+
+// softDelete soft-deletes model and its inventory rows in one transaction.
+// It refuses with a _HAS_STOCK conflict while those rows hold stock or
+// transfers touching model are in transit, unless force is set. Reservations
+// deduct stock as they are made, so no open reservation can outlive the
+// stock check. The parent and inventory rows stay locked until commit so
+// stock cannot arrive between the check and the delete.
+func (p parent) softDelete(ctx context.Context, db *gorm.DB, model interface{}, id uint, force bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(model, id).Error; err != nil {
+			return TranslateError(err, p.entity)
+		}
+
+		var quantities []int
+		err := tx.Model(&models.Inventory{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(p.column+" = ?", id).Pluck("quantity", &quantities).Error
+		if err != nil {
+			return TranslateError(err, p.entity)
+		}
+		stock := 0
+		for _, quantity := range quantities {
+			stock += quantity
+		}
+		var inTransit int
+		err = tx.Model(&models.InventoryTransfer{}).Select("COALESCE(SUM(quantity), 0)").
+			Where("status = ?", models.TransferInTransit).Where(p.transfers, sql.Named("id", id)).Scan(&inTransit).Error
+		if err != nil {
+			return TranslateError(err, p.entity)
+		}
+		if stock > 0 || inTransit > 0 {
+			if !force {
+				return NewHasStockError(p.entity, stock, inTransit)
+			}
+			slog.WarnContext(ctx, "force deleting record that holds stock", "entity", p.entity, "id", id, "stock", stock, "in_transit", inTransit)
+		}
+
+		if err := tx.Where(p.column+" = ?", id).Delete(&models.Inventory{}).Error; err != nil {
+			return TranslateError(err, p.entity)
+		}
+		return TranslateError(tx.Delete(model).Error, p.entity)
+	})
+}
+
+// restore brings back a soft-deleted model and the inventory rows whose other
+// parent is still live. A live record holding the same name or code makes it
+// fail with a conflict.
+func (p parent) restore(ctx context.Context, db *gorm.DB, model interface{}, id uint) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(model, id).Error; err != nil {
+			return TranslateError(err, p.entity)
+		}
+		if err := tx.Unscoped().Model(model).Update("deleted_at", nil).Error; err != nil {
+			return TranslateError(err, p.entity)
+		}
+		err := tx.Unscoped().Model(&models.Inventory{}).
+			Where(p.column+" = ? AND deleted_at IS NOT NULL", id).
+			Where(p.otherColumn+" IN (SELECT id FROM "+p.otherTable+" WHERE deleted_at IS NULL)").
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return TranslateError(err, p.entity)
+		}
+		return TranslateError(tx.First(model, id).Error, p.entity)
+	})
+}
+
+// requireLiveParents rejects stock writes naming a missing or deleted hub or
+// SKU; the foreign keys alone would accept soft-deleted ones. The parents
+// are locked FOR SHARE until commit, so softDelete, which locks its record
+// FOR UPDATE, either finishes first and is seen here or waits and then
+// removes the rows written here.
+func requireLiveParents(tx *gorm.DB, hubIDs, skuIDs []uint) error {
+	for _, p := range []struct {
+		model interface{}
+		ids   []uint
+	}{{&models.Hub{}, hubIDs}, {&models.SKU{}, skuIDs}} {
+		ids := distinctIDs(p.ids)
+		var live []uint
+		err := tx.Model(p.model).Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("id IN ?", ids).Order("id").Pluck("id", &live).Error
+		if err != nil {
+			return err
+		}
+		if len(live) != len(ids) {
+			return NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, nil)
+		}
+	}
+	return nil
+}
+
+func distinctIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	distinct := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			distinct = append(distinct, id)
+		}
+	}
+	return distinct
+}
+
+// deleted loads the soft-deleted records into dest, most recent first.
+func deleted(ctx context.Context, db *gorm.DB, dest interface{}) error {
+	return db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(dest).Error
+}
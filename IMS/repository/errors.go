@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrConflict          = errors.New("conflict")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrValidation        = errors.New("validation failed")
+	// ErrPreconditionRequired and ErrPreconditionFailed back optimistic
+	// concurrency: an update arrived without a version, or with a stale one.
+	ErrPreconditionRequired = errors.New("precondition required")
+	ErrPreconditionFailed   = errors.New("precondition failed")
+)
+
+// Error is the domain error returned by the repositories. Kind is one of the
+// sentinels above and decides the HTTP status, Code is the stable identifier
+// clients branch on and Message is the human readable text.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func NewError(kind error, code string, message string, err error) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func (e *Error) WithDetails(details interface{}) *Error {
+	e.Details = details
+	return e
+}
+
+func NewNotFoundError(entity string, err error) *Error {
+	return NewError(ErrNotFound, entityCode(entity, constants.ErrCodeSuffixNotFound), entity+" Not Found", err)
+}
+
+func NewConflictError(entity string, err error) *Error {
+	return NewError(ErrConflict, entityCode(entity, constants.ErrCodeSuffixExists), entity+" Already Exists", err)
+}
+
+func NewValidationError(code string, message string, err error) *Error {
+	return NewError(ErrValidation, code, message, err)
+}
+
+func NewInsufficientStockError(available, requested int) *Error {
+	return NewError(ErrInsufficientStock, constants.ErrCodeInsufficientInventory, constants.ErrInsufficientInventory, nil).
+		WithDetails(map[string]int{"available": available, "requested": requested})
+}
+
+// NewHasStockError refuses to delete a hub or SKU that still holds stock,
+// on hand or in transit.
+func NewHasStockError(entity string, stock, inTransit int) *Error {
+	return NewError(ErrConflict, entityCode(entity, constants.ErrCodeSuffixHasStock), entity+" Still Holds Stock", nil).
+		WithDetails(map[string]int{"stock": stock, "in_transit": inTransit})
+}
+
+func NewTransferStateError(status models.TransferStatus) *Error {
+	return NewError(ErrConflict, constants.ErrCodeTransferNotInTransit, constants.ErrTransferNotInTransit, nil).
+		WithDetails(map[string]models.TransferStatus{"status": status})
+}
+
+func NewCycleCountStateError(status models.CycleCountStatus) *Error {
+	return NewError(ErrConflict, constants.ErrCodeCycleCountNotOpen, constants.ErrCycleCountNotOpen, nil).
+		WithDetails(map[string]models.CycleCountStatus{"status": status})
+}
+
+func NewPreconditionRequiredError() *Error {
+	return NewError(ErrPreconditionRequired, constants.ErrCodePreconditionRequired, constants.ErrIfMatchRequired, nil)
+}
+
+func NewVersionMismatchError(expected, current uint) *Error {
+	return NewError(ErrPreconditionFailed, constants.ErrCodeVersionMismatch, constants.ErrVersionMismatch, nil).
+		WithDetails(map[string]uint{"expected_version": expected, "current_version": current})
+}
+
+// TranslateError maps gorm errors onto domain errors for the given entity and
+// passes everything else through untouched.
+func TranslateError(err error, entity string) error {
+	var domainErr *Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &domainErr):
+		return err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NewNotFoundError(entity, err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return NewConflictError(entity, err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, err)
+	}
+	return err
+}
+
+func entityCode(entity string, suffix string) string {
+	return strings.ToUpper(strings.ReplaceAll(entity, " ", "_")) + suffix
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+)
+
+// SKUFilter narrows ExportSkus; empty fields match everything.
+type SKUFilter struct {
+	TenantID string
+	SellerID string
+}
+
+// ExportHubs passes every hub to fn in ID order, a batch at a time, so the
+// whole table is never held in memory. An error from fn stops the export.
+func (r *HubRepository) ExportHubs(ctx context.Context, fn func([]models.Hub) error) error {
+	var hubs []models.Hub
+	return r.DB.WithContext(ctx).FindInBatches(&hubs, constants.DefaultBatchSize, func(*gorm.DB, int) error {
+		return fn(hubs)
+	}).Error
+}
+
+func (r *SkuRepository) ExportSkus(ctx context.Context, filter SKUFilter, fn func([]models.SKU) error) error {
+	query := r.DB.WithContext(ctx)
+	if filter.TenantID != "" {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.SellerID != "" {
+		query = query.Where("seller_id = ?", filter.SellerID)
+	}
+
+	var skus []models.SKU
+	return query.FindInBatches(&skus, constants.DefaultBatchSize, func(*gorm.DB, int) error {
+		return fn(skus)
+	}).Error
+}
+
+// ExportInventory streams inventory rows with their hub and SKU, optionally
+// for one hub and/or SKU.
+func (r *InventoryRepository) ExportInventory(ctx context.Context, hubID, skuID uint, fn func([]models.Inventory) error) error {
+	query := r.DB.WithContext(ctx).Preload("Hub").Preload("SKU")
+	if hubID != 0 {
+		query = query.Where("hub_id = ?", hubID)
+	}
+	if skuID != 0 {
+		query = query.Where("sku_id = ?", skuID)
+	}
+
+	var inventories []models.Inventory
+	return query.FindInBatches(&inventories, constants.DefaultBatchSize, func(*gorm.DB, int) error {
+		return fn(inventories)
+	}).Error
+}
@@ -5,110 +5,202 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/Trishank-Omniful/Onboarding-Task/cache"
 	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/metrics"
 	"github.com/Trishank-Omniful/Onboarding-Task/models"
-	"github.com/omniful/go_commons/redis"
 	"gorm.io/gorm"
 )
 
 type HubRepository struct {
-	DB    *gorm.DB
-	Redis *redis.Client
+	DB       *gorm.DB
+	Cache    cache.Cache
+	CacheTTL time.Duration
 }
 
-func NewHubRepository(db *gorm.DB, redis *redis.Client) *HubRepository {
-	return &HubRepository{DB: db, Redis: redis}
+func NewHubRepository(db *gorm.DB, cache cache.Cache, cacheTTL time.Duration) *HubRepository {
+	return &HubRepository{DB: db, Cache: cache, CacheTTL: cacheTTL}
 }
 
 func getHubCacheKey(id uint) string {
 	return fmt.Sprintf("%s%d", constants.CacheKeyHubID, id)
 }
 
-func (r *HubRepository) GetAllHubs() ([]models.Hub, error) {
+func (r *HubRepository) GetAllHubs(ctx context.Context) ([]models.Hub, error) {
 	var hubs []models.Hub
-	result := r.DB.Find(&hubs)
+	result := r.DB.WithContext(ctx).Find(&hubs)
 	return hubs, result.Error
 }
 
-func (r *HubRepository) GetHubById(id uint) (*models.Hub, error) {
-	ctx := context.Background()
+func (r *HubRepository) GetHubById(ctx context.Context, id uint) (*models.Hub, error) {
 	cacheKey := getHubCacheKey(id)
 	var hub models.Hub
 
-	val, err := r.Redis.Get(ctx, cacheKey)
+	val, err := r.Cache.Get(ctx, cacheKey)
 	if err == nil {
-		if jsonErr := json.Unmarshal([]byte(val), &hub); jsonErr == nil {
-			log.Printf("HUB retrieved from cache: %d", id)
+		jsonErr := json.Unmarshal([]byte(val), &hub)
+		if jsonErr == nil {
+			metrics.CacheRequests.WithLabelValues(constants.EntityHub, metrics.CacheHit).Inc()
+			slog.DebugContext(ctx, "hub cache hit", "hub_id", id)
 			return &hub, nil
 		}
-		log.Println("Failed to unmarshal HUB from Redis: ", err)
-	} else if err != r.Redis.Nil {
-		log.Println("Redis error while fetching HUB: ", err)
+		metrics.CacheRequests.WithLabelValues(constants.EntityHub, metrics.CacheError).Inc()
+		slog.WarnContext(ctx, "failed to unmarshal cached hub", "hub_id", id, "error", jsonErr)
+	} else if errors.Is(err, cache.ErrCacheMiss) {
+		metrics.CacheRequests.WithLabelValues(constants.EntityHub, metrics.CacheMiss).Inc()
+	} else {
+		metrics.CacheRequests.WithLabelValues(constants.EntityHub, metrics.CacheError).Inc()
+		slog.WarnContext(ctx, "cache error while fetching hub", "hub_id", id, "error", err)
 	}
 	hub = models.Hub{}
-	result := r.DB.First(&hub, id)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return nil, result.Error
+	result := r.DB.WithContext(ctx).First(&hub, id)
+	if result.Error != nil {
+		return nil, TranslateError(result.Error, constants.EntityHub)
 	}
 	HubJSON, jsonErr := json.Marshal(hub)
 	if jsonErr != nil {
-		log.Println("Failed to marshal HUB for Redis", jsonErr)
+		slog.WarnContext(ctx, "failed to marshal hub for cache", "hub_id", id, "error", jsonErr)
 	} else {
-		if success, err := r.Redis.Set(ctx, cacheKey, string(HubJSON), time.Duration(constants.CacheTTLHubs)*time.Minute); err != nil {
-			log.Print("Failed to Set HUB in Redis", err)
-		} else if success {
-			log.Print("HUB set in Redis Cache: ", cacheKey)
+		if err := r.Cache.Set(ctx, cacheKey, string(HubJSON), r.CacheTTL); err != nil {
+			slog.WarnContext(ctx, "failed to set hub in cache", "key", cacheKey, "error", err)
+		} else {
+			slog.DebugContext(ctx, "hub set in cache", "key", cacheKey)
 		}
 	}
-	return &hub, result.Error
+	return &hub, nil
+}
+
+func (r *HubRepository) CreateHub(ctx context.Context, hub *models.Hub) error {
+	result := r.DB.WithContext(ctx).Create(hub)
+	return TranslateError(result.Error, constants.EntityHub)
 }
 
-func (r *HubRepository) CreateHub(hub *models.Hub) error {
-	result := r.DB.Create(hub)
-	return result.Error
+// UpdateHub applies the non-zero fields of hub if hub.Version still matches
+// the stored version, and leaves hub holding the updated row.
+func (r *HubRepository) UpdateHub(ctx context.Context, hub *models.Hub) error {
+	err := updateVersioned(ctx, r.DB, hub, hub.ID, &hub.Version, constants.EntityHub)
+	// The If-Match may have been read from a stale L1 copy, so the entry is
+	// dropped on a mismatch as well.
+	r.Cache.Del(ctx, getHubCacheKey(hub.ID))
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "hub cache invalidated", "hub_id", hub.ID, "reason", "update")
+	return nil
 }
 
-func (r *HubRepository) UpdateHub(hub *models.Hub) error {
-	result := r.DB.Model(hub).Updates(hub)
-	if result.Error == nil {
-		r.Redis.Del(context.Background(), getHubCacheKey(hub.ID))
-		log.Print("Hub Cache Invalidated after Update")
+// DeleteHub soft-deletes the hub with its inventory rows. A hub holding
+// stock is only deleted when force is set.
+func (r *HubRepository) DeleteHub(ctx context.Context, id uint, force bool) error {
+	if err := hubParent.softDelete(ctx, r.DB, &models.Hub{}, id, force); err != nil {
+		return err
 	}
-	return result.Error
+	r.Cache.Del(ctx, getHubCacheKey(id))
+	slog.DebugContext(ctx, "hub cache invalidated", "hub_id", id, "reason", "delete")
+	return nil
 }
 
-func (r *HubRepository) DeleteHub(id uint) error {
+func (r *HubRepository) RestoreHub(ctx context.Context, id uint) (*models.Hub, error) {
 	var hub models.Hub
-	result := r.DB.Delete(&hub, id)
-	if result.Error == nil {
-		r.Redis.Del(context.Background(), getHubCacheKey(id))
-		log.Print("Hub Cache Invalidated after Delete")
+	if err := hubParent.restore(ctx, r.DB, &hub, id); err != nil {
+		return nil, err
 	}
-	return result.Error
+	r.Cache.Del(ctx, getHubCacheKey(id))
+	return &hub, nil
+}
+
+func (r *HubRepository) GetDeletedHubs(ctx context.Context) ([]models.Hub, error) {
+	var hubs []models.Hub
+	return hubs, deleted(ctx, r.DB, &hubs)
 }
 
-func (r *HubRepository) GetHubByName(name string) (*models.Hub, error) {
+func (r *HubRepository) GetHubByName(ctx context.Context, name string) (*models.Hub, error) {
 	var hub models.Hub
-	result := r.DB.Where("name = ?", name).First(&hub)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return nil, errors.New("hub not found by name")
+	result := r.DB.WithContext(ctx).Where("name = ?", name).First(&hub)
+	if result.Error != nil {
+		return nil, TranslateError(result.Error, constants.EntityHub)
 	}
-	return &hub, result.Error
+	return &hub, nil
 }
 
-func (r *HubRepository) CreateHubsBatch(hubs []models.Hub) error {
+func (r *HubRepository) CreateHubsBatch(ctx context.Context, hubs []models.Hub) error {
 	if len(hubs) == 0 {
 		return nil
 	}
-	result := r.DB.CreateInBatches(hubs, 100)
-	return result.Error
+	result := r.DB.WithContext(ctx).CreateInBatches(hubs, 100)
+	return TranslateError(result.Error, constants.EntityHub)
+}
+
+// CreateHubsBatchPartial creates every hub whose name is not already taken
+// and reports the rest as duplicates. Results are aligned with hubs.
+func (r *HubRepository) CreateHubsBatchPartial(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, error) {
+	results, positions, err := r.checkHubs(ctx, hubs)
+	if err != nil || len(positions) == 0 {
+		return results, err
+	}
+
+	toCreate := make([]models.Hub, len(positions))
+	for j, i := range positions {
+		toCreate[j] = hubs[i]
+	}
+	if err := r.DB.WithContext(ctx).CreateInBatches(toCreate, constants.DefaultBatchSize).Error; err != nil {
+		return nil, TranslateError(err, constants.EntityHub)
+	}
+	for j, i := range positions {
+		hubs[i] = toCreate[j]
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemCreated, ID: toCreate[j].ID}
+	}
+	return results, nil
+}
+
+// CheckHubsBatchPartial reports what CreateHubsBatchPartial would do with hubs
+// without writing: taken names are duplicates and the rest valid.
+func (r *HubRepository) CheckHubsBatchPartial(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, error) {
+	results, _, err := r.checkHubs(ctx, hubs)
+	return results, err
+}
+
+// checkHubs marks the hubs whose name is taken, by a stored hub or an
+// earlier row, as duplicates and the rest as valid. It returns the positions
+// of the valid ones.
+func (r *HubRepository) checkHubs(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, []int, error) {
+	results := make([]models.BatchItemResult, len(hubs))
+	if len(hubs) == 0 {
+		return results, nil, nil
+	}
+
+	names := make([]string, len(hubs))
+	for i, hub := range hubs {
+		names[i] = hub.Name
+	}
+
+	var existing []string
+	if err := r.DB.WithContext(ctx).Model(&models.Hub{}).Where("name IN ?", names).Pluck("name", &existing).Error; err != nil {
+		return nil, nil, TranslateError(err, constants.EntityHub)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		taken[name] = true
+	}
+
+	var positions []int
+	for i, hub := range hubs {
+		if taken[hub.Name] {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+			continue
+		}
+		taken[hub.Name] = true
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemValid}
+		positions = append(positions, i)
+	}
+	return results, positions, nil
 }
 
-func (r *HubRepository) GetHubsByIDs(ids []uint) ([]models.Hub, error) {
+func (r *HubRepository) GetHubsByIDs(ctx context.Context, ids []uint) ([]models.Hub, error) {
 	var hubs []models.Hub
-	result := r.DB.Where("id IN (?)", ids).Find(&hubs)
+	result := r.DB.WithContext(ctx).Where("id IN (?)", ids).Find(&hubs)
 	return hubs, result.Error
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+type HubRepositoryInterface interface {
+	GetAllHubs(ctx context.Context) ([]models.Hub, error)
+	GetHubById(ctx context.Context, id uint) (*models.Hub, error)
+	CreateHub(ctx context.Context, hub *models.Hub) error
+	UpdateHub(ctx context.Context, hub *models.Hub) error
+	DeleteHub(ctx context.Context, id uint, force bool) error
+	RestoreHub(ctx context.Context, id uint) (*models.Hub, error)
+	GetDeletedHubs(ctx context.Context) ([]models.Hub, error)
+	GetHubByName(ctx context.Context, name string) (*models.Hub, error)
+	CreateHubsBatch(ctx context.Context, hubs []models.Hub) error
+	CreateHubsBatchPartial(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, error)
+	CheckHubsBatchPartial(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, error)
+	GetHubsByIDs(ctx context.Context, ids []uint) ([]models.Hub, error)
+	ExportHubs(ctx context.Context, fn func([]models.Hub) error) error
+}
+
+type SkuRepositoryInterface interface {
+	GetAllSkus(ctx context.Context) ([]models.SKU, error)
+	GetSkuById(ctx context.Context, id uint) (*models.SKU, error)
+	CreateSku(ctx context.Context, sku *models.SKU) error
+	UpdateSku(ctx context.Context, sku *models.SKU) error
+	DeleteSku(ctx context.Context, id uint, force bool) error
+	RestoreSku(ctx context.Context, id uint) (*models.SKU, error)
+	GetDeletedSkus(ctx context.Context) ([]models.SKU, error)
+	GetSkusByTenantAndSeller(ctx context.Context, tenantID string, sellerID string, skuCodes []string) ([]models.SKU, error)
+	CreateSKUsBatch(ctx context.Context, skus []models.SKU) error
+	CreateSKUsBatchPartial(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, error)
+	CheckSKUsBatchPartial(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, error)
+	GetSKUsByIDs(ctx context.Context, ids []uint) ([]models.SKU, error)
+	GetSKUsByCodes(ctx context.Context, codes []string) ([]models.SKU, error)
+	ExportSkus(ctx context.Context, filter SKUFilter, fn func([]models.SKU) error) error
+}
+
+type InventoryRepositoryInterface interface {
+	UpsertInventory(ctx context.Context, inventory *models.Inventory) error
+	GetInventoryByHubAndSKU(ctx context.Context, hubID, skuID uint) (*models.Inventory, error)
+	GetInventoriesFiltered(ctx context.Context, skuCode *string, hubID *uint) ([]models.Inventory, error)
+	GetInventory(ctx context.Context, hubID, skuID string) ([]models.Inventory, error)
+	GetInventoryWithZeroDefaults(ctx context.Context, hubID uint, skuIDs []uint) ([]models.Inventory, error)
+	ReduceInventory(ctx context.Context, hubID, skuID uint, quantityToReduce int) error
+	UpsertInventoryBatch(ctx context.Context, inventories []models.Inventory) error
+	UpsertInventoryBatchPartial(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, error)
+	CheckInventoryBatchPartial(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, error)
+	GetInventoriesByHubAndSKUs(ctx context.Context, hubID uint, skuIDs []uint) ([]models.Inventory, error)
+	AtomicReduceInventory(ctx context.Context, hubID, skuID uint, quantityToReduce int) (*models.Inventory, error)
+	CheckInventoryAvailability(ctx context.Context, hubID, skuID uint, requiredQuantity int) (bool, error)
+	GetInventoryMovements(ctx context.Context, hubID, skuID uint, limit int) ([]models.InventoryMovement, error)
+	SetStockThresholds(ctx context.Context, inventory *models.Inventory) error
+	GetInventoryAlerts(ctx context.Context, filter AlertFilter) ([]models.InventoryAlert, error)
+	GetAvailabilityBySKUCodes(ctx context.Context, codes []string, filter AvailabilityFilter) ([]models.SKUAvailability, error)
+	CheckAvailabilityBatch(ctx context.Context, lines []models.AvailabilityLine) (*models.AvailabilityCheck, error)
+	GetValuation(ctx context.Context, groupBy []models.ValuationDimension, filter ValuationFilter) ([]models.ValuationRow, error)
+	ExportInventory(ctx context.Context, hubID, skuID uint, fn func([]models.Inventory) error) error
+}
+
+type TransferRepositoryInterface interface {
+	CreateTransfer(ctx context.Context, transfer *models.InventoryTransfer, receive bool) error
+	ReceiveTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error)
+	CancelTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error)
+	GetTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error)
+	ListTransfers(ctx context.Context, filter TransferFilter) ([]models.InventoryTransfer, error)
+}
+
+type CycleCountRepositoryInterface interface {
+	CreateCycleCount(ctx context.Context, count *models.CycleCount) error
+	GetCycleCount(ctx context.Context, id uint) (*models.CycleCount, error)
+	ListCycleCounts(ctx context.Context, filter CycleCountFilter) ([]models.CycleCount, error)
+	SubmitCounts(ctx context.Context, id uint, entries []models.CycleCountEntry) (*models.CycleCount, error)
+	ApproveCycleCount(ctx context.Context, id uint, approvedBy string) (*models.CycleCount, error)
+	CancelCycleCount(ctx context.Context, id uint) (*models.CycleCount, error)
+}
+
+type SnapshotRepositoryInterface interface {
+	TakeSnapshot(ctx context.Context, date time.Time) (int64, error)
+	HasSnapshot(ctx context.Context, date time.Time) (bool, error)
+	GetStockAsOf(ctx context.Context, date time.Time, filter SnapshotFilter) (*models.StockReport, error)
+	GetStockChange(ctx context.Context, from, to time.Time, filter SnapshotFilter) (*models.StockChangeReport, error)
+}
+
+var (
+	_ HubRepositoryInterface        = (*HubRepository)(nil)
+	_ SkuRepositoryInterface        = (*SkuRepository)(nil)
+	_ InventoryRepositoryInterface  = (*InventoryRepository)(nil)
+	_ TransferRepositoryInterface   = (*TransferRepository)(nil)
+	_ SnapshotRepositoryInterface   = (*SnapshotRepository)(nil)
+	_ CycleCountRepositoryInterface = (*CycleCountRepository)(nil)
+)
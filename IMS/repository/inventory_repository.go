@@ -1,54 +1,77 @@
 package repository
 
 import (
+	"context"
 	"errors"
 
+	"github.com/Trishank-Omniful/Onboarding-Task/cache"
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/events"
+	"github.com/Trishank-Omniful/Onboarding-Task/metrics"
 	"github.com/Trishank-Omniful/Onboarding-Task/models"
-	"github.com/omniful/go_commons/redis"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type InventoryRepository struct {
 	DB      *gorm.DB
-	Redis   *redis.Client
-	SKURepo *SkuRepository
-	HubRepo *HubRepository
+	Cache   cache.Cache
+	SKURepo SkuRepositoryInterface
+	HubRepo HubRepositoryInterface
+	Alerts  events.AlertPublisher
 }
 
 func NewInventoryRepository(
 	db *gorm.DB,
-	redis *redis.Client,
-	skuRepo *SkuRepository,
-	hubRepo *HubRepository,
+	cache cache.Cache,
+	skuRepo SkuRepositoryInterface,
+	hubRepo HubRepositoryInterface,
+	alerts events.AlertPublisher,
 ) *InventoryRepository {
 	return &InventoryRepository{
 		DB:      db,
-		Redis:   redis,
+		Cache:   cache,
 		SKURepo: skuRepo,
 		HubRepo: hubRepo,
+		Alerts:  alerts,
 	}
 }
 
-func (r *InventoryRepository) UpsertInventory(inventory *models.Inventory) error {
-	upsert := r.DB.Clauses(
-		clause.OnConflict{
-			Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
-			DoUpdates: clause.Assignments(map[string]interface{}{"quantity": inventory.Quantity}),
-		},
-	).Create(inventory)
-	return upsert.Error
+func (r *InventoryRepository) UpsertInventory(ctx context.Context, inventory *models.Inventory) error {
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireLiveParents(tx, []uint{inventory.HubID}, []uint{inventory.SKUID}); err != nil {
+			return err
+		}
+		upsert := tx.Omit("reorder_point", "safety_stock").Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"quantity": inventory.Quantity}),
+			},
+		).Create(inventory)
+		if upsert.Error != nil {
+			return TranslateError(upsert.Error, constants.EntityInventory)
+		}
+		var err error
+		opened, err = evaluateStock(tx, [][2]uint{{inventory.HubID, inventory.SKUID}})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	publishAlerts(ctx, r.Alerts, opened)
+	return nil
 }
 
-func (r *InventoryRepository) GetInventoryByHubAndSKU(hubID, skuID uint) (*models.Inventory, error) {
+func (r *InventoryRepository) GetInventoryByHubAndSKU(ctx context.Context, hubID, skuID uint) (*models.Inventory, error) {
 	var inventory models.Inventory
-	result := r.DB.Preload("Hub").Preload("SKU").Where("hub_id = ? AND sku_id = ?", hubID, skuID).First(&inventory)
+	result := r.DB.WithContext(ctx).Preload("Hub").Preload("SKU").Where("hub_id = ? AND sku_id = ?", hubID, skuID).First(&inventory)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		hub, err := r.HubRepo.GetHubById(hubID)
+		hub, err := r.HubRepo.GetHubById(ctx, hubID)
 		if err != nil {
 			return nil, err
 		}
-		sku, err := r.SKURepo.GetSkuById(skuID)
+		sku, err := r.SKURepo.GetSkuById(ctx, skuID)
 		if err != nil {
 			return nil, err
 		}
@@ -61,12 +84,15 @@ func (r *InventoryRepository) GetInventoryByHubAndSKU(hubID, skuID uint) (*model
 			Quantity: 0,
 		}, nil
 	}
-	return &inventory, result.Error
+	if result.Error != nil {
+		return nil, TranslateError(result.Error, constants.EntityInventory)
+	}
+	return &inventory, nil
 }
 
-func (r *InventoryRepository) GetInventoriesFiltered(skuCode *string, hubID *uint) ([]models.Inventory, error) {
+func (r *InventoryRepository) GetInventoriesFiltered(ctx context.Context, skuCode *string, hubID *uint) ([]models.Inventory, error) {
 	var inventories []models.Inventory
-	query := r.DB.Model(&models.Inventory{})
+	query := r.DB.WithContext(ctx).Model(&models.Inventory{})
 
 	if skuCode != nil && *skuCode != "" {
 		query = query.Joins("JOIN skus on skus.id = inventories.sku_id").Where("skus.code = ?", *skuCode)
@@ -83,9 +109,9 @@ func (r *InventoryRepository) GetInventoriesFiltered(skuCode *string, hubID *uin
 	return inventories, nil
 }
 
-func (r *InventoryRepository) GetInventory(hubID, skuID string) ([]models.Inventory, error) {
+func (r *InventoryRepository) GetInventory(ctx context.Context, hubID, skuID string) ([]models.Inventory, error) {
 	var inventories []models.Inventory
-	query := r.DB.Model(&models.Inventory{}).Preload("Hub").Preload("SKU")
+	query := r.DB.WithContext(ctx).Model(&models.Inventory{}).Preload("Hub").Preload("SKU")
 
 	if hubID != "" {
 		query = query.Where("hub_id = ?", hubID)
@@ -99,65 +125,179 @@ func (r *InventoryRepository) GetInventory(hubID, skuID string) ([]models.Invent
 	return inventories, result.Error
 }
 
-func (r *InventoryRepository) GetInventoryWithZeroDefaults(hubID uint, skuIDs []uint) ([]models.Inventory, error) {
+func (r *InventoryRepository) GetInventoryWithZeroDefaults(ctx context.Context, hubID uint, skuIDs []uint) ([]models.Inventory, error) {
 	if len(skuIDs) == 0 {
 		var inventories []models.Inventory
-		query := r.DB.Model(&models.Inventory{}).Preload("Hub").Preload("SKU").Where("hub_id = ?", hubID)
+		query := r.DB.WithContext(ctx).Model(&models.Inventory{}).Preload("Hub").Preload("SKU").Where("hub_id = ?", hubID)
 		result := query.Find(&inventories)
 		return inventories, result.Error
 	}
 
-	return r.GetInventoriesByHubAndSKUs(hubID, skuIDs)
+	return r.GetInventoriesByHubAndSKUs(ctx, hubID, skuIDs)
 }
 
-func (r *InventoryRepository) ReduceInventory(hubID, skuID uint, quantityToReduce int) error {
-	if quantityToReduce <= 0 {
-		return errors.New("quantity to reduce must be positive")
+func (r *InventoryRepository) ReduceInventory(ctx context.Context, hubID, skuID uint, quantityToReduce int) error {
+	_, err := r.AtomicReduceInventory(ctx, hubID, skuID, quantityToReduce)
+	return err
+}
+
+// UpsertInventoryBatch writes the whole batch with multi-row
+// INSERT ... ON CONFLICT statements of up to constants.UpsertChunkSize rows,
+// so a full batch costs a single round-trip. When a hub/SKU pair repeats, the
+// last row wins, as it did when rows were upserted one at a time. A row
+// naming a missing or deleted hub or SKU fails the whole batch.
+func (r *InventoryRepository) UpsertInventoryBatch(ctx context.Context, inventories []models.Inventory) error {
+	rows := dedupeInventories(inventories)
+	if len(rows) == 0 {
+		return nil
 	}
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		var inventory models.Inventory
-		result := tx.Set("gorm:query_option", "FOR UPDATE").Where("hub_id = ? AND sku_id = ?", hubID, skuID).First(&inventory)
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("inventory record not found for reduction")
-		}
-		if result.Error != nil {
-			return result.Error
-		}
-		if inventory.Quantity < quantityToReduce {
-			return errors.New("insufficient inventory")
+
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hubIDs, skuIDs []uint
+		for _, row := range rows {
+			hubIDs = append(hubIDs, row.HubID)
+			skuIDs = append(skuIDs, row.SKUID)
 		}
-		inventory.Quantity -= quantityToReduce
-		if err := tx.Save(&inventory).Error; err != nil {
+		if err := requireLiveParents(tx, hubIDs, skuIDs); err != nil {
 			return err
 		}
-		return nil
+		upsert := tx.Omit("reorder_point", "safety_stock").Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+			},
+		).CreateInBatches(&rows, constants.UpsertChunkSize)
+		if upsert.Error != nil {
+			return TranslateError(upsert.Error, constants.EntityInventory)
+		}
+		var err error
+		opened, err = evaluateStock(tx, inventoryKeys(rows))
+		return err
 	})
+	if err != nil {
+		return err
+	}
+	publishAlerts(ctx, r.Alerts, opened)
+	return nil
+}
+
+// dedupeInventories keeps the last row for each hub/SKU pair. PostgreSQL
+// rejects an ON CONFLICT DO UPDATE statement that touches the same row twice.
+func dedupeInventories(inventories []models.Inventory) []models.Inventory {
+	positions := make(map[[2]uint]int, len(inventories))
+	rows := make([]models.Inventory, 0, len(inventories))
+	for _, inventory := range inventories {
+		key := [2]uint{inventory.HubID, inventory.SKUID}
+		if i, ok := positions[key]; ok {
+			rows[i] = inventory
+			continue
+		}
+		positions[key] = len(rows)
+		rows = append(rows, inventory)
+	}
+	return rows
+}
+
+// UpsertInventoryBatchPartial upserts every row whose hub and SKU exist and
+// reports the rest as invalid. As in UpsertInventoryBatch the last row for a
+// hub/SKU pair wins; the earlier ones are reported as duplicates. Results are
+// aligned with inventories.
+func (r *InventoryRepository) UpsertInventoryBatchPartial(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, error) {
+	results, positions, stocked, err := r.checkInventory(ctx, inventories)
+	if err != nil || len(positions) == 0 {
+		return results, err
+	}
+
+	toUpsert := make([]models.Inventory, len(positions))
+	for j, i := range positions {
+		toUpsert[j] = inventories[i]
+		results[i].Status = models.BatchItemCreated
+		if stocked[[2]uint{inventories[i].HubID, inventories[i].SKUID}] {
+			results[i].Status = models.BatchItemUpdated
+		}
+	}
+	if err := r.UpsertInventoryBatch(ctx, toUpsert); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CheckInventoryBatchPartial reports what UpsertInventoryBatchPartial would do
+// with inventories without writing: rows naming a missing hub or SKU are
+// invalid, rows superseded by a later one duplicates and the rest valid.
+func (r *InventoryRepository) CheckInventoryBatchPartial(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, error) {
+	results, _, _, err := r.checkInventory(ctx, inventories)
+	return results, err
 }
 
-func (r *InventoryRepository) UpsertInventoryBatch(inventories []models.Inventory) error {
+// checkInventory classifies inventories for UpsertInventoryBatchPartial,
+// marking the rows to upsert as valid. It returns their positions and which
+// hub/SKU pairs are already stocked.
+func (r *InventoryRepository) checkInventory(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, []int, map[[2]uint]bool, error) {
+	results := make([]models.BatchItemResult, len(inventories))
 	if len(inventories) == 0 {
-		return nil
+		return results, nil, nil, nil
 	}
 
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		for _, inventory := range inventories {
-			upsert := tx.Clauses(
-				clause.OnConflict{
-					Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
-					DoUpdates: clause.Assignments(map[string]interface{}{"quantity": inventory.Quantity}),
-				},
-			).Create(&inventory)
-			if upsert.Error != nil {
-				return upsert.Error
-			}
+	var hubIDs, skuIDs []uint
+	var pairs [][]interface{}
+	for _, inventory := range inventories {
+		hubIDs = append(hubIDs, inventory.HubID)
+		skuIDs = append(skuIDs, inventory.SKUID)
+		pairs = append(pairs, []interface{}{inventory.HubID, inventory.SKUID})
+	}
+
+	hubs, err := r.HubRepo.GetHubsByIDs(ctx, hubIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	skus, err := r.SKURepo.GetSKUsByIDs(ctx, skuIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var existing []models.Inventory
+	if err := r.DB.WithContext(ctx).Select("hub_id", "sku_id").Where("(hub_id, sku_id) IN ?", pairs).Find(&existing).Error; err != nil {
+		return nil, nil, nil, TranslateError(err, constants.EntityInventory)
+	}
+
+	knownHubs := make(map[uint]bool, len(hubs))
+	for _, hub := range hubs {
+		knownHubs[hub.ID] = true
+	}
+	knownSKUs := make(map[uint]bool, len(skus))
+	for _, sku := range skus {
+		knownSKUs[sku.ID] = true
+	}
+	stocked := make(map[[2]uint]bool, len(existing))
+	for _, inventory := range existing {
+		stocked[[2]uint{inventory.HubID, inventory.SKUID}] = true
+	}
+
+	last := make(map[[2]uint]int, len(inventories))
+	for i, inventory := range inventories {
+		last[[2]uint{inventory.HubID, inventory.SKUID}] = i
+	}
+
+	var positions []int
+	for i, inventory := range inventories {
+		key := [2]uint{inventory.HubID, inventory.SKUID}
+		switch {
+		case !knownHubs[inventory.HubID] || !knownSKUs[inventory.SKUID]:
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemInvalid, Reason: constants.ErrInvalidReference}
+		case last[key] != i:
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrSupersededRow}
+		default:
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemValid}
+			positions = append(positions, i)
 		}
-		return nil
-	})
+	}
+	return results, positions, stocked, nil
 }
 
-func (r *InventoryRepository) GetInventoriesByHubAndSKUs(hubID uint, skuIDs []uint) ([]models.Inventory, error) {
+func (r *InventoryRepository) GetInventoriesByHubAndSKUs(ctx context.Context, hubID uint, skuIDs []uint) ([]models.Inventory, error) {
 	var inventories []models.Inventory
-	query := r.DB.Model(&models.Inventory{}).Preload("Hub").Preload("SKU").Where("hub_id = ?", hubID)
+	query := r.DB.WithContext(ctx).Model(&models.Inventory{}).Preload("Hub").Preload("SKU").Where("hub_id = ?", hubID)
 
 	if len(skuIDs) > 0 {
 		query = query.Where("sku_id IN (?)", skuIDs)
@@ -174,12 +314,12 @@ func (r *InventoryRepository) GetInventoriesByHubAndSKUs(hubID uint, skuIDs []ui
 			existingMap[inv.SKUID] = inv
 		}
 
-		hub, err := r.HubRepo.GetHubById(hubID)
+		hub, err := r.HubRepo.GetHubById(ctx, hubID)
 		if err != nil {
 			return nil, err
 		}
 
-		skus, err := r.SKURepo.GetSKUsByIDs(skuIDs)
+		skus, err := r.SKURepo.GetSKUsByIDs(ctx, skuIDs)
 		if err != nil {
 			return nil, err
 		}
@@ -205,40 +345,80 @@ func (r *InventoryRepository) GetInventoriesByHubAndSKUs(hubID uint, skuIDs []ui
 	return inventories, nil
 }
 
-func (r *InventoryRepository) AtomicReduceInventory(hubID, skuID uint, quantityToReduce int) (*models.Inventory, error) {
+func (r *InventoryRepository) AtomicReduceInventory(ctx context.Context, hubID, skuID uint, quantityToReduce int) (*models.Inventory, error) {
 	if quantityToReduce <= 0 {
-		return nil, errors.New("quantity to reduce must be positive")
+		return nil, NewValidationError(constants.ErrCodeInvalidQuantity, "quantity to reduce must be positive", nil)
 	}
 
 	var updatedInventory *models.Inventory
-	err := r.DB.Transaction(func(tx *gorm.DB) error {
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var inventory models.Inventory
-		result := tx.Set("gorm:query_option", "FOR UPDATE").Where("hub_id = ? AND sku_id = ?", hubID, skuID).First(&inventory)
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("inventory record not found for reduction")
-		}
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("hub_id = ? AND sku_id = ?", hubID, skuID).First(&inventory)
 		if result.Error != nil {
-			return result.Error
+			return TranslateError(result.Error, constants.EntityInventory)
 		}
 		if inventory.Quantity < quantityToReduce {
-			return errors.New("insufficient inventory")
+			return NewInsufficientStockError(inventory.Quantity, quantityToReduce)
 		}
 		inventory.Quantity -= quantityToReduce
-		if err := tx.Save(&inventory).Error; err != nil {
-			return err
+		if err := tx.Model(&inventory).Update("quantity", inventory.Quantity).Error; err != nil {
+			return TranslateError(err, constants.EntityInventory)
 		}
 		updatedInventory = &inventory
-		return nil
+		var err error
+		opened, err = evaluateStock(tx, [][2]uint{{hubID, skuID}})
+		return err
 	})
 
+	metrics.InventoryReservations.WithLabelValues(reservationResult(err)).Inc()
 	if err != nil {
 		return nil, err
 	}
+	publishAlerts(ctx, r.Alerts, opened)
 	return updatedInventory, nil
 }
 
-func (r *InventoryRepository) CheckInventoryAvailability(hubID, skuID uint, requiredQuantity int) (bool, error) {
-	inventory, err := r.GetInventoryByHubAndSKU(hubID, skuID)
+func reservationResult(err error) string {
+	switch {
+	case err == nil:
+		return metrics.ReservationSuccess
+	case errors.Is(err, ErrInsufficientStock):
+		return metrics.ReservationInsufficient
+	case errors.Is(err, ErrNotFound):
+		return metrics.ReservationNotFound
+	}
+	return metrics.ReservationError
+}
+
+// GetInventoryMovements returns the history of a hub/SKU pair, newest
+// first. Zero IDs match every hub or SKU, and a limit of zero returns
+// constants.DefaultHistoryLimit movements.
+func (r *InventoryRepository) GetInventoryMovements(ctx context.Context, hubID, skuID uint, limit int) ([]models.InventoryMovement, error) {
+	query := r.DB.WithContext(ctx).Model(&models.InventoryMovement{})
+	if hubID != 0 {
+		query = query.Where("hub_id = ?", hubID)
+	}
+	if skuID != 0 {
+		query = query.Where("sku_id = ?", skuID)
+	}
+
+	var movements []models.InventoryMovement
+	err := query.Order("id DESC").Limit(HistoryLimit(limit)).Find(&movements).Error
+	return movements, err
+}
+
+// HistoryLimit bounds a history query, using constants.DefaultHistoryLimit
+// when the caller asked for no particular limit.
+func HistoryLimit(limit int) int {
+	if limit <= 0 {
+		return constants.DefaultHistoryLimit
+	}
+	return limit
+}
+
+func (r *InventoryRepository) CheckInventoryAvailability(ctx context.Context, hubID, skuID uint, requiredQuantity int) (bool, error) {
+	inventory, err := r.GetInventoryByHubAndSKU(ctx, hubID, skuID)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/cache"
+	"github.com/Trishank-Omniful/Onboarding-Task/config"
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+func TestDedupeInventories(t *testing.T) {
+	rows := dedupeInventories([]models.Inventory{
+		{HubID: 1, SKUID: 1, Quantity: 1},
+		{HubID: 1, SKUID: 2, Quantity: 2},
+		{HubID: 1, SKUID: 1, Quantity: 3},
+	})
+
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].SKUID != 1 || rows[0].Quantity != 3 {
+		t.Fatalf("rows[0] = %+v, want sku 1 with the last quantity 3", rows[0])
+	}
+	if rows[1].SKUID != 2 || rows[1].Quantity != 2 {
+		t.Fatalf("rows[1] = %+v, want sku 2 with quantity 2", rows[1])
+	}
+}
+
+// BenchmarkUpsertInventoryBatch compares the multi-row upsert with the
+// previous one-statement-per-row loop. It needs a disposable PostgreSQL
+// database, e.g.
+//
+//	IMS_BENCH_POSTGRES_DSN="host=localhost user=postgres password=postgres dbname=ims_bench port=5432 sslmode=disable" \
+//	  go test ./repository -run '^$' -bench UpsertInventoryBatch
+func BenchmarkUpsertInventoryBatch(b *testing.B) {
+	dsn := os.Getenv("IMS_BENCH_POSTGRES_DSN")
+	if dsn == "" {
+		b.Skip("IMS_BENCH_POSTGRES_DSN not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true, Logger: logger.Discard})
+	if err != nil {
+		b.Fatalf("connect: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Hub{}, &models.SKU{}, &models.Inventory{}, &models.InventoryAlert{}); err != nil {
+		b.Fatalf("migrate: %v", err)
+	}
+	b.Cleanup(func() {
+		db.Exec("TRUNCATE inventory_alerts, inventories, skus, hubs RESTART IDENTITY CASCADE")
+	})
+
+	batch := seedBenchmarkBatch(b, db, config.Default().Batch.MaxSize)
+	hubRepo := NewHubRepository(db, cache.NewLRUCache(0), time.Minute)
+	skuRepo := NewSkuRepository(db, cache.NewLRUCache(0), time.Minute)
+	repo := NewInventoryRepository(db, cache.NewLRUCache(0), skuRepo, hubRepo, nil)
+
+	b.Run("multi_row", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := repo.UpsertInventoryBatch(context.Background(), withQuantity(batch, i)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("row_by_row", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := upsertRowByRow(db, withQuantity(batch, i)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func seedBenchmarkBatch(b *testing.B, db *gorm.DB, size int) []models.Inventory {
+	b.Helper()
+
+	hubs := make([]models.Hub, 10)
+	for i := range hubs {
+		hubs[i] = models.Hub{Name: fmt.Sprintf("bench_hub_%d", i), Address: "bench"}
+	}
+	skus := make([]models.SKU, size/len(hubs))
+	for i := range skus {
+		skus[i] = models.SKU{Code: fmt.Sprintf("bench_sku_%d", i), Name: "bench", TenantId: "bench", SellerId: "bench"}
+	}
+	if err := db.CreateInBatches(hubs, constants.DefaultBatchSize).Error; err != nil {
+		b.Fatalf("seed hubs: %v", err)
+	}
+	if err := db.CreateInBatches(skus, constants.DefaultBatchSize).Error; err != nil {
+		b.Fatalf("seed skus: %v", err)
+	}
+
+	batch := make([]models.Inventory, 0, size)
+	for _, hub := range hubs {
+		for _, sku := range skus {
+			batch = append(batch, models.Inventory{HubID: hub.ID, SKUID: sku.ID})
+		}
+	}
+	return batch
+}
+
+func withQuantity(batch []models.Inventory, quantity int) []models.Inventory {
+	rows := make([]models.Inventory, len(batch))
+	for i, inventory := range batch {
+		rows[i] = models.Inventory{HubID: inventory.HubID, SKUID: inventory.SKUID, Quantity: quantity}
+	}
+	return rows
+}
+
+func upsertRowByRow(db *gorm.DB, inventories []models.Inventory) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, inventory := range inventories {
+			upsert := tx.Clauses(
+				clause.OnConflict{
+					Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+					DoUpdates: clause.Assignments(map[string]interface{}{"quantity": inventory.Quantity}),
+				},
+			).Create(&inventory)
+			if upsert.Error != nil {
+				return upsert.Error
+			}
+		}
+		return nil
+	})
+}
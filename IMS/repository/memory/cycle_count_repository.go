@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+)
+
+// CycleCountRepository keeps counts under the lock of Inventory, so approval
+// adjusts stock and records history atomically.
+type CycleCountRepository struct {
+	Inventory *InventoryRepository
+	counts    map[uint]models.CycleCount
+	nextID    uint
+}
+
+func NewCycleCountRepository(inventory *InventoryRepository) *CycleCountRepository {
+	return &CycleCountRepository{Inventory: inventory, counts: make(map[uint]models.CycleCount), nextID: 1}
+}
+
+func (r *CycleCountRepository) CreateCycleCount(ctx context.Context, count *models.CycleCount) error {
+	if _, err := r.Inventory.HubRepo.GetHubById(ctx, count.HubID); errors.Is(err, repository.ErrNotFound) {
+		return repository.NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, nil)
+	}
+
+	inv := r.Inventory
+	inv.mu.Lock()
+	inventories := inv.filter(func(i models.Inventory) bool { return i.HubID == count.HubID })
+	inv.mu.Unlock()
+
+	count.Lines = []models.CycleCountLine{}
+	for _, inventory := range inventories {
+		if !r.inCategory(ctx, count, inventory.SKUID) {
+			continue
+		}
+		count.Lines = append(count.Lines, models.CycleCountLine{SKUID: inventory.SKUID, SystemQuantity: inventory.Quantity})
+	}
+	sortLines(count.Lines)
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	now := time.Now()
+	count.ID = r.nextID
+	r.nextID++
+	count.CreatedAt, count.UpdatedAt = now, now
+	count.Status = models.CycleCountOpen
+	count.ApprovedBy, count.ApprovedAt, count.CancelledAt = "", nil, nil
+	for i := range count.Lines {
+		count.Lines[i].CycleCountID = count.ID
+	}
+	r.counts[count.ID] = *count
+	return nil
+}
+
+func (r *CycleCountRepository) GetCycleCount(ctx context.Context, id uint) (*models.CycleCount, error) {
+	r.Inventory.mu.Lock()
+	defer r.Inventory.mu.Unlock()
+
+	count, ok := r.counts[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntityCycleCount, nil)
+	}
+	count.Lines = append([]models.CycleCountLine{}, count.Lines...)
+	return &count, nil
+}
+
+func (r *CycleCountRepository) ListCycleCounts(ctx context.Context, filter repository.CycleCountFilter) ([]models.CycleCount, error) {
+	r.Inventory.mu.Lock()
+	defer r.Inventory.mu.Unlock()
+
+	var counts []models.CycleCount
+	for _, count := range r.counts {
+		if (filter.HubID == 0 || count.HubID == filter.HubID) && (filter.Status == "" || count.Status == filter.Status) {
+			count.Lines = nil
+			counts = append(counts, count)
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].ID > counts[j].ID })
+	return counts, nil
+}
+
+func (r *CycleCountRepository) SubmitCounts(ctx context.Context, id uint, entries []models.CycleCountEntry) (*models.CycleCount, error) {
+	count, err := r.GetCycleCount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if _, err := r.Inventory.SKURepo.GetSkuById(ctx, entry.SKUID); err != nil || !r.inCategory(ctx, count, entry.SKUID) {
+			return nil, repository.NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, nil)
+		}
+	}
+
+	inv := r.Inventory
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	stored := r.counts[id]
+	if stored.Status != models.CycleCountOpen {
+		return nil, repository.NewCycleCountStateError(stored.Status)
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		system := inv.inventories[inventoryKey{stored.HubID, entry.SKUID}].Quantity
+		i := sort.Search(len(stored.Lines), func(i int) bool { return stored.Lines[i].SKUID >= entry.SKUID })
+		if i == len(stored.Lines) || stored.Lines[i].SKUID != entry.SKUID {
+			stored.Lines = append(stored.Lines, models.CycleCountLine{CycleCountID: id, SKUID: entry.SKUID})
+			sortLines(stored.Lines)
+			i = sort.Search(len(stored.Lines), func(i int) bool { return stored.Lines[i].SKUID >= entry.SKUID })
+		}
+		stored.Lines[i].Count(entry.CountedQuantity, system, now)
+	}
+	stored.UpdatedAt = now
+	r.counts[id] = stored
+	stored.Lines = append([]models.CycleCountLine{}, stored.Lines...)
+	return &stored, nil
+}
+
+func (r *CycleCountRepository) ApproveCycleCount(ctx context.Context, id uint, approvedBy string) (*models.CycleCount, error) {
+	inv := r.Inventory
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	count, err := r.open(id)
+	if err != nil {
+		return nil, err
+	}
+	var keys []inventoryKey
+	for _, line := range count.Lines {
+		if line.Variance == nil || *line.Variance == 0 {
+			continue
+		}
+		current := inv.inventories[inventoryKey{count.HubID, line.SKUID}].Quantity
+		if current+*line.Variance < 0 {
+			return nil, repository.NewInsufficientStockError(current, -*line.Variance)
+		}
+		keys = append(keys, inventoryKey{count.HubID, line.SKUID})
+	}
+	for _, line := range count.Lines {
+		if line.Variance == nil || *line.Variance == 0 {
+			continue
+		}
+		countID := count.ID
+		inv.movements = append(inv.movements, models.InventoryMovement{
+			ID:            uint(len(inv.movements) + 1),
+			CreatedAt:     time.Now(),
+			HubID:         count.HubID,
+			SKUID:         line.SKUID,
+			Quantity:      *line.Variance,
+			QuantityAfter: inv.addStock(count.HubID, line.SKUID, *line.Variance),
+			Reason:        models.MovementCycleCount,
+			CycleCountID:  &countID,
+		})
+	}
+
+	now := time.Now()
+	count.Status, count.ApprovedBy, count.ApprovedAt, count.UpdatedAt = models.CycleCountApproved, approvedBy, &now, now
+	r.counts[id] = count
+	inv.publish(ctx, inv.evaluate(keys...))
+	return &count, nil
+}
+
+func (r *CycleCountRepository) CancelCycleCount(ctx context.Context, id uint) (*models.CycleCount, error) {
+	r.Inventory.mu.Lock()
+	defer r.Inventory.mu.Unlock()
+
+	count, err := r.open(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	count.Status, count.CancelledAt, count.UpdatedAt = models.CycleCountCancelled, &now, now
+	r.counts[id] = count
+	return &count, nil
+}
+
+// open returns an open count. The caller holds Inventory.mu.
+func (r *CycleCountRepository) open(id uint) (models.CycleCount, error) {
+	count, ok := r.counts[id]
+	if !ok {
+		return count, repository.NewNotFoundError(constants.EntityCycleCount, nil)
+	}
+	if count.Status != models.CycleCountOpen {
+		return count, repository.NewCycleCountStateError(count.Status)
+	}
+	return count, nil
+}
+
+func (r *CycleCountRepository) inCategory(ctx context.Context, count *models.CycleCount, skuID uint) bool {
+	if count.Category == "" {
+		return true
+	}
+	sku, err := r.Inventory.SKURepo.GetSkuById(ctx, skuID)
+	return err == nil && sku.Category == count.Category
+}
+
+func sortLines(lines []models.CycleCountLine) {
+	sort.Slice(lines, func(i, j int) bool { return lines[i].SKUID < lines[j].SKUID })
+}
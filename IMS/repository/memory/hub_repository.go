@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"gorm.io/gorm"
+)
+
+type HubRepository struct {
+	mu      sync.RWMutex
+	hubs    map[uint]models.Hub
+	deleted map[uint]models.Hub
+	nextID  uint
+	// stock reports the units held against a hub; NewInventoryRepository
+	// wires it so deletes can refuse stocked hubs.
+	stock func(id uint) (onHand, inTransit int)
+}
+
+func NewHubRepository() *HubRepository {
+	return &HubRepository{hubs: make(map[uint]models.Hub), deleted: make(map[uint]models.Hub), nextID: 1}
+}
+
+func (r *HubRepository) GetAllHubs(ctx context.Context) ([]models.Hub, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hubs := make([]models.Hub, 0, len(r.hubs))
+	for _, hub := range r.hubs {
+		hubs = append(hubs, hub)
+	}
+	sort.Slice(hubs, func(i, j int) bool { return hubs[i].ID < hubs[j].ID })
+	return hubs, nil
+}
+
+func (r *HubRepository) GetHubById(ctx context.Context, id uint) (*models.Hub, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hub, ok := r.hubs[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntityHub, gorm.ErrRecordNotFound)
+	}
+	return &hub, nil
+}
+
+func (r *HubRepository) CreateHub(ctx context.Context, hub *models.Hub) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.create(hub)
+}
+
+func (r *HubRepository) UpdateHub(ctx context.Context, hub *models.Hub) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if hub.Version == 0 {
+		return repository.NewPreconditionRequiredError()
+	}
+	existing, ok := r.hubs[hub.ID]
+	if !ok {
+		return repository.NewNotFoundError(constants.EntityHub, nil)
+	}
+	if existing.Version != hub.Version {
+		return repository.NewVersionMismatchError(hub.Version, existing.Version)
+	}
+	mergeHub(&existing, hub)
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+	r.hubs[hub.ID] = existing
+	*hub = existing
+	return nil
+}
+
+func (r *HubRepository) DeleteHub(ctx context.Context, id uint, force bool) error {
+	if _, err := r.GetHubById(ctx, id); err != nil {
+		return err
+	}
+	if r.stock != nil {
+		if stock, inTransit := r.stock(id); (stock > 0 || inTransit > 0) && !force {
+			return repository.NewHasStockError(constants.EntityHub, stock, inTransit)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hub, ok := r.hubs[id]
+	if !ok {
+		return repository.NewNotFoundError(constants.EntityHub, nil)
+	}
+	hub.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	delete(r.hubs, id)
+	r.deleted[id] = hub
+	return nil
+}
+
+func (r *HubRepository) RestoreHub(ctx context.Context, id uint) (*models.Hub, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hub, ok := r.deleted[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntityHub, nil)
+	}
+	for _, live := range r.hubs {
+		if live.Name == hub.Name {
+			return nil, repository.TranslateError(gorm.ErrDuplicatedKey, constants.EntityHub)
+		}
+	}
+	hub.DeletedAt = gorm.DeletedAt{}
+	hub.UpdatedAt = time.Now()
+	delete(r.deleted, id)
+	r.hubs[id] = hub
+	return &hub, nil
+}
+
+func (r *HubRepository) GetDeletedHubs(ctx context.Context) ([]models.Hub, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hubs := make([]models.Hub, 0, len(r.deleted))
+	for _, hub := range r.deleted {
+		hubs = append(hubs, hub)
+	}
+	sort.Slice(hubs, func(i, j int) bool { return hubs[i].DeletedAt.Time.After(hubs[j].DeletedAt.Time) })
+	return hubs, nil
+}
+
+func (r *HubRepository) GetHubByName(ctx context.Context, name string) (*models.Hub, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, hub := range r.hubs {
+		if hub.Name == name {
+			return &hub, nil
+		}
+	}
+	return nil, repository.NewNotFoundError(constants.EntityHub, nil)
+}
+
+func (r *HubRepository) CreateHubsBatch(ctx context.Context, hubs []models.Hub) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make(map[uint]models.Hub, len(r.hubs))
+	for id, hub := range r.hubs {
+		snapshot[id] = hub
+	}
+	nextID := r.nextID
+
+	for i := range hubs {
+		if err := r.create(&hubs[i]); err != nil {
+			r.hubs, r.nextID = snapshot, nextID
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *HubRepository) CreateHubsBatchPartial(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]models.BatchItemResult, len(hubs))
+	for i := range hubs {
+		if err := r.create(&hubs[i]); err != nil {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+			continue
+		}
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemCreated, ID: hubs[i].ID}
+	}
+	return results, nil
+}
+
+func (r *HubRepository) CheckHubsBatchPartial(ctx context.Context, hubs []models.Hub) ([]models.BatchItemResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	taken := make(map[string]bool, len(r.hubs)+len(hubs))
+	for _, hub := range r.hubs {
+		taken[hub.Name] = true
+	}
+	results := make([]models.BatchItemResult, len(hubs))
+	for i, hub := range hubs {
+		if taken[hub.Name] {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+			continue
+		}
+		taken[hub.Name] = true
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemValid}
+	}
+	return results, nil
+}
+
+func (r *HubRepository) GetHubsByIDs(ctx context.Context, ids []uint) ([]models.Hub, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var hubs []models.Hub
+	for _, id := range ids {
+		if hub, ok := r.hubs[id]; ok {
+			hubs = append(hubs, hub)
+		}
+	}
+	return hubs, nil
+}
+
+func (r *HubRepository) create(hub *models.Hub) error {
+	for _, existing := range r.hubs {
+		if existing.Name == hub.Name {
+			return repository.TranslateError(gorm.ErrDuplicatedKey, constants.EntityHub)
+		}
+	}
+	now := time.Now()
+	hub.ID = r.nextID
+	hub.CreatedAt, hub.UpdatedAt = now, now
+	hub.Version = 1
+	r.nextID++
+	r.hubs[hub.ID] = *hub
+	return nil
+}
+
+// mergeHub copies the non-zero fields of update onto existing, mirroring
+// gorm's Updates with a struct argument.
+func mergeHub(existing *models.Hub, update *models.Hub) {
+	if update.Name != "" {
+		existing.Name = update.Name
+	}
+	if update.Address != "" {
+		existing.Address = update.Address
+	}
+	if update.City != "" {
+		existing.City = update.City
+	}
+	if update.State != "" {
+		existing.State = update.State
+	}
+	if update.Country != "" {
+		existing.Country = update.Country
+	}
+	if update.PostalCode != "" {
+		existing.PostalCode = update.PostalCode
+	}
+	if update.ContactName != "" {
+		existing.ContactName = update.ContactName
+	}
+	if update.ContactEmail != "" {
+		existing.ContactEmail = update.ContactEmail
+	}
+}
+
+func (r *HubRepository) ExportHubs(ctx context.Context, fn func([]models.Hub) error) error {
+	hubs, _ := r.GetAllHubs(ctx)
+	return fn(hubs)
+}
@@ -0,0 +1,627 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/events"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"gorm.io/gorm"
+)
+
+type inventoryKey struct {
+	hubID uint
+	skuID uint
+}
+
+type InventoryRepository struct {
+	mu             sync.Mutex
+	inventories    map[inventoryKey]models.Inventory
+	nextID         uint
+	transfers      map[uint]models.InventoryTransfer
+	nextTransferID uint
+	movements      []models.InventoryMovement
+	alerts         []models.InventoryAlert
+	SKURepo        repository.SkuRepositoryInterface
+	HubRepo        repository.HubRepositoryInterface
+	// Alerts receives opened stock alerts when set.
+	Alerts events.AlertPublisher
+}
+
+func NewInventoryRepository(skuRepo repository.SkuRepositoryInterface, hubRepo repository.HubRepositoryInterface) *InventoryRepository {
+	r := &InventoryRepository{
+		inventories:    make(map[inventoryKey]models.Inventory),
+		nextID:         1,
+		transfers:      make(map[uint]models.InventoryTransfer),
+		nextTransferID: 1,
+		SKURepo:        skuRepo,
+		HubRepo:        hubRepo,
+	}
+	if hubs, ok := hubRepo.(*HubRepository); ok {
+		hubs.stock = func(id uint) (int, int) {
+			return r.stock(
+				func(inv models.Inventory) bool { return inv.HubID == id },
+				func(t models.InventoryTransfer) bool { return t.SourceHubID == id || t.DestinationHubID == id },
+			)
+		}
+	}
+	if skus, ok := skuRepo.(*SkuRepository); ok {
+		skus.stock = func(id uint) (int, int) {
+			return r.stock(
+				func(inv models.Inventory) bool { return inv.SKUID == id },
+				func(t models.InventoryTransfer) bool { return t.SKUID == id },
+			)
+		}
+	}
+	return r
+}
+
+func (r *InventoryRepository) UpsertInventory(ctx context.Context, inventory *models.Inventory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.upsert(ctx, inventory); err != nil {
+		return err
+	}
+	r.publish(ctx, r.evaluate(inventoryKey{inventory.HubID, inventory.SKUID}))
+	return nil
+}
+
+func (r *InventoryRepository) GetInventoryByHubAndSKU(ctx context.Context, hubID, skuID uint) (*models.Inventory, error) {
+	r.mu.Lock()
+	inventory, ok := r.inventories[inventoryKey{hubID, skuID}]
+	r.mu.Unlock()
+
+	if !ok {
+		inventory = models.Inventory{HubID: hubID, SKUID: skuID, Quantity: 0}
+	}
+	hub, err := r.HubRepo.GetHubById(ctx, hubID)
+	if err != nil {
+		return nil, err
+	}
+	sku, err := r.SKURepo.GetSkuById(ctx, skuID)
+	if err != nil {
+		return nil, err
+	}
+	inventory.Hub, inventory.SKU = *hub, *sku
+	return &inventory, nil
+}
+
+func (r *InventoryRepository) GetInventoriesFiltered(ctx context.Context, skuCode *string, hubID *uint) ([]models.Inventory, error) {
+	var skuID uint
+	if skuCode != nil && *skuCode != "" {
+		skus, err := r.SKURepo.GetSKUsByCodes(ctx, []string{*skuCode})
+		if err != nil {
+			return nil, err
+		}
+		if len(skus) == 0 {
+			return []models.Inventory{}, nil
+		}
+		skuID = skus[0].ID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.filter(func(inv models.Inventory) bool {
+		return (skuID == 0 || inv.SKUID == skuID) &&
+			(hubID == nil || *hubID == 0 || inv.HubID == *hubID)
+	}), nil
+}
+
+func (r *InventoryRepository) GetInventory(ctx context.Context, hubID, skuID string) ([]models.Inventory, error) {
+	hub, _ := strconv.ParseUint(hubID, 10, 32)
+	sku, _ := strconv.ParseUint(skuID, 10, 32)
+
+	r.mu.Lock()
+	inventories := r.filter(func(inv models.Inventory) bool {
+		return (hubID == "" || inv.HubID == uint(hub)) &&
+			(skuID == "" || inv.SKUID == uint(sku))
+	})
+	r.mu.Unlock()
+
+	return r.preload(ctx, inventories), nil
+}
+
+func (r *InventoryRepository) GetInventoryWithZeroDefaults(ctx context.Context, hubID uint, skuIDs []uint) ([]models.Inventory, error) {
+	if len(skuIDs) == 0 {
+		r.mu.Lock()
+		inventories := r.filter(func(inv models.Inventory) bool { return inv.HubID == hubID })
+		r.mu.Unlock()
+		return r.preload(ctx, inventories), nil
+	}
+	return r.GetInventoriesByHubAndSKUs(ctx, hubID, skuIDs)
+}
+
+func (r *InventoryRepository) ReduceInventory(ctx context.Context, hubID, skuID uint, quantityToReduce int) error {
+	_, err := r.AtomicReduceInventory(ctx, hubID, skuID, quantityToReduce)
+	return err
+}
+
+func (r *InventoryRepository) UpsertInventoryBatch(ctx context.Context, inventories []models.Inventory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make(map[inventoryKey]models.Inventory, len(r.inventories))
+	for key, inv := range r.inventories {
+		snapshot[key] = inv
+	}
+	nextID := r.nextID
+
+	keys := make([]inventoryKey, len(inventories))
+	for i := range inventories {
+		if err := r.upsert(ctx, &inventories[i]); err != nil {
+			r.inventories, r.nextID = snapshot, nextID
+			return err
+		}
+		keys[i] = inventoryKey{inventories[i].HubID, inventories[i].SKUID}
+	}
+	r.publish(ctx, r.evaluate(keys...))
+	return nil
+}
+
+func (r *InventoryRepository) UpsertInventoryBatchPartial(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]models.BatchItemResult, len(inventories))
+	last := make(map[inventoryKey]int, len(inventories))
+	for i := range inventories {
+		last[inventoryKey{inventories[i].HubID, inventories[i].SKUID}] = i
+	}
+	var keys []inventoryKey
+	for i := range inventories {
+		key := inventoryKey{inventories[i].HubID, inventories[i].SKUID}
+		if err := r.requireParents(ctx, key.hubID, key.skuID); err != nil {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemInvalid, Reason: constants.ErrInvalidReference}
+			continue
+		}
+		if last[key] != i {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrSupersededRow}
+			continue
+		}
+		_, existed := r.inventories[key]
+		if err := r.upsert(ctx, &inventories[i]); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		status := models.BatchItemCreated
+		if existed {
+			status = models.BatchItemUpdated
+		}
+		results[i] = models.BatchItemResult{Index: i, Status: status}
+	}
+	r.publish(ctx, r.evaluate(keys...))
+	return results, nil
+}
+
+func (r *InventoryRepository) CheckInventoryBatchPartial(ctx context.Context, inventories []models.Inventory) ([]models.BatchItemResult, error) {
+	results := make([]models.BatchItemResult, len(inventories))
+	last := make(map[inventoryKey]int, len(inventories))
+	for i := range inventories {
+		last[inventoryKey{inventories[i].HubID, inventories[i].SKUID}] = i
+	}
+	for i := range inventories {
+		key := inventoryKey{inventories[i].HubID, inventories[i].SKUID}
+		switch {
+		case r.requireParents(ctx, key.hubID, key.skuID) != nil:
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemInvalid, Reason: constants.ErrInvalidReference}
+		case last[key] != i:
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrSupersededRow}
+		default:
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemValid}
+		}
+	}
+	return results, nil
+}
+
+func (r *InventoryRepository) GetInventoriesByHubAndSKUs(ctx context.Context, hubID uint, skuIDs []uint) ([]models.Inventory, error) {
+	hub, err := r.HubRepo.GetHubById(ctx, hubID)
+	if err != nil {
+		return nil, err
+	}
+	skus, err := r.SKURepo.GetSKUsByIDs(ctx, skuIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var inventories []models.Inventory
+	for _, sku := range skus {
+		inventory, ok := r.inventories[inventoryKey{hubID, sku.ID}]
+		if !ok {
+			inventory = models.Inventory{HubID: hubID, SKUID: sku.ID, Quantity: 0}
+		}
+		inventory.Hub, inventory.SKU = *hub, sku
+		inventories = append(inventories, inventory)
+	}
+	return inventories, nil
+}
+
+func (r *InventoryRepository) AtomicReduceInventory(ctx context.Context, hubID, skuID uint, quantityToReduce int) (*models.Inventory, error) {
+	if quantityToReduce <= 0 {
+		return nil, repository.NewValidationError(constants.ErrCodeInvalidQuantity, "quantity to reduce must be positive", nil)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := inventoryKey{hubID, skuID}
+	inventory, ok := r.inventories[key]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntityInventory, nil)
+	}
+	if inventory.Quantity < quantityToReduce {
+		return nil, repository.NewInsufficientStockError(inventory.Quantity, quantityToReduce)
+	}
+	inventory.Quantity -= quantityToReduce
+	inventory.UpdatedAt = time.Now()
+	r.inventories[key] = inventory
+	r.publish(ctx, r.evaluate(key))
+	return &inventory, nil
+}
+
+func (r *InventoryRepository) GetInventoryMovements(ctx context.Context, hubID, skuID uint, limit int) ([]models.InventoryMovement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var movements []models.InventoryMovement
+	limit = repository.HistoryLimit(limit)
+	for i := len(r.movements) - 1; i >= 0 && len(movements) < limit; i-- {
+		m := r.movements[i]
+		if (hubID == 0 || m.HubID == hubID) && (skuID == 0 || m.SKUID == skuID) {
+			movements = append(movements, m)
+		}
+	}
+	return movements, nil
+}
+
+func (r *InventoryRepository) SetStockThresholds(ctx context.Context, inventory *models.Inventory) error {
+	if err := r.requireParents(ctx, inventory.HubID, inventory.SKUID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := inventoryKey{inventory.HubID, inventory.SKUID}
+	r.addStock(key.hubID, key.skuID, 0)
+	stored := r.inventories[key]
+	stored.ReorderPoint, stored.SafetyStock = inventory.ReorderPoint, inventory.SafetyStock
+	r.inventories[key] = stored
+	*inventory = stored
+	r.publish(ctx, r.evaluate(key))
+	return nil
+}
+
+func (r *InventoryRepository) GetInventoryAlerts(ctx context.Context, filter repository.AlertFilter) ([]models.InventoryAlert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var alerts []models.InventoryAlert
+	for i := len(r.alerts) - 1; i >= 0 && (filter.Limit <= 0 || len(alerts) < filter.Limit); i-- {
+		a := r.alerts[i]
+		if (filter.IncludeResolved || a.ResolvedAt == nil) &&
+			(filter.HubID == 0 || a.HubID == filter.HubID) &&
+			(filter.SKUID == 0 || a.SKUID == filter.SKUID) &&
+			(filter.Level == "" || a.Level == filter.Level) {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts, nil
+}
+
+func (r *InventoryRepository) GetAvailabilityBySKUCodes(ctx context.Context, codes []string, filter repository.AvailabilityFilter) ([]models.SKUAvailability, error) {
+	skus, err := r.SKURepo.GetSKUsByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[uint]bool, len(skus))
+	for _, sku := range skus {
+		wanted[sku.ID] = true
+	}
+
+	r.mu.Lock()
+	inventories := r.filter(func(inv models.Inventory) bool { return wanted[inv.SKUID] && inv.Quantity > 0 })
+	r.mu.Unlock()
+
+	sort.SliceStable(inventories, func(i, j int) bool {
+		if inventories[i].Quantity != inventories[j].Quantity {
+			return inventories[i].Quantity > inventories[j].Quantity
+		}
+		return inventories[i].HubID < inventories[j].HubID
+	})
+	stock := make(map[uint][]models.HubAvailability)
+	for _, inv := range inventories {
+		hub, err := r.HubRepo.GetHubById(ctx, inv.HubID)
+		if err != nil ||
+			(filter.Country != "" && !strings.EqualFold(hub.Country, filter.Country)) ||
+			(filter.State != "" && !strings.EqualFold(hub.State, filter.State)) {
+			continue
+		}
+		stock[inv.SKUID] = append(stock[inv.SKUID], models.HubAvailability{
+			HubID:      hub.ID,
+			HubName:    hub.Name,
+			City:       hub.City,
+			State:      hub.State,
+			Country:    hub.Country,
+			PostalCode: hub.PostalCode,
+			Quantity:   inv.Quantity,
+		})
+	}
+
+	availability := make([]models.SKUAvailability, len(skus))
+	for i, sku := range skus {
+		availability[i] = models.NewSKUAvailability(sku, stock[sku.ID])
+	}
+	return availability, nil
+}
+
+func (r *InventoryRepository) CheckAvailabilityBatch(ctx context.Context, lines []models.AvailabilityLine) (*models.AvailabilityCheck, error) {
+	results := make([]models.LineAvailability, len(lines))
+	for i, line := range lines {
+		result := models.LineAvailability{
+			Index:            i,
+			HubID:            line.HubID,
+			HubCode:          line.HubCode,
+			SKUID:            line.SKUID,
+			SKUCode:          line.SKUCode,
+			RequiredQuantity: line.RequiredQuantity,
+		}
+		hub, sku := r.resolve(ctx, line)
+		if hub == nil || sku == nil {
+			result.Reason = constants.ErrInvalidReference
+			results[i] = result
+			continue
+		}
+		result.HubID, result.HubCode, result.SKUID, result.SKUCode = hub.ID, hub.Name, sku.ID, sku.Code
+
+		r.mu.Lock()
+		result.AvailableQuantity = r.inventories[inventoryKey{hub.ID, sku.ID}].Quantity
+		r.mu.Unlock()
+		results[i] = result
+	}
+	check := models.NewAvailabilityCheck(results)
+	return &check, nil
+}
+
+// resolve looks up a line's hub and SKU by ID, or by code when the ID is zero.
+func (r *InventoryRepository) resolve(ctx context.Context, line models.AvailabilityLine) (*models.Hub, *models.SKU) {
+	var hub *models.Hub
+	if line.HubID != 0 {
+		hub, _ = r.HubRepo.GetHubById(ctx, line.HubID)
+	} else {
+		hub, _ = r.HubRepo.GetHubByName(ctx, line.HubCode)
+	}
+
+	var sku *models.SKU
+	if line.SKUID != 0 {
+		sku, _ = r.SKURepo.GetSkuById(ctx, line.SKUID)
+	} else if skus, _ := r.SKURepo.GetSKUsByCodes(ctx, []string{line.SKUCode}); len(skus) == 1 {
+		sku = &skus[0]
+	}
+	return hub, sku
+}
+
+func (r *InventoryRepository) CheckInventoryAvailability(ctx context.Context, hubID, skuID uint, requiredQuantity int) (bool, error) {
+	inventory, err := r.GetInventoryByHubAndSKU(ctx, hubID, skuID)
+	if err != nil {
+		return false, err
+	}
+	return inventory.Quantity >= requiredQuantity, nil
+}
+
+func (r *InventoryRepository) upsert(ctx context.Context, inventory *models.Inventory) error {
+	if err := r.requireParents(ctx, inventory.HubID, inventory.SKUID); err != nil {
+		return err
+	}
+
+	key := inventoryKey{inventory.HubID, inventory.SKUID}
+	now := time.Now()
+	existing, ok := r.inventories[key]
+	if ok {
+		existing.Quantity = inventory.Quantity
+		existing.UpdatedAt = now
+		r.inventories[key] = existing
+		inventory.ID = existing.ID
+		return nil
+	}
+
+	inventory.ID = r.nextID
+	inventory.CreatedAt, inventory.UpdatedAt = now, now
+	r.nextID++
+	r.inventories[key] = models.Inventory{
+		Model:    inventory.Model,
+		HubID:    inventory.HubID,
+		SKUID:    inventory.SKUID,
+		Quantity: inventory.Quantity,
+	}
+	return nil
+}
+
+func (r *InventoryRepository) requireParents(ctx context.Context, hubID, skuID uint) error {
+	if _, err := r.HubRepo.GetHubById(ctx, hubID); errors.Is(err, repository.ErrNotFound) {
+		return repository.TranslateError(gorm.ErrForeignKeyViolated, constants.EntityInventory)
+	}
+	if _, err := r.SKURepo.GetSkuById(ctx, skuID); errors.Is(err, repository.ErrNotFound) {
+		return repository.TranslateError(gorm.ErrForeignKeyViolated, constants.EntityInventory)
+	}
+	return nil
+}
+
+// evaluate mirrors the repository package: a pair whose level changed has its
+// open alert resolved and, unless it is back to ok, a new alert opened. The
+// caller holds r.mu.
+func (r *InventoryRepository) evaluate(keys ...inventoryKey) []models.InventoryAlert {
+	var opened []models.InventoryAlert
+	for _, key := range keys {
+		inventory, ok := r.inventories[key]
+		if !ok {
+			continue
+		}
+		level := inventory.StockLevel()
+		open := -1
+		for i, alert := range r.alerts {
+			if alert.HubID == key.hubID && alert.SKUID == key.skuID && alert.ResolvedAt == nil {
+				open = i
+			}
+		}
+		if open >= 0 && r.alerts[open].Level == level {
+			continue
+		}
+		now := time.Now()
+		if open >= 0 {
+			r.alerts[open].ResolvedAt = &now
+		}
+		if level != models.StockOK {
+			alert := models.NewInventoryAlert(inventory, level)
+			alert.ID = uint(len(r.alerts) + 1)
+			alert.CreatedAt = now
+			r.alerts = append(r.alerts, alert)
+			opened = append(opened, alert)
+		}
+	}
+	return opened
+}
+
+func (r *InventoryRepository) publish(ctx context.Context, alerts []models.InventoryAlert) {
+	if r.Alerts != nil && len(alerts) > 0 {
+		_ = r.Alerts.PublishAlerts(ctx, alerts)
+	}
+}
+
+// stock sums the matching on-hand quantities and in-transit transfers.
+func (r *InventoryRepository) stock(onHand func(models.Inventory) bool, transfer func(models.InventoryTransfer) bool) (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total, inTransit := 0, 0
+	for _, inv := range r.inventories {
+		if onHand(inv) {
+			total += inv.Quantity
+		}
+	}
+	for _, t := range r.transfers {
+		if t.Status == models.TransferInTransit && transfer(t) {
+			inTransit += t.Quantity
+		}
+	}
+	return total, inTransit
+}
+
+func (r *InventoryRepository) filter(keep func(models.Inventory) bool) []models.Inventory {
+	var inventories []models.Inventory
+	for _, inv := range r.inventories {
+		if keep(inv) {
+			inventories = append(inventories, inv)
+		}
+	}
+	sort.Slice(inventories, func(i, j int) bool { return inventories[i].ID < inventories[j].ID })
+	return inventories
+}
+
+func (r *InventoryRepository) preload(ctx context.Context, inventories []models.Inventory) []models.Inventory {
+	for i := range inventories {
+		if hub, err := r.HubRepo.GetHubById(ctx, inventories[i].HubID); err == nil {
+			inventories[i].Hub = *hub
+		}
+		if sku, err := r.SKURepo.GetSkuById(ctx, inventories[i].SKUID); err == nil {
+			inventories[i].SKU = *sku
+		}
+	}
+	return inventories
+}
+
+var (
+	_ repository.HubRepositoryInterface       = (*HubRepository)(nil)
+	_ repository.SkuRepositoryInterface       = (*SkuRepository)(nil)
+	_ repository.InventoryRepositoryInterface = (*InventoryRepository)(nil)
+	_ repository.TransferRepositoryInterface  = (*TransferRepository)(nil)
+)
+
+func (r *InventoryRepository) GetValuation(ctx context.Context, groupBy []models.ValuationDimension, filter repository.ValuationFilter) ([]models.ValuationRow, error) {
+	r.mu.Lock()
+	inventories := r.filter(func(inv models.Inventory) bool {
+		return inv.Quantity > 0 && (filter.HubID == 0 || inv.HubID == filter.HubID)
+	})
+	r.mu.Unlock()
+
+	type group struct {
+		row      models.ValuationRow
+		unpriced map[uint]bool
+	}
+	groups := make(map[models.ValuationRow]*group)
+	for _, inv := range inventories {
+		hub, err := r.HubRepo.GetHubById(ctx, inv.HubID)
+		if err != nil {
+			continue
+		}
+		sku, err := r.SKURepo.GetSkuById(ctx, inv.SKUID)
+		if err != nil ||
+			(filter.Category != "" && sku.Category != filter.Category) ||
+			(filter.TenantID != "" && sku.TenantId != filter.TenantID) ||
+			(filter.SellerID != "" && sku.SellerId != filter.SellerID) {
+			continue
+		}
+
+		var key models.ValuationRow
+		for _, dimension := range groupBy {
+			switch dimension {
+			case models.ValuationByHub:
+				key.HubID, key.HubName = hub.ID, hub.Name
+			case models.ValuationByCategory:
+				key.Category = sku.Category
+			case models.ValuationByTenant:
+				key.TenantID = sku.TenantId
+			case models.ValuationBySeller:
+				key.SellerID = sku.SellerId
+			}
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{row: key, unpriced: make(map[uint]bool)}
+			groups[key] = g
+		}
+		g.row.Quantity += inv.Quantity
+		if !sku.Price.Valid {
+			g.row.UnpricedQuantity += inv.Quantity
+			g.unpriced[sku.ID] = true
+			continue
+		}
+		value := float64(inv.Quantity) * sku.Price.Float64
+		if g.row.Value != nil {
+			value += *g.row.Value
+		}
+		g.row.Value = &value
+	}
+
+	rows := make([]models.ValuationRow, 0, len(groups))
+	for _, g := range groups {
+		g.row.UnpricedSKUs = len(g.unpriced)
+		rows = append(rows, g.row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.HubID != b.HubID {
+			return a.HubID < b.HubID
+		}
+		return a.Category+"\x00"+a.TenantID+"\x00"+a.SellerID < b.Category+"\x00"+b.TenantID+"\x00"+b.SellerID
+	})
+	return rows, nil
+}
+
+func (r *InventoryRepository) ExportInventory(ctx context.Context, hubID, skuID uint, fn func([]models.Inventory) error) error {
+	r.mu.Lock()
+	inventories := r.filter(func(inv models.Inventory) bool {
+		return (hubID == 0 || inv.HubID == hubID) && (skuID == 0 || inv.SKUID == skuID)
+	})
+	r.mu.Unlock()
+	return fn(r.preload(ctx, inventories))
+}
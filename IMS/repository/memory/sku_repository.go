@@ -0,0 +1,278 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"gorm.io/gorm"
+)
+
+type SkuRepository struct {
+	mu      sync.RWMutex
+	skus    map[uint]models.SKU
+	deleted map[uint]models.SKU
+	nextID  uint
+	// stock reports the units held against a sku; NewInventoryRepository
+	// wires it so deletes can refuse stocked skus.
+	stock func(id uint) (onHand, inTransit int)
+}
+
+func NewSkuRepository() *SkuRepository {
+	return &SkuRepository{skus: make(map[uint]models.SKU), deleted: make(map[uint]models.SKU), nextID: 1}
+}
+
+func (r *SkuRepository) GetAllSkus(ctx context.Context) ([]models.SKU, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.filter(func(models.SKU) bool { return true }), nil
+}
+
+func (r *SkuRepository) GetSkuById(ctx context.Context, id uint) (*models.SKU, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sku, ok := r.skus[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntitySKU, gorm.ErrRecordNotFound)
+	}
+	return &sku, nil
+}
+
+func (r *SkuRepository) CreateSku(ctx context.Context, sku *models.SKU) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.create(sku)
+}
+
+func (r *SkuRepository) UpdateSku(ctx context.Context, sku *models.SKU) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sku.Version == 0 {
+		return repository.NewPreconditionRequiredError()
+	}
+	existing, ok := r.skus[sku.ID]
+	if !ok {
+		return repository.NewNotFoundError(constants.EntitySKU, nil)
+	}
+	if existing.Version != sku.Version {
+		return repository.NewVersionMismatchError(sku.Version, existing.Version)
+	}
+	mergeSKU(&existing, sku)
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+	r.skus[sku.ID] = existing
+	*sku = existing
+	return nil
+}
+
+func (r *SkuRepository) DeleteSku(ctx context.Context, id uint, force bool) error {
+	if _, err := r.GetSkuById(ctx, id); err != nil {
+		return err
+	}
+	if r.stock != nil {
+		if stock, inTransit := r.stock(id); (stock > 0 || inTransit > 0) && !force {
+			return repository.NewHasStockError(constants.EntitySKU, stock, inTransit)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sku, ok := r.skus[id]
+	if !ok {
+		return repository.NewNotFoundError(constants.EntitySKU, nil)
+	}
+	sku.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	delete(r.skus, id)
+	r.deleted[id] = sku
+	return nil
+}
+
+func (r *SkuRepository) RestoreSku(ctx context.Context, id uint) (*models.SKU, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sku, ok := r.deleted[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntitySKU, nil)
+	}
+	for _, live := range r.skus {
+		if live.Code == sku.Code {
+			return nil, repository.TranslateError(gorm.ErrDuplicatedKey, constants.EntitySKU)
+		}
+	}
+	sku.DeletedAt = gorm.DeletedAt{}
+	sku.UpdatedAt = time.Now()
+	delete(r.deleted, id)
+	r.skus[id] = sku
+	return &sku, nil
+}
+
+func (r *SkuRepository) GetDeletedSkus(ctx context.Context) ([]models.SKU, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	skus := make([]models.SKU, 0, len(r.deleted))
+	for _, sku := range r.deleted {
+		skus = append(skus, sku)
+	}
+	sort.Slice(skus, func(i, j int) bool { return skus[i].DeletedAt.Time.After(skus[j].DeletedAt.Time) })
+	return skus, nil
+}
+
+func (r *SkuRepository) GetSkusByTenantAndSeller(ctx context.Context, tenantID string, sellerID string, skuCodes []string) ([]models.SKU, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codes := make(map[string]bool, len(skuCodes))
+	for _, code := range skuCodes {
+		codes[code] = true
+	}
+	return r.filter(func(sku models.SKU) bool {
+		return (tenantID == "" || sku.TenantId == tenantID) &&
+			(sellerID == "" || sku.SellerId == sellerID) &&
+			(len(codes) == 0 || codes[sku.Code])
+	}), nil
+}
+
+func (r *SkuRepository) CreateSKUsBatch(ctx context.Context, skus []models.SKU) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make(map[uint]models.SKU, len(r.skus))
+	for id, sku := range r.skus {
+		snapshot[id] = sku
+	}
+	nextID := r.nextID
+
+	for i := range skus {
+		if err := r.create(&skus[i]); err != nil {
+			r.skus, r.nextID = snapshot, nextID
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SkuRepository) CreateSKUsBatchPartial(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]models.BatchItemResult, len(skus))
+	for i := range skus {
+		if err := r.create(&skus[i]); err != nil {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+			continue
+		}
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemCreated, ID: skus[i].ID}
+	}
+	return results, nil
+}
+
+func (r *SkuRepository) CheckSKUsBatchPartial(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	taken := make(map[string]bool, len(r.skus)+len(skus))
+	for _, sku := range r.skus {
+		taken[sku.Code] = true
+	}
+	results := make([]models.BatchItemResult, len(skus))
+	for i, sku := range skus {
+		if taken[sku.Code] {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+			continue
+		}
+		taken[sku.Code] = true
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemValid}
+	}
+	return results, nil
+}
+
+func (r *SkuRepository) GetSKUsByIDs(ctx context.Context, ids []uint) ([]models.SKU, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.filter(func(sku models.SKU) bool { return wanted[sku.ID] }), nil
+}
+
+func (r *SkuRepository) GetSKUsByCodes(ctx context.Context, codes []string) ([]models.SKU, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		wanted[code] = true
+	}
+	return r.filter(func(sku models.SKU) bool { return wanted[sku.Code] }), nil
+}
+
+func (r *SkuRepository) create(sku *models.SKU) error {
+	for _, existing := range r.skus {
+		if existing.Code == sku.Code {
+			return repository.TranslateError(gorm.ErrDuplicatedKey, constants.EntitySKU)
+		}
+	}
+	now := time.Now()
+	sku.ID = r.nextID
+	sku.CreatedAt, sku.UpdatedAt = now, now
+	sku.Version = 1
+	r.nextID++
+	r.skus[sku.ID] = *sku
+	return nil
+}
+
+func (r *SkuRepository) filter(keep func(models.SKU) bool) []models.SKU {
+	var skus []models.SKU
+	for _, sku := range r.skus {
+		if keep(sku) {
+			skus = append(skus, sku)
+		}
+	}
+	sort.Slice(skus, func(i, j int) bool { return skus[i].ID < skus[j].ID })
+	return skus
+}
+
+func mergeSKU(existing *models.SKU, update *models.SKU) {
+	if update.Code != "" {
+		existing.Code = update.Code
+	}
+	if update.Name != "" {
+		existing.Name = update.Name
+	}
+	if update.Description != "" {
+		existing.Description = update.Description
+	}
+	if update.TenantId != "" {
+		existing.TenantId = update.TenantId
+	}
+	if update.SellerId != "" {
+		existing.SellerId = update.SellerId
+	}
+	if update.Category != "" {
+		existing.Category = update.Category
+	}
+	if update.Price.Valid {
+		existing.Price = update.Price
+	}
+}
+
+func (r *SkuRepository) ExportSkus(ctx context.Context, filter repository.SKUFilter, fn func([]models.SKU) error) error {
+	r.mu.RLock()
+	skus := r.filter(func(sku models.SKU) bool {
+		return (filter.TenantID == "" || sku.TenantId == filter.TenantID) &&
+			(filter.SellerID == "" || sku.SellerId == filter.SellerID)
+	})
+	r.mu.RUnlock()
+	return fn(skus)
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+)
+
+type snapshotKey struct {
+	date string
+	inventoryKey
+}
+
+// SnapshotRepository snapshots the stock held by Inventory.
+type SnapshotRepository struct {
+	mu        sync.Mutex
+	Inventory *InventoryRepository
+	snapshots map[snapshotKey]models.InventorySnapshot
+	nextID    uint
+}
+
+func NewSnapshotRepository(inventory *InventoryRepository) *SnapshotRepository {
+	return &SnapshotRepository{
+		Inventory: inventory,
+		snapshots: make(map[snapshotKey]models.InventorySnapshot),
+		nextID:    1,
+	}
+}
+
+func (r *SnapshotRepository) TakeSnapshot(ctx context.Context, date time.Time) (int64, error) {
+	inv := r.Inventory
+	inv.mu.Lock()
+	inventories := make([]models.Inventory, 0, len(inv.inventories))
+	for _, inventory := range inv.inventories {
+		inventories = append(inventories, inventory)
+	}
+	inv.mu.Unlock()
+
+	day := date.Format(time.DateOnly)
+	snapshotDate, _ := time.Parse(time.DateOnly, day)
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, inventory := range inventories {
+		snapshot := models.InventorySnapshot{
+			ID:           r.nextID,
+			CreatedAt:    now,
+			SnapshotDate: snapshotDate,
+			HubID:        inventory.HubID,
+			SKUID:        inventory.SKUID,
+			Quantity:     inventory.Quantity,
+		}
+		if sku, err := inv.SKURepo.GetSkuById(ctx, inventory.SKUID); err == nil && sku.Price.Valid {
+			price := sku.Price.Float64
+			snapshot.UnitPrice = &price
+		}
+		r.nextID++
+		r.snapshots[snapshotKey{day, inventoryKey{inventory.HubID, inventory.SKUID}}] = snapshot
+	}
+	return int64(len(inventories)), nil
+}
+
+func (r *SnapshotRepository) HasSnapshot(ctx context.Context, date time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	day := date.Format(time.DateOnly)
+	for key := range r.snapshots {
+		if key.date == day {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *SnapshotRepository) GetStockAsOf(ctx context.Context, date time.Time, filter repository.SnapshotFilter) (*models.StockReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshotDate := r.latest(date)
+	if snapshotDate == nil {
+		return models.NewStockReport(date, nil, nil), nil
+	}
+	return models.NewStockReport(date, snapshotDate, r.rows(snapshotDate, filter)), nil
+}
+
+func (r *SnapshotRepository) GetStockChange(ctx context.Context, from, to time.Time, filter repository.SnapshotFilter) (*models.StockChangeReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &models.StockChangeReport{From: from, To: to, FromSnapshotDate: r.latest(from), ToSnapshotDate: r.latest(to), Changes: []models.StockChange{}}
+	if report.ToSnapshotDate == nil {
+		return report, nil
+	}
+	quantities := make(map[inventoryKey][2]int)
+	for _, s := range r.rows(report.FromSnapshotDate, filter) {
+		q := quantities[inventoryKey{s.HubID, s.SKUID}]
+		q[0] = s.Quantity
+		quantities[inventoryKey{s.HubID, s.SKUID}] = q
+	}
+	for _, s := range r.rows(report.ToSnapshotDate, filter) {
+		q := quantities[inventoryKey{s.HubID, s.SKUID}]
+		q[1] = s.Quantity
+		quantities[inventoryKey{s.HubID, s.SKUID}] = q
+	}
+	for key, q := range quantities {
+		if q[0] != q[1] {
+			report.Changes = append(report.Changes, models.StockChange{
+				HubID: key.hubID, SKUID: key.skuID, FromQuantity: q[0], ToQuantity: q[1], Change: q[1] - q[0],
+			})
+		}
+	}
+	sort.Slice(report.Changes, func(i, j int) bool {
+		a, b := report.Changes[i], report.Changes[j]
+		return a.HubID < b.HubID || a.HubID == b.HubID && a.SKUID < b.SKUID
+	})
+	return report, nil
+}
+
+// latest returns the latest snapshot date on or before date. The caller
+// holds r.mu.
+func (r *SnapshotRepository) latest(date time.Time) *time.Time {
+	day := date.Format(time.DateOnly)
+	var latest *time.Time
+	for key, s := range r.snapshots {
+		if key.date <= day && (latest == nil || s.SnapshotDate.After(*latest)) {
+			snapshotDate := s.SnapshotDate
+			latest = &snapshotDate
+		}
+	}
+	return latest
+}
+
+func (r *SnapshotRepository) rows(date *time.Time, filter repository.SnapshotFilter) []models.InventorySnapshot {
+	if date == nil {
+		return nil
+	}
+	day := date.Format(time.DateOnly)
+	var rows []models.InventorySnapshot
+	for key, s := range r.snapshots {
+		if key.date == day && (filter.HubID == 0 || s.HubID == filter.HubID) && (filter.SKUID == 0 || s.SKUID == filter.SKUID) {
+			rows = append(rows, s)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].HubID < rows[j].HubID || rows[i].HubID == rows[j].HubID && rows[i].SKUID < rows[j].SKUID
+	})
+	return rows
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+)
+
+// TransferRepository keeps transfers next to the stock of Inventory and
+// under its lock, so every step moves stock and records history atomically.
+type TransferRepository struct {
+	Inventory *InventoryRepository
+}
+
+func NewTransferRepository(inventory *InventoryRepository) *TransferRepository {
+	return &TransferRepository{Inventory: inventory}
+}
+
+func (r *TransferRepository) CreateTransfer(ctx context.Context, transfer *models.InventoryTransfer, receive bool) error {
+	if err := r.requireParents(ctx, transfer); err != nil {
+		return err
+	}
+
+	inv := r.Inventory
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	key := inventoryKey{transfer.SourceHubID, transfer.SKUID}
+	source := inv.inventories[key]
+	if source.Quantity < transfer.Quantity {
+		return repository.NewInsufficientStockError(source.Quantity, transfer.Quantity)
+	}
+	now := time.Now()
+	source.Quantity -= transfer.Quantity
+	source.UpdatedAt = now
+	inv.inventories[key] = source
+
+	transfer.ID = inv.nextTransferID
+	inv.nextTransferID++
+	transfer.CreatedAt, transfer.UpdatedAt = now, now
+	transfer.Status = models.TransferInTransit
+	transfer.DispatchedAt = &now
+	transfer.ReceivedAt, transfer.CancelledAt = nil, nil
+	inv.record(transfer.SourceHubID, transfer, -transfer.Quantity, source.Quantity, models.MovementTransferOut)
+	keys := []inventoryKey{key}
+	if receive {
+		inv.settleTransfer(transfer, models.TransferReceived)
+		keys = append(keys, inventoryKey{transfer.DestinationHubID, transfer.SKUID})
+	}
+	inv.transfers[transfer.ID] = *transfer
+	inv.publish(ctx, inv.evaluate(keys...))
+	return nil
+}
+
+func (r *TransferRepository) ReceiveTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error) {
+	return r.settle(ctx, id, models.TransferReceived)
+}
+
+func (r *TransferRepository) CancelTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error) {
+	return r.settle(ctx, id, models.TransferCancelled)
+}
+
+func (r *TransferRepository) GetTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error) {
+	r.Inventory.mu.Lock()
+	defer r.Inventory.mu.Unlock()
+
+	transfer, ok := r.Inventory.transfers[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntityTransfer, nil)
+	}
+	return &transfer, nil
+}
+
+func (r *TransferRepository) ListTransfers(ctx context.Context, filter repository.TransferFilter) ([]models.InventoryTransfer, error) {
+	r.Inventory.mu.Lock()
+	defer r.Inventory.mu.Unlock()
+
+	var transfers []models.InventoryTransfer
+	for _, t := range r.Inventory.transfers {
+		if (filter.Status == "" || t.Status == filter.Status) &&
+			(filter.HubID == 0 || t.SourceHubID == filter.HubID || t.DestinationHubID == filter.HubID) &&
+			(filter.SKUID == 0 || t.SKUID == filter.SKUID) {
+			transfers = append(transfers, t)
+		}
+	}
+	sort.Slice(transfers, func(i, j int) bool { return transfers[i].ID > transfers[j].ID })
+	if limit := repository.HistoryLimit(filter.Limit); len(transfers) > limit {
+		transfers = transfers[:limit]
+	}
+	return transfers, nil
+}
+
+func (r *TransferRepository) settle(ctx context.Context, id uint, status models.TransferStatus) (*models.InventoryTransfer, error) {
+	inv := r.Inventory
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	transfer, ok := inv.transfers[id]
+	if !ok {
+		return nil, repository.NewNotFoundError(constants.EntityTransfer, nil)
+	}
+	if transfer.Status != models.TransferInTransit {
+		return nil, repository.NewTransferStateError(transfer.Status)
+	}
+	hubID := transfer.DestinationHubID
+	if status == models.TransferCancelled {
+		hubID = transfer.SourceHubID
+	}
+	if err := inv.requireParents(ctx, hubID, transfer.SKUID); err != nil {
+		return nil, err
+	}
+	inv.settleTransfer(&transfer, status)
+	inv.transfers[id] = transfer
+	inv.publish(ctx, inv.evaluate(inventoryKey{hubID, transfer.SKUID}))
+	return &transfer, nil
+}
+
+func (r *TransferRepository) requireParents(ctx context.Context, transfer *models.InventoryTransfer) error {
+	invalid := repository.NewValidationError(constants.ErrCodeInvalidReference, constants.ErrInvalidReference, nil)
+	for _, hubID := range []uint{transfer.SourceHubID, transfer.DestinationHubID} {
+		if _, err := r.Inventory.HubRepo.GetHubById(ctx, hubID); errors.Is(err, repository.ErrNotFound) {
+			return invalid
+		}
+	}
+	if _, err := r.Inventory.SKURepo.GetSkuById(ctx, transfer.SKUID); errors.Is(err, repository.ErrNotFound) {
+		return invalid
+	}
+	return nil
+}
+
+// settleTransfer mirrors the repository package: the destination is credited
+// on receipt, the source on cancellation. The caller holds r.mu.
+func (r *InventoryRepository) settleTransfer(transfer *models.InventoryTransfer, status models.TransferStatus) {
+	hubID, reason := transfer.DestinationHubID, models.MovementTransferIn
+	if status == models.TransferCancelled {
+		hubID, reason = transfer.SourceHubID, models.MovementTransferReturn
+	}
+	quantity := r.addStock(hubID, transfer.SKUID, transfer.Quantity)
+
+	now := time.Now()
+	transfer.Status = status
+	transfer.UpdatedAt = now
+	if status == models.TransferCancelled {
+		transfer.CancelledAt = &now
+	} else {
+		transfer.ReceivedAt = &now
+	}
+	r.record(hubID, transfer, transfer.Quantity, quantity, reason)
+}
+
+func (r *InventoryRepository) addStock(hubID, skuID uint, quantity int) int {
+	key := inventoryKey{hubID, skuID}
+	now := time.Now()
+	inventory, ok := r.inventories[key]
+	if !ok {
+		inventory = models.Inventory{HubID: hubID, SKUID: skuID}
+		inventory.ID = r.nextID
+		inventory.CreatedAt = now
+		r.nextID++
+	}
+	inventory.Quantity += quantity
+	inventory.UpdatedAt = now
+	r.inventories[key] = inventory
+	return inventory.Quantity
+}
+
+func (r *InventoryRepository) record(hubID uint, transfer *models.InventoryTransfer, quantity, after int, reason models.MovementReason) {
+	transferID := transfer.ID
+	r.movements = append(r.movements, models.InventoryMovement{
+		ID:            uint(len(r.movements) + 1),
+		CreatedAt:     time.Now(),
+		HubID:         hubID,
+		SKUID:         transfer.SKUID,
+		Quantity:      quantity,
+		QuantityAfter: after,
+		Reason:        reason,
+		TransferID:    &transferID,
+	})
+}
@@ -5,94 +5,119 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/Trishank-Omniful/Onboarding-Task/cache"
 	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/metrics"
 	"github.com/Trishank-Omniful/Onboarding-Task/models"
-	"github.com/omniful/go_commons/redis"
 	"gorm.io/gorm"
 )
 
 type SkuRepository struct {
-	DB    *gorm.DB
-	Redis *redis.Client
+	DB       *gorm.DB
+	Cache    cache.Cache
+	CacheTTL time.Duration
 }
 
-func NewSkuRepository(db *gorm.DB, redis *redis.Client) *SkuRepository {
-	return &SkuRepository{DB: db, Redis: redis}
+func NewSkuRepository(db *gorm.DB, cache cache.Cache, cacheTTL time.Duration) *SkuRepository {
+	return &SkuRepository{DB: db, Cache: cache, CacheTTL: cacheTTL}
 }
 
 func getSKUIDCacheKey(id uint) string {
 	return fmt.Sprintf("%s%d", constants.CacheKeySKUID, id)
 }
 
-func (r *SkuRepository) GetAllSkus() ([]models.SKU, error) {
+func (r *SkuRepository) GetAllSkus(ctx context.Context) ([]models.SKU, error) {
 	var skus []models.SKU
-	result := r.DB.Find(&skus)
+	result := r.DB.WithContext(ctx).Find(&skus)
 	return skus, result.Error
 }
 
-func (r *SkuRepository) GetSkuById(id uint) (*models.SKU, error) {
-	ctx := context.Background()
+func (r *SkuRepository) GetSkuById(ctx context.Context, id uint) (*models.SKU, error) {
 	cacheKey := getSKUIDCacheKey(id)
 	var sku models.SKU
 
-	val, err := r.Redis.Get(ctx, cacheKey)
+	val, err := r.Cache.Get(ctx, cacheKey)
 	if err == nil {
-		if jsonErr := json.Unmarshal([]byte(val), &sku); jsonErr == nil {
-			log.Printf("SKU retrieved from cache: %d", id)
+		jsonErr := json.Unmarshal([]byte(val), &sku)
+		if jsonErr == nil {
+			metrics.CacheRequests.WithLabelValues(constants.EntitySKU, metrics.CacheHit).Inc()
+			slog.DebugContext(ctx, "sku cache hit", "sku_id", id)
 			return &sku, nil
 		}
-		log.Println("Failed to unmarshal SKU from Redis: ", err)
-	} else if err != r.Redis.Nil {
-		log.Println("Redis error while fetching SKU: ", err)
+		metrics.CacheRequests.WithLabelValues(constants.EntitySKU, metrics.CacheError).Inc()
+		slog.WarnContext(ctx, "failed to unmarshal cached sku", "sku_id", id, "error", jsonErr)
+	} else if errors.Is(err, cache.ErrCacheMiss) {
+		metrics.CacheRequests.WithLabelValues(constants.EntitySKU, metrics.CacheMiss).Inc()
+	} else {
+		metrics.CacheRequests.WithLabelValues(constants.EntitySKU, metrics.CacheError).Inc()
+		slog.WarnContext(ctx, "cache error while fetching sku", "sku_id", id, "error", err)
 	}
 	sku = models.SKU{}
-	result := r.DB.First(&sku, id)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return nil, result.Error
+	result := r.DB.WithContext(ctx).First(&sku, id)
+	if result.Error != nil {
+		return nil, TranslateError(result.Error, constants.EntitySKU)
 	}
 	HubJSON, jsonErr := json.Marshal(sku)
 	if jsonErr != nil {
-		log.Println("Failed to marshal SKU for Redis", jsonErr)
+		slog.WarnContext(ctx, "failed to marshal sku for cache", "sku_id", id, "error", jsonErr)
 	} else {
-		if success, err := r.Redis.Set(ctx, cacheKey, string(HubJSON), time.Duration(constants.CacheTTLSKUs)*time.Minute); err != nil {
-			log.Print("Failed to Set SKU in Redis", err)
-		} else if success {
-			log.Print("SKU set in Redis Cache: ", cacheKey)
+		if err := r.Cache.Set(ctx, cacheKey, string(HubJSON), r.CacheTTL); err != nil {
+			slog.WarnContext(ctx, "failed to set sku in cache", "key", cacheKey, "error", err)
+		} else {
+			slog.DebugContext(ctx, "sku set in cache", "key", cacheKey)
 		}
 	}
-	return &sku, result.Error
+	return &sku, nil
+}
+
+func (r *SkuRepository) CreateSku(ctx context.Context, sku *models.SKU) error {
+	result := r.DB.WithContext(ctx).Create(sku)
+	return TranslateError(result.Error, constants.EntitySKU)
 }
 
-func (r *SkuRepository) CreateSku(sku *models.SKU) error {
-	result := r.DB.Create(sku)
-	return result.Error
+// UpdateSku applies the non-zero fields of sku if sku.Version still matches
+// the stored version, and leaves sku holding the updated row.
+func (r *SkuRepository) UpdateSku(ctx context.Context, sku *models.SKU) error {
+	err := updateVersioned(ctx, r.DB, sku, sku.ID, &sku.Version, constants.EntitySKU)
+	// Dropped on a mismatch as well, as in UpdateHub.
+	r.Cache.Del(ctx, getSKUIDCacheKey(sku.ID))
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "sku cache invalidated", "sku_id", sku.ID, "reason", "update")
+	return nil
 }
 
-func (r *SkuRepository) UpdateSku(sku *models.SKU) error {
-	result := r.DB.Model(sku).Updates(sku)
-	if result.Error == nil {
-		r.Redis.Del(context.Background(), getSKUIDCacheKey(sku.ID))
-		log.Print("SKU Cache Invalidated after Update")
+// DeleteSku soft-deletes the SKU with its inventory rows in every hub. A
+// SKU holding stock is only deleted when force is set.
+func (r *SkuRepository) DeleteSku(ctx context.Context, id uint, force bool) error {
+	if err := skuParent.softDelete(ctx, r.DB, &models.SKU{}, id, force); err != nil {
+		return err
 	}
-	return result.Error
+	r.Cache.Del(ctx, getSKUIDCacheKey(id))
+	slog.DebugContext(ctx, "sku cache invalidated", "sku_id", id, "reason", "delete")
+	return nil
 }
 
-func (r *SkuRepository) DeleteSku(id uint) error {
+func (r *SkuRepository) RestoreSku(ctx context.Context, id uint) (*models.SKU, error) {
 	var sku models.SKU
-	result := r.DB.Delete(&sku, id)
-	if result.Error == nil {
-		r.Redis.Del(context.Background(), getSKUIDCacheKey(id))
-		log.Print("SKU Cache Invalidated after Update")
-		return nil
+	if err := skuParent.restore(ctx, r.DB, &sku, id); err != nil {
+		return nil, err
 	}
-	return result.Error
+	r.Cache.Del(ctx, getSKUIDCacheKey(id))
+	return &sku, nil
+}
+
+func (r *SkuRepository) GetDeletedSkus(ctx context.Context) ([]models.SKU, error) {
+	var skus []models.SKU
+	return skus, deleted(ctx, r.DB, &skus)
 }
 
-func (r *SkuRepository) GetSkusByTenantAndSeller(tenantID string, sellerID string, skuCodes []string) ([]models.SKU, error) {
-	query := r.DB.Model(&models.SKU{})
+func (r *SkuRepository) GetSkusByTenantAndSeller(ctx context.Context, tenantID string, sellerID string, skuCodes []string) ([]models.SKU, error) {
+	query := r.DB.WithContext(ctx).Model(&models.SKU{})
 
 	if tenantID != "" {
 		query = query.Where("tenant_id = ?", tenantID)
@@ -111,22 +136,87 @@ func (r *SkuRepository) GetSkusByTenantAndSeller(tenantID string, sellerID strin
 	return skus, err
 }
 
-func (r *SkuRepository) CreateSKUsBatch(skus []models.SKU) error {
+func (r *SkuRepository) CreateSKUsBatch(ctx context.Context, skus []models.SKU) error {
 	if len(skus) == 0 {
 		return nil
 	}
-	result := r.DB.CreateInBatches(skus, 100)
-	return result.Error
+	result := r.DB.WithContext(ctx).CreateInBatches(skus, 100)
+	return TranslateError(result.Error, constants.EntitySKU)
+}
+
+// CreateSKUsBatchPartial creates every SKU whose code is not already taken
+// and reports the rest as duplicates. Results are aligned with skus.
+func (r *SkuRepository) CreateSKUsBatchPartial(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, error) {
+	results, positions, err := r.checkSKUs(ctx, skus)
+	if err != nil || len(positions) == 0 {
+		return results, err
+	}
+
+	toCreate := make([]models.SKU, len(positions))
+	for j, i := range positions {
+		toCreate[j] = skus[i]
+	}
+	if err := r.DB.WithContext(ctx).CreateInBatches(toCreate, constants.DefaultBatchSize).Error; err != nil {
+		return nil, TranslateError(err, constants.EntitySKU)
+	}
+	for j, i := range positions {
+		skus[i] = toCreate[j]
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemCreated, ID: toCreate[j].ID}
+	}
+	return results, nil
+}
+
+// CheckSKUsBatchPartial reports what CreateSKUsBatchPartial would do with skus
+// without writing: taken codes are duplicates and the rest valid.
+func (r *SkuRepository) CheckSKUsBatchPartial(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, error) {
+	results, _, err := r.checkSKUs(ctx, skus)
+	return results, err
+}
+
+// checkSKUs marks the skus whose code is taken, by a stored SKU or an
+// earlier row, as duplicates and the rest as valid. It returns the positions
+// of the valid ones.
+func (r *SkuRepository) checkSKUs(ctx context.Context, skus []models.SKU) ([]models.BatchItemResult, []int, error) {
+	results := make([]models.BatchItemResult, len(skus))
+	if len(skus) == 0 {
+		return results, nil, nil
+	}
+
+	codes := make([]string, len(skus))
+	for i, sku := range skus {
+		codes[i] = sku.Code
+	}
+
+	var existing []string
+	if err := r.DB.WithContext(ctx).Model(&models.SKU{}).Where("code IN ?", codes).Pluck("code", &existing).Error; err != nil {
+		return nil, nil, TranslateError(err, constants.EntitySKU)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, code := range existing {
+		taken[code] = true
+	}
+
+	var positions []int
+	for i, sku := range skus {
+		if taken[sku.Code] {
+			results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemDuplicate, Reason: constants.ErrRecordExists}
+			continue
+		}
+		taken[sku.Code] = true
+		results[i] = models.BatchItemResult{Index: i, Status: models.BatchItemValid}
+		positions = append(positions, i)
+	}
+	return results, positions, nil
 }
 
-func (r *SkuRepository) GetSKUsByIDs(ids []uint) ([]models.SKU, error) {
+func (r *SkuRepository) GetSKUsByIDs(ctx context.Context, ids []uint) ([]models.SKU, error) {
 	var skus []models.SKU
-	result := r.DB.Where("id IN (?)", ids).Find(&skus)
+	result := r.DB.WithContext(ctx).Where("id IN (?)", ids).Find(&skus)
 	return skus, result.Error
 }
 
-func (r *SkuRepository) GetSKUsByCodes(codes []string) ([]models.SKU, error) {
+func (r *SkuRepository) GetSKUsByCodes(ctx context.Context, codes []string) ([]models.SKU, error) {
 	var skus []models.SKU
-	result := r.DB.Where("code IN (?)", codes).Find(&skus)
+	result := r.DB.WithContext(ctx).Where("code IN (?)", codes).Find(&skus)
 	return skus, result.Error
 }
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+)
+
+// SnapshotFilter narrows the stock reports to a hub and/or SKU; zero fields
+// match everything.
+type SnapshotFilter struct {
+	HubID uint
+	SKUID uint
+}
+
+type SnapshotRepository struct {
+	DB *gorm.DB
+}
+
+func NewSnapshotRepository(db *gorm.DB) *SnapshotRepository {
+	return &SnapshotRepository{DB: db}
+}
+
+// TakeSnapshot copies every inventory row with its SKU price into the
+// snapshot of date in one statement and returns the number of rows. Taking
+// the same date again overwrites it, so concurrent or repeated runs are safe.
+func (r *SnapshotRepository) TakeSnapshot(ctx context.Context, date time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).Exec(`
+		INSERT INTO inventory_snapshots (created_at, snapshot_date, hub_id, sku_id, quantity, unit_price)
+		SELECT now(), ?::date, inventories.hub_id, inventories.sku_id, inventories.quantity, skus.price
+		FROM inventories
+		JOIN skus ON skus.id = inventories.sku_id
+		WHERE inventories.deleted_at IS NULL
+		ON CONFLICT (snapshot_date, hub_id, sku_id) DO UPDATE
+		SET created_at = EXCLUDED.created_at, quantity = EXCLUDED.quantity, unit_price = EXCLUDED.unit_price`,
+		date.Format(time.DateOnly))
+	return result.RowsAffected, result.Error
+}
+
+// HasSnapshot reports whether a snapshot of date has been taken.
+func (r *SnapshotRepository) HasSnapshot(ctx context.Context, date time.Time) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.InventorySnapshot{}).
+		Where("snapshot_date = ?", date.Format(time.DateOnly)).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// GetStockAsOf values stock from the latest snapshot on or before date.
+func (r *SnapshotRepository) GetStockAsOf(ctx context.Context, date time.Time, filter SnapshotFilter) (*models.StockReport, error) {
+	db := r.DB.WithContext(ctx)
+	snapshotDate, err := latestSnapshotDate(db, date)
+	if err != nil || snapshotDate == nil {
+		return models.NewStockReport(date, nil, nil), err
+	}
+
+	var snapshots []models.InventorySnapshot
+	err = snapshotQuery(db, snapshotDate, filter).Order("hub_id, sku_id").Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.NewStockReport(date, snapshotDate, snapshots), nil
+}
+
+// GetStockChange compares the latest snapshots on or before from and to and
+// returns the pairs whose quantity differs.
+func (r *SnapshotRepository) GetStockChange(ctx context.Context, from, to time.Time, filter SnapshotFilter) (*models.StockChangeReport, error) {
+	db := r.DB.WithContext(ctx)
+	report := &models.StockChangeReport{From: from, To: to, Changes: []models.StockChange{}}
+	var err error
+	if report.FromSnapshotDate, err = latestSnapshotDate(db, from); err != nil {
+		return nil, err
+	}
+	if report.ToSnapshotDate, err = latestSnapshotDate(db, to); err != nil || report.ToSnapshotDate == nil {
+		return report, err
+	}
+
+	fromRows := snapshotQuery(db, report.FromSnapshotDate, filter).Select("hub_id, sku_id, quantity")
+	toRows := snapshotQuery(db, report.ToSnapshotDate, filter).Select("hub_id, sku_id, quantity")
+	err = db.Table("(?) AS f", fromRows).
+		Joins("FULL JOIN (?) AS t ON t.hub_id = f.hub_id AND t.sku_id = f.sku_id", toRows).
+		Select(`COALESCE(f.hub_id, t.hub_id) AS hub_id, COALESCE(f.sku_id, t.sku_id) AS sku_id,
+			COALESCE(f.quantity, 0) AS from_quantity, COALESCE(t.quantity, 0) AS to_quantity,
+			COALESCE(t.quantity, 0) - COALESCE(f.quantity, 0) AS change`).
+		Where("COALESCE(f.quantity, 0) <> COALESCE(t.quantity, 0)").
+		Order("hub_id, sku_id").
+		Scan(&report.Changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func latestSnapshotDate(db *gorm.DB, date time.Time) (*time.Time, error) {
+	var latest sql.NullTime
+	err := db.Model(&models.InventorySnapshot{}).
+		Select("MAX(snapshot_date)").
+		Where("snapshot_date <= ?", date.Format(time.DateOnly)).
+		Scan(&latest).Error
+	if err != nil || !latest.Valid {
+		return nil, err
+	}
+	return &latest.Time, nil
+}
+
+// snapshotQuery selects the rows of one snapshot. A nil date selects none, so
+// a missing snapshot reads as zero stock.
+func snapshotQuery(db *gorm.DB, date *time.Time, filter SnapshotFilter) *gorm.DB {
+	query := db.Model(&models.InventorySnapshot{})
+	if date == nil {
+		return query.Where("FALSE")
+	}
+	query = query.Where("snapshot_date = ?", date.Format(time.DateOnly))
+	if filter.HubID != 0 {
+		query = query.Where("hub_id = ?", filter.HubID)
+	}
+	if filter.SKUID != 0 {
+		query = query.Where("sku_id = ?", filter.SKUID)
+	}
+	return query
+}
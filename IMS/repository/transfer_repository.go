@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/events"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransferFilter narrows ListTransfers; zero fields match everything. HubID
+// matches either end of a transfer. Limit caps the transfers returned, most
+// recent first, and defaults to constants.DefaultHistoryLimit.
+type TransferFilter struct {
+	Status models.TransferStatus
+	HubID  uint
+	SKUID  uint
+	Limit  int
+}
+
+type TransferRepository struct {
+	DB     *gorm.DB
+	Alerts events.AlertPublisher
+}
+
+func NewTransferRepository(db *gorm.DB, alerts events.AlertPublisher) *TransferRepository {
+	return &TransferRepository{DB: db, Alerts: alerts}
+}
+
+// CreateTransfer dispatches transfer: the source hub loses the stock and the
+// transfer is left in transit, both in one transaction. With receive set the
+// destination is credited in the same transaction as well.
+func (r *TransferRepository) CreateTransfer(ctx context.Context, transfer *models.InventoryTransfer, receive bool) error {
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hubIDs := []uint{transfer.SourceHubID, transfer.DestinationHubID}
+		if err := requireLiveParents(tx, hubIDs, []uint{transfer.SKUID}); err != nil {
+			return err
+		}
+
+		source, err := lockTransferStock(tx, transfer, receive)
+		if err != nil {
+			return err
+		}
+		if source.Quantity < transfer.Quantity {
+			return NewInsufficientStockError(source.Quantity, transfer.Quantity)
+		}
+		source.Quantity -= transfer.Quantity
+		if err := tx.Model(&source).Update("quantity", source.Quantity).Error; err != nil {
+			return TranslateError(err, constants.EntityInventory)
+		}
+
+		now := time.Now()
+		transfer.ID = 0
+		transfer.Status = models.TransferInTransit
+		transfer.DispatchedAt = &now
+		transfer.ReceivedAt, transfer.CancelledAt = nil, nil
+		if err := tx.Create(transfer).Error; err != nil {
+			return TranslateError(err, constants.EntityTransfer)
+		}
+		err = recordMovement(tx, transfer.SourceHubID, transfer, -transfer.Quantity, source.Quantity, models.MovementTransferOut)
+		if err != nil {
+			return err
+		}
+		keys := [][2]uint{{transfer.SourceHubID, transfer.SKUID}}
+		if receive {
+			if err := settleTransfer(tx, transfer, models.TransferReceived); err != nil {
+				return err
+			}
+			keys = append(keys, [2]uint{transfer.DestinationHubID, transfer.SKUID})
+		}
+		opened, err = evaluateStock(tx, keys)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	publishAlerts(ctx, r.Alerts, opened)
+	return nil
+}
+
+// ReceiveTransfer credits the destination hub with an in-transit transfer.
+func (r *TransferRepository) ReceiveTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error) {
+	return r.settle(ctx, id, models.TransferReceived)
+}
+
+// CancelTransfer returns the stock of an in-transit transfer to its source.
+func (r *TransferRepository) CancelTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error) {
+	return r.settle(ctx, id, models.TransferCancelled)
+}
+
+func (r *TransferRepository) GetTransfer(ctx context.Context, id uint) (*models.InventoryTransfer, error) {
+	var transfer models.InventoryTransfer
+	if err := r.DB.WithContext(ctx).First(&transfer, id).Error; err != nil {
+		return nil, TranslateError(err, constants.EntityTransfer)
+	}
+	return &transfer, nil
+}
+
+func (r *TransferRepository) ListTransfers(ctx context.Context, filter TransferFilter) ([]models.InventoryTransfer, error) {
+	query := r.DB.WithContext(ctx).Model(&models.InventoryTransfer{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.HubID != 0 {
+		query = query.Where("source_hub_id = ? OR destination_hub_id = ?", filter.HubID, filter.HubID)
+	}
+	if filter.SKUID != 0 {
+		query = query.Where("sku_id = ?", filter.SKUID)
+	}
+
+	var transfers []models.InventoryTransfer
+	err := query.Order("id DESC").Limit(HistoryLimit(filter.Limit)).Find(&transfers).Error
+	return transfers, err
+}
+
+func (r *TransferRepository) settle(ctx context.Context, id uint, status models.TransferStatus) (*models.InventoryTransfer, error) {
+	var transfer models.InventoryTransfer
+	var opened []models.InventoryAlert
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, id).Error; err != nil {
+			return TranslateError(err, constants.EntityTransfer)
+		}
+		if transfer.Status != models.TransferInTransit {
+			return NewTransferStateError(transfer.Status)
+		}
+		if err := settleTransfer(tx, &transfer, status); err != nil {
+			return err
+		}
+		hubID := transfer.DestinationHubID
+		if status == models.TransferCancelled {
+			hubID = transfer.SourceHubID
+		}
+		var err error
+		opened, err = evaluateStock(tx, [][2]uint{{hubID, transfer.SKUID}})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishAlerts(ctx, r.Alerts, opened)
+	return &transfer, nil
+}
+
+// settleTransfer closes an in-transit transfer, crediting the destination on
+// receipt or the source on cancellation, and records that leg. The credited
+// hub and the SKU must still be live, as they may have been force-deleted
+// while the stock was in transit.
+func settleTransfer(tx *gorm.DB, transfer *models.InventoryTransfer, status models.TransferStatus) error {
+	hubID, reason := transfer.DestinationHubID, models.MovementTransferIn
+	if status == models.TransferCancelled {
+		hubID, reason = transfer.SourceHubID, models.MovementTransferReturn
+	}
+	if err := requireLiveParents(tx, []uint{hubID}, []uint{transfer.SKUID}); err != nil {
+		return err
+	}
+
+	quantity, err := addStock(tx, hubID, transfer.SKUID, transfer.Quantity)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	transfer.Status = status
+	if status == models.TransferCancelled {
+		transfer.CancelledAt = &now
+	} else {
+		transfer.ReceivedAt = &now
+	}
+	if err := tx.Model(transfer).Select("status", "received_at", "cancelled_at").Updates(transfer).Error; err != nil {
+		return TranslateError(err, constants.EntityTransfer)
+	}
+	return recordMovement(tx, hubID, transfer, transfer.Quantity, quantity, reason)
+}
+
+// lockTransferStock locks the source row of transfer and, when it is received
+// at once, the destination row too, creating it if needed. Rows are locked in
+// hub ID order so transfers running both ways between two hubs queue instead
+// of deadlocking. A missing or deleted source counts as holding no stock.
+func lockTransferStock(tx *gorm.DB, transfer *models.InventoryTransfer, receive bool) (models.Inventory, error) {
+	hubIDs := []uint{transfer.SourceHubID}
+	if receive {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.Inventory{HubID: transfer.DestinationHubID, SKUID: transfer.SKUID}).Error
+		if err != nil {
+			return models.Inventory{}, TranslateError(err, constants.EntityInventory)
+		}
+		hubIDs = append(hubIDs, transfer.DestinationHubID)
+	}
+
+	var rows []models.Inventory
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sku_id = ? AND hub_id IN ?", transfer.SKUID, hubIDs).Order("hub_id").Find(&rows).Error
+	if err != nil {
+		return models.Inventory{}, TranslateError(err, constants.EntityInventory)
+	}
+	for _, row := range rows {
+		if row.HubID == transfer.SourceHubID && !row.DeletedAt.Valid {
+			return row, nil
+		}
+	}
+	return models.Inventory{}, NewInsufficientStockError(0, transfer.Quantity)
+}
+
+// addStock credits a hub/SKU row, creating or restoring it if needed, and
+// returns the new quantity. Callers check the hub and SKU are live first.
+func addStock(tx *gorm.DB, hubID, skuID uint, quantity int) (int, error) {
+	err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"quantity":   gorm.Expr("inventories.quantity + ?", quantity),
+			"updated_at": time.Now(),
+			"deleted_at": nil,
+		}),
+	}).Create(&models.Inventory{HubID: hubID, SKUID: skuID, Quantity: quantity}).Error
+	if err != nil {
+		return 0, TranslateError(err, constants.EntityInventory)
+	}
+
+	var inventory models.Inventory
+	if err := tx.Where("hub_id = ? AND sku_id = ?", hubID, skuID).First(&inventory).Error; err != nil {
+		return 0, TranslateError(err, constants.EntityInventory)
+	}
+	return inventory.Quantity, nil
+}
+
+func recordMovement(tx *gorm.DB, hubID uint, transfer *models.InventoryTransfer, quantity, after int, reason models.MovementReason) error {
+	movement := models.InventoryMovement{
+		HubID:         hubID,
+		SKUID:         transfer.SKUID,
+		Quantity:      quantity,
+		QuantityAfter: after,
+		Reason:        reason,
+		TransferID:    &transfer.ID,
+	}
+	return TranslateError(tx.Create(&movement).Error, constants.EntityInventory)
+}
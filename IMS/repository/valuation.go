@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+// ValuationFilter narrows a valuation report; zero fields match everything.
+type ValuationFilter struct {
+	HubID    uint
+	Category string
+	TenantID string
+	SellerID string
+}
+
+// valuationColumns maps each dimension to the expressions it groups by and
+// the row columns they fill.
+var valuationColumns = map[models.ValuationDimension][][2]string{
+	models.ValuationByHub:      {{"hubs.id", "hub_id"}, {"hubs.name", "hub_name"}},
+	models.ValuationByCategory: {{"COALESCE(skus.category, '')", "category"}},
+	models.ValuationByTenant:   {{"skus.tenant_id", "tenant_id"}},
+	models.ValuationBySeller:   {{"skus.seller_id", "seller_id"}},
+}
+
+// GetValuation values current stock at SKU prices, grouped by the given
+// dimensions in one query.
+func (r *InventoryRepository) GetValuation(ctx context.Context, groupBy []models.ValuationDimension, filter ValuationFilter) ([]models.ValuationRow, error) {
+	columns := []string{
+		"SUM(inventories.quantity) AS quantity",
+		"COALESCE(SUM(inventories.quantity) FILTER (WHERE skus.price IS NULL), 0) AS unpriced_quantity",
+		"COUNT(DISTINCT skus.id) FILTER (WHERE skus.price IS NULL) AS unpriced_skus",
+		"SUM(inventories.quantity * skus.price) AS value",
+	}
+	var groups []string
+	for _, dimension := range groupBy {
+		for _, column := range valuationColumns[dimension] {
+			columns = append(columns, column[0]+" AS "+column[1])
+			groups = append(groups, column[0])
+		}
+	}
+
+	query := r.DB.WithContext(ctx).Table("inventories").
+		Select(strings.Join(columns, ", ")).
+		Joins("JOIN hubs ON hubs.id = inventories.hub_id AND hubs.deleted_at IS NULL").
+		Joins("JOIN skus ON skus.id = inventories.sku_id AND skus.deleted_at IS NULL").
+		Where("inventories.deleted_at IS NULL AND inventories.quantity > 0")
+	if filter.HubID != 0 {
+		query = query.Where("hubs.id = ?", filter.HubID)
+	}
+	if filter.Category != "" {
+		query = query.Where("skus.category = ?", filter.Category)
+	}
+	if filter.TenantID != "" {
+		query = query.Where("skus.tenant_id = ?", filter.TenantID)
+	}
+	if filter.SellerID != "" {
+		query = query.Where("skus.seller_id = ?", filter.SellerID)
+	}
+
+	if len(groups) > 0 {
+		grouping := strings.Join(groups, ", ")
+		query = query.Group(grouping).Order(grouping)
+	}
+
+	var rows []models.ValuationRow
+	err := query.Scan(&rows).Error
+	return rows, err
+}
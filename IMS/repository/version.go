@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// updateVersioned applies the non-zero fields of model only if row id is
+// still at *version, the version the caller read. On success *version is
+// bumped and model is refreshed from the stored row; a stale version yields
+// a VERSION_MISMATCH error carrying the current one.
+func updateVersioned(ctx context.Context, db *gorm.DB, model interface{}, id uint, version *uint, entity string) error {
+	expected := *version
+	if expected == 0 {
+		return NewPreconditionRequiredError()
+	}
+
+	*version = expected + 1
+	result := db.WithContext(ctx).Model(model).Clauses(clause.Returning{}).
+		Where("version = ?", expected).Updates(model)
+	if result.Error == nil && result.RowsAffected == 1 {
+		return nil
+	}
+	*version = expected
+	if result.Error != nil {
+		return TranslateError(result.Error, entity)
+	}
+
+	var current []uint
+	if err := db.WithContext(ctx).Model(model).Where("id = ?", id).Pluck("version", &current).Error; err != nil {
+		return TranslateError(err, entity)
+	}
+	if len(current) == 0 {
+		return NewNotFoundError(entity, nil)
+	}
+	return NewVersionMismatchError(expected, current[0])
+}
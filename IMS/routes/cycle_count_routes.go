@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Trishank-Omniful/Onboarding-Task/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCycleCountRoutes(router *gin.RouterGroup, ctrl *controllers.CycleCountController) {
+	router.POST("/inventory/cycle-counts", ctrl.CreateCycleCount)
+	router.GET("/inventory/cycle-counts", ctrl.ListCycleCounts)
+	router.GET("/inventory/cycle-counts/:id", ctrl.GetCycleCount)
+	router.POST("/inventory/cycle-counts/:id/counts", ctrl.SubmitCounts)
+	router.GET("/inventory/cycle-counts/:id/variance", ctrl.GetVariance)
+	router.POST("/inventory/cycle-counts/:id/approve", ctrl.ApproveCycleCount)
+	router.POST("/inventory/cycle-counts/:id/cancel", ctrl.CancelCycleCount)
+}
@@ -9,11 +9,15 @@ func RegisterHubRoutes(router *gin.RouterGroup, controller *controllers.HubContr
 	hubGroup := router.Group("/hub")
 	{
 		hubGroup.GET("", controller.GetAllHubs)
+		hubGroup.GET("/deleted", controller.GetDeletedHubs)
+		hubGroup.GET("/export", controller.ExportHubs)
 		hubGroup.GET("/:id", controller.GetHubById)
 		hubGroup.POST("", controller.CreateHub)
 		hubGroup.PUT("/:id", controller.UpdateHub)
 		hubGroup.DELETE("/:id", controller.DeleteHub)
+		hubGroup.POST("/:id/restore", controller.RestoreHub)
 		hubGroup.POST("/batch", controller.CreateHubsBatch)
 		hubGroup.POST("/batch/ids", controller.GetHubsByIDs)
+		hubGroup.POST("/import", controller.ImportHubs)
 	}
 }
@@ -9,7 +9,16 @@ func RegisterInventoryRoutes(router *gin.RouterGroup, ctrl *controllers.Inventor
 	router.POST("/inventory", ctrl.UpsertInventory)
 	router.GET("/inventory", ctrl.GetInventory)
 	router.POST("/inventory/batch", ctrl.UpsertInventoryBatch)
+	router.POST("/inventory/import", ctrl.ImportInventory)
+	router.GET("/inventory/export", ctrl.ExportInventory)
 	router.POST("/inventory/batch/hub-skus", ctrl.GetInventoriesByHubAndSKUs)
 	router.POST("/inventory/atomic/reduce", ctrl.AtomicReduceInventory)
 	router.POST("/inventory/check-availability", ctrl.CheckInventoryAvailability)
+	router.POST("/inventory/check-availability/batch", ctrl.CheckAvailabilityBatch)
+	router.GET("/inventory/availability", ctrl.GetAvailability)
+	router.POST("/inventory/availability", ctrl.GetAvailabilityBatch)
+	router.GET("/inventory/history", ctrl.GetInventoryHistory)
+	router.PUT("/inventory/thresholds", ctrl.SetStockThresholds)
+	router.GET("/inventory/alerts", ctrl.GetInventoryAlerts)
+	router.GET("/inventory/valuation", ctrl.GetValuation)
 }
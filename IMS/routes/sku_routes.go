@@ -9,13 +9,17 @@ func RegisterSkuRoutes(router *gin.RouterGroup, controller *controllers.SkuContr
 	skuGroup := router.Group("/sku")
 	{
 		skuGroup.GET("", controller.GetAllSkus)
+		skuGroup.GET("/deleted", controller.GetDeletedSkus)
+		skuGroup.GET("/export", controller.ExportSkus)
 		skuGroup.GET("/:id", controller.GetSkuById)
 		skuGroup.POST("", controller.CreateSku)
 		skuGroup.PUT("/:id", controller.UpdateSku)
 		skuGroup.DELETE("/:id", controller.DeleteSku)
+		skuGroup.POST("/:id/restore", controller.RestoreSku)
 		skuGroup.POST("/filter", controller.GetSkusByTenantAndSeller)
 		skuGroup.POST("/batch", controller.CreateSKUsBatch)
 		skuGroup.POST("/batch/ids", controller.GetSKUsByIDs)
 		skuGroup.POST("/batch/codes", controller.GetSKUsByCodes)
+		skuGroup.POST("/import", controller.ImportSkus)
 	}
 }
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/Trishank-Omniful/Onboarding-Task/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSnapshotRoutes(router *gin.RouterGroup, ctrl *controllers.SnapshotController) {
+	router.GET("/inventory/snapshots", ctrl.GetStockAsOf)
+	router.GET("/inventory/snapshots/changes", ctrl.GetStockChange)
+}
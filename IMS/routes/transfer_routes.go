@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/Trishank-Omniful/Onboarding-Task/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTransferRoutes(router *gin.RouterGroup, ctrl *controllers.TransferController) {
+	router.POST("/inventory/transfers", ctrl.CreateTransfer)
+	router.GET("/inventory/transfers", ctrl.ListTransfers)
+	router.GET("/inventory/transfers/:id", ctrl.GetTransfer)
+	router.POST("/inventory/transfers/:id/receive", ctrl.ReceiveTransfer)
+	router.POST("/inventory/transfers/:id/cancel", ctrl.CancelTransfer)
+}
@@ -0,0 +1,181 @@
+package seeder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const maxSeedBatchSize = 5000
+
+// Fixture describes the dataset to generate. Sizes can be overridden from the
+// command line; the vocabularies only shape how realistic the rows look.
+type Fixture struct {
+	Seed             int64             `json:"seed"`
+	Tenants          int               `json:"tenants"`
+	SellersPerTenant int               `json:"sellers_per_tenant"`
+	Hubs             int               `json:"hubs"`
+	SKUs             int               `json:"skus"`
+	Inventory        int               `json:"inventory"`
+	MinQuantity      int               `json:"min_quantity"`
+	MaxQuantity      int               `json:"max_quantity"`
+	NullPriceRatio   float64           `json:"null_price_ratio"`
+	BatchSize        int               `json:"batch_size"`
+	Locations        []Location        `json:"locations"`
+	Categories       []Category        `json:"categories"`
+	Brands           []string          `json:"brands"`
+	FirstNames       []string          `json:"first_names"`
+	LastNames        []string          `json:"last_names"`
+	Streets          []string          `json:"streets"`
+	HubRecords       []HubRecord       `json:"hub_records"`
+	SKURecords       []SKURecord       `json:"sku_records"`
+	InventoryRecords []InventoryRecord `json:"inventory_records"`
+}
+
+type Location struct {
+	Country      string   `json:"country"`
+	State        string   `json:"state"`
+	Cities       []string `json:"cities"`
+	PostalPrefix string   `json:"postal_prefix"`
+}
+
+type Category struct {
+	Name     string   `json:"name"`
+	Code     string   `json:"code"`
+	MinPrice float64  `json:"min_price"`
+	MaxPrice float64  `json:"max_price"`
+	Products []string `json:"products"`
+}
+
+// HubRecord, SKURecord and InventoryRecord are inserted verbatim before any
+// generated rows, so fixtures can pin the handful of rows a test relies on.
+type HubRecord struct {
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	Country      string `json:"country"`
+	PostalCode   string `json:"postal_code"`
+	ContactName  string `json:"contact_name"`
+	ContactEmail string `json:"contact_email"`
+}
+
+type SKURecord struct {
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	TenantId    string   `json:"tenant_id"`
+	SellerId    string   `json:"seller_id"`
+	Category    string   `json:"category"`
+	Price       *float64 `json:"price"`
+}
+
+type InventoryRecord struct {
+	HubName  string `json:"hub_name"`
+	SKUCode  string `json:"sku_code"`
+	Quantity int    `json:"quantity"`
+}
+
+// LoadFixture reads a YAML or JSON fixture on top of DefaultFixture, so a
+// file only needs the keys it wants to change.
+func LoadFixture(path string) (Fixture, error) {
+	fixture := DefaultFixture()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fixture, fmt.Errorf("read fixture: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// Decode through JSON so both formats share the json tags above.
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fixture, fmt.Errorf("parse fixture %s: %w", path, err)
+		}
+		if data, err = json.Marshal(raw); err != nil {
+			return fixture, fmt.Errorf("parse fixture %s: %w", path, err)
+		}
+	case ".json":
+	default:
+		return fixture, fmt.Errorf("unsupported fixture format %q, use .yaml or .json", filepath.Ext(path))
+	}
+
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return fixture, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+func (f Fixture) Validate() error {
+	switch {
+	case f.Tenants <= 0 || f.SellersPerTenant <= 0:
+		return fmt.Errorf("tenants and sellers_per_tenant must be positive")
+	case f.Hubs < 0 || f.SKUs < 0 || f.Inventory < 0:
+		return fmt.Errorf("hubs, skus and inventory cannot be negative")
+	case f.Inventory > (f.Hubs+len(f.HubRecords))*(f.SKUs+len(f.SKURecords)):
+		return fmt.Errorf("inventory (%d) cannot exceed hubs x skus", f.Inventory)
+	case f.MinQuantity < 0 || f.MaxQuantity < f.MinQuantity:
+		return fmt.Errorf("quantity range [%d, %d] is invalid", f.MinQuantity, f.MaxQuantity)
+	case f.NullPriceRatio < 0 || f.NullPriceRatio > 1:
+		return fmt.Errorf("null_price_ratio must be between 0 and 1")
+	case f.BatchSize <= 0 || f.BatchSize > maxSeedBatchSize:
+		return fmt.Errorf("batch_size must be between 1 and %d", maxSeedBatchSize)
+	case f.Hubs > 0 && len(f.Locations) == 0:
+		return fmt.Errorf("at least one location is required to generate hubs")
+	case f.SKUs > 0 && len(f.Categories) == 0:
+		return fmt.Errorf("at least one category is required to generate skus")
+	case len(f.Brands) == 0 || len(f.FirstNames) == 0 || len(f.LastNames) == 0 || len(f.Streets) == 0:
+		return fmt.Errorf("brands, first_names, last_names and streets cannot be empty")
+	}
+	for _, location := range f.Locations {
+		if len(location.Cities) == 0 {
+			return fmt.Errorf("location %s has no cities", location.State)
+		}
+	}
+	for _, category := range f.Categories {
+		if len(category.Products) == 0 || category.MaxPrice < category.MinPrice {
+			return fmt.Errorf("category %s needs products and a valid price range", category.Name)
+		}
+	}
+	return nil
+}
+
+func DefaultFixture() Fixture {
+	return Fixture{
+		Seed:             1,
+		Tenants:          5,
+		SellersPerTenant: 4,
+		Hubs:             100,
+		SKUs:             1000,
+		Inventory:        10000,
+		MinQuantity:      0,
+		MaxQuantity:      500,
+		NullPriceRatio:   0.02,
+		BatchSize:        1000,
+		Locations: []Location{
+			{Country: "India", State: "Maharashtra", Cities: []string{"Mumbai", "Pune", "Nagpur", "Nashik"}, PostalPrefix: "4"},
+			{Country: "India", State: "Karnataka", Cities: []string{"Bengaluru", "Mysuru", "Mangaluru"}, PostalPrefix: "56"},
+			{Country: "India", State: "Delhi", Cities: []string{"New Delhi"}, PostalPrefix: "110"},
+			{Country: "India", State: "Tamil Nadu", Cities: []string{"Chennai", "Coimbatore", "Madurai"}, PostalPrefix: "6"},
+			{Country: "India", State: "West Bengal", Cities: []string{"Kolkata", "Howrah"}, PostalPrefix: "7"},
+			{Country: "India", State: "Telangana", Cities: []string{"Hyderabad", "Warangal"}, PostalPrefix: "50"},
+			{Country: "India", State: "Gujarat", Cities: []string{"Ahmedabad", "Surat", "Vadodara"}, PostalPrefix: "39"},
+		},
+		Categories: []Category{
+			{Name: "Electronics", Code: "ELEC", MinPrice: 499, MaxPrice: 49999, Products: []string{"Wireless Earbuds", "Power Bank", "Smart Watch", "Bluetooth Speaker", "USB-C Charger"}},
+			{Name: "Apparel", Code: "APRL", MinPrice: 299, MaxPrice: 4999, Products: []string{"Cotton T-Shirt", "Denim Jeans", "Running Shoes", "Hooded Sweatshirt", "Linen Shirt"}},
+			{Name: "Grocery", Code: "GROC", MinPrice: 20, MaxPrice: 999, Products: []string{"Basmati Rice 5kg", "Green Tea 100g", "Olive Oil 1L", "Almonds 500g", "Instant Coffee 200g"}},
+			{Name: "Home", Code: "HOME", MinPrice: 199, MaxPrice: 14999, Products: []string{"Bedsheet Set", "Cookware Set", "Table Lamp", "Storage Box", "Wall Clock"}},
+			{Name: "Beauty", Code: "BEAU", MinPrice: 99, MaxPrice: 2999, Products: []string{"Face Wash", "Sunscreen SPF50", "Hair Serum", "Lip Balm", "Body Lotion"}},
+		},
+		Brands:     []string{"Acme", "Zenith", "Nova", "Orbit", "Lumen", "Kairo", "Veda", "Astra"},
+		FirstNames: []string{"Aarav", "Diya", "Ishaan", "Meera", "Kabir", "Ananya", "Rohan", "Saanvi", "Vikram", "Priya"},
+		LastNames:  []string{"Sharma", "Iyer", "Reddy", "Patel", "Gupta", "Nair", "Banerjee", "Singh", "Rao", "Mehta"},
+		Streets:    []string{"MG Road", "Station Road", "Industrial Estate", "Ring Road", "Link Road", "Logistics Park"},
+	}
+}
@@ -0,0 +1,93 @@
+package seeder
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+var hubKinds = []string{"Fulfilment Center", "Warehouse", "Dark Store", "Sort Center"}
+
+type generator struct {
+	fixture Fixture
+	rng     *rand.Rand
+}
+
+func newGenerator(fixture Fixture) *generator {
+	return &generator{fixture: fixture, rng: rand.New(rand.NewSource(fixture.Seed))}
+}
+
+func (g *generator) hub(i int) models.Hub {
+	location := g.fixture.Locations[i%len(g.fixture.Locations)]
+	city := location.Cities[g.rng.Intn(len(location.Cities))]
+	first, last := g.pick(g.fixture.FirstNames), g.pick(g.fixture.LastNames)
+
+	return models.Hub{
+		Name:         fmt.Sprintf("%s %s %04d", city, hubKinds[i%len(hubKinds)], i+1),
+		Address:      fmt.Sprintf("%d, %s, %s", 1+g.rng.Intn(300), g.pick(g.fixture.Streets), city),
+		City:         city,
+		State:        location.State,
+		Country:      location.Country,
+		PostalCode:   postalCode(location.PostalPrefix, g.rng),
+		ContactName:  first + " " + last,
+		ContactEmail: fmt.Sprintf("%s.%s.hub%04d@example.com", strings.ToLower(first), strings.ToLower(last), i+1),
+	}
+}
+
+// sku spreads SKUs round-robin across every tenant's sellers so each tenant
+// ends up with a comparable catalog.
+func (g *generator) sku(i int) models.SKU {
+	sellers := g.fixture.Tenants * g.fixture.SellersPerTenant
+	tenant := (i % sellers) / g.fixture.SellersPerTenant
+	seller := i % sellers
+	category := g.fixture.Categories[g.rng.Intn(len(g.fixture.Categories))]
+	brand, product := g.pick(g.fixture.Brands), g.pick(category.Products)
+
+	sku := models.SKU{
+		Code:        fmt.Sprintf("T%02d-%s-%07d", tenant+1, category.Code, i+1),
+		Name:        brand + " " + product,
+		Description: fmt.Sprintf("%s %s from the %s range", brand, product, category.Name),
+		TenantId:    fmt.Sprintf("tenant_%02d", tenant+1),
+		SellerId:    fmt.Sprintf("tenant_%02d_seller_%02d", tenant+1, seller%g.fixture.SellersPerTenant+1),
+		Category:    category.Name,
+	}
+	if g.rng.Float64() >= g.fixture.NullPriceRatio {
+		price := category.MinPrice + g.rng.Float64()*(category.MaxPrice-category.MinPrice)
+		sku.Price = models.ToNullFloat64(math.Round(price*100) / 100)
+	}
+	return sku
+}
+
+func (g *generator) quantity() int {
+	return g.fixture.MinQuantity + g.rng.Intn(g.fixture.MaxQuantity-g.fixture.MinQuantity+1)
+}
+
+// samplePairs picks exactly want distinct (hub, sku) index pairs out of a
+// hubs x skus matrix, in row order, without materialising the matrix
+// (Knuth's selection sampling). Returning false from visit stops early.
+func (g *generator) samplePairs(hubs, skus, want int, visit func(hub, sku int) bool) {
+	total := hubs * skus
+	for i := 0; i < total && want > 0; i++ {
+		if g.rng.Intn(total-i) < want {
+			want--
+			if !visit(i/skus, i%skus) {
+				return
+			}
+		}
+	}
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rng.Intn(len(values))]
+}
+
+func postalCode(prefix string, rng *rand.Rand) string {
+	code := prefix
+	for len(code) < 6 {
+		code += string(rune('0' + rng.Intn(10)))
+	}
+	return code
+}
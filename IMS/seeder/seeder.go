@@ -0,0 +1,274 @@
+package seeder
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/constants"
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+	"github.com/Trishank-Omniful/Onboarding-Task/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+type Seeder struct {
+	DB      *gorm.DB
+	Fixture Fixture
+}
+
+type Summary struct {
+	Hubs        int
+	SKUs        int
+	Inventories int
+	Duration    time.Duration
+}
+
+// Catalog is the subset of the seeded data other services need to build
+// on top of it, e.g. OMS generating orders against real hubs and SKUs.
+type Catalog struct {
+	Hubs []CatalogHub `json:"hubs"`
+	SKUs []CatalogSKU `json:"skus"`
+}
+
+type CatalogHub struct {
+	Name       string `json:"name"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postal_code"`
+}
+
+type CatalogSKU struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	TenantId string   `json:"tenant_id"`
+	SellerId string   `json:"seller_id"`
+	Price    *float64 `json:"price"`
+}
+
+func NewSeeder(db *gorm.DB, fixture Fixture) *Seeder {
+	return &Seeder{
+		DB: db.Session(&gorm.Session{
+			SkipDefaultTransaction: true,
+			Logger:                 db.Logger.LogMode(logger.Warn),
+		}),
+		Fixture: fixture,
+	}
+}
+
+// Reset empties the IMS tables and restarts their id sequences.
+func (s *Seeder) Reset() error {
+	log.Println("Truncating hubs, skus and inventories...")
+	return s.DB.Exec("TRUNCATE TABLE inventories, skus, hubs RESTART IDENTITY CASCADE").Error
+}
+
+func (s *Seeder) Run() (Summary, *Catalog, error) {
+	if err := s.Fixture.Validate(); err != nil {
+		return Summary{}, nil, err
+	}
+	start := time.Now()
+	gen := newGenerator(s.Fixture)
+	catalog := &Catalog{}
+
+	hubIDs, err := s.seedHubs(gen, catalog)
+	if err != nil {
+		return Summary{}, nil, err
+	}
+	skuIDs, err := s.seedSKUs(gen, catalog)
+	if err != nil {
+		return Summary{}, nil, err
+	}
+	inventories, err := s.seedInventory(gen, hubIDs, skuIDs, catalog)
+	if err != nil {
+		return Summary{}, nil, err
+	}
+
+	return Summary{
+		Hubs:        len(hubIDs),
+		SKUs:        len(skuIDs),
+		Inventories: inventories,
+		Duration:    time.Since(start),
+	}, catalog, nil
+}
+
+func (s *Seeder) seedHubs(gen *generator, catalog *Catalog) ([]uint, error) {
+	hubs := make([]models.Hub, 0, len(s.Fixture.HubRecords)+s.Fixture.Hubs)
+	for _, r := range s.Fixture.HubRecords {
+		hubs = append(hubs, models.Hub{
+			Name: r.Name, Address: r.Address, City: r.City, State: r.State, Country: r.Country,
+			PostalCode: r.PostalCode, ContactName: r.ContactName, ContactEmail: r.ContactEmail,
+		})
+	}
+	for i := 0; i < s.Fixture.Hubs; i++ {
+		hubs = append(hubs, gen.hub(i))
+	}
+	if len(hubs) == 0 {
+		return nil, nil
+	}
+
+	if err := s.DB.CreateInBatches(&hubs, s.Fixture.BatchSize).Error; err != nil {
+		return nil, fmt.Errorf("seed hubs: %w", repository.TranslateError(err, constants.EntityHub))
+	}
+
+	ids := make([]uint, len(hubs))
+	for i, hub := range hubs {
+		ids[i] = hub.ID
+		catalog.Hubs = append(catalog.Hubs, CatalogHub{
+			Name: hub.Name, City: hub.City, State: hub.State, Country: hub.Country, PostalCode: hub.PostalCode,
+		})
+	}
+	log.Printf("Seeded %d hubs", len(ids))
+	return ids, nil
+}
+
+func (s *Seeder) seedSKUs(gen *generator, catalog *Catalog) ([]uint, error) {
+	total := len(s.Fixture.SKURecords) + s.Fixture.SKUs
+	ids := make([]uint, 0, total)
+	batch := make([]models.SKU, 0, s.Fixture.BatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.DB.Create(&batch).Error; err != nil {
+			return fmt.Errorf("seed skus: %w", repository.TranslateError(err, constants.EntitySKU))
+		}
+		for _, sku := range batch {
+			ids = append(ids, sku.ID)
+			catalog.SKUs = append(catalog.SKUs, CatalogSKU{
+				Code: sku.Code, Name: sku.Name, TenantId: sku.TenantId, SellerId: sku.SellerId, Price: nullablePrice(sku.Price),
+			})
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for i := 0; i < total; i++ {
+		if i < len(s.Fixture.SKURecords) {
+			r := s.Fixture.SKURecords[i]
+			sku := models.SKU{
+				Code: r.Code, Name: r.Name, Description: r.Description, TenantId: r.TenantId,
+				SellerId: r.SellerId, Category: r.Category,
+			}
+			if r.Price != nil {
+				sku.Price = models.ToNullFloat64(*r.Price)
+			}
+			batch = append(batch, sku)
+		} else {
+			batch = append(batch, gen.sku(i-len(s.Fixture.SKURecords)))
+		}
+		if len(batch) == s.Fixture.BatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	log.Printf("Seeded %d skus", len(ids))
+	return ids, nil
+}
+
+// seedInventory fills a sparse hub x SKU matrix with Fixture.Inventory rows,
+// then applies the explicit records on top of it.
+func (s *Seeder) seedInventory(gen *generator, hubIDs, skuIDs []uint, catalog *Catalog) (int, error) {
+	want := s.Fixture.Inventory
+	if max := len(hubIDs) * len(skuIDs); want > max {
+		want = max
+	}
+
+	batch := make([]models.Inventory, 0, s.Fixture.BatchSize)
+	inserted, batches := 0, 0
+	var insertErr error
+
+	flush := func() bool {
+		if len(batch) == 0 {
+			return true
+		}
+		if err := s.DB.Create(&batch).Error; err != nil {
+			insertErr = fmt.Errorf("seed inventory: %w", repository.TranslateError(err, constants.EntityInventory))
+			return false
+		}
+		inserted += len(batch)
+		if batches++; batches%100 == 0 {
+			log.Printf("Seeded %d/%d inventory rows", inserted, want)
+		}
+		batch = batch[:0]
+		return true
+	}
+
+	gen.samplePairs(len(hubIDs), len(skuIDs), want, func(hub, sku int) bool {
+		batch = append(batch, models.Inventory{HubID: hubIDs[hub], SKUID: skuIDs[sku], Quantity: gen.quantity()})
+		if len(batch) == s.Fixture.BatchSize {
+			return flush()
+		}
+		return true
+	})
+	if insertErr != nil || !flush() {
+		return inserted, insertErr
+	}
+
+	explicit, err := s.inventoryRecords(hubIDs, skuIDs, catalog)
+	if err != nil {
+		return inserted, err
+	}
+	if len(explicit) > 0 {
+		err := s.DB.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "hub_id"}, {Name: "sku_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+		}).CreateInBatches(&explicit, s.Fixture.BatchSize).Error
+		if err != nil {
+			return inserted, fmt.Errorf("seed inventory records: %w", repository.TranslateError(err, constants.EntityInventory))
+		}
+	}
+
+	log.Printf("Seeded %d inventory rows", inserted+len(explicit))
+	return inserted + len(explicit), nil
+}
+
+func (s *Seeder) inventoryRecords(hubIDs, skuIDs []uint, catalog *Catalog) ([]models.Inventory, error) {
+	if len(s.Fixture.InventoryRecords) == 0 {
+		return nil, nil
+	}
+	hubs := make(map[string]uint, len(catalog.Hubs))
+	for i, hub := range catalog.Hubs {
+		hubs[hub.Name] = hubIDs[i]
+	}
+	skus := make(map[string]uint, len(catalog.SKUs))
+	for i, sku := range catalog.SKUs {
+		skus[sku.Code] = skuIDs[i]
+	}
+
+	inventories := make([]models.Inventory, 0, len(s.Fixture.InventoryRecords))
+	for _, r := range s.Fixture.InventoryRecords {
+		hubID, ok := hubs[r.HubName]
+		if !ok {
+			return nil, fmt.Errorf("inventory record references unknown hub %q", r.HubName)
+		}
+		skuID, ok := skus[r.SKUCode]
+		if !ok {
+			return nil, fmt.Errorf("inventory record references unknown sku %q", r.SKUCode)
+		}
+		inventories = append(inventories, models.Inventory{HubID: hubID, SKUID: skuID, Quantity: r.Quantity})
+	}
+	return inventories, nil
+}
+
+func (c *Catalog) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c)
+}
+
+func nullablePrice(price sql.NullFloat64) *float64 {
+	if !price.Valid {
+		return nil
+	}
+	return &price.Float64
+}
@@ -0,0 +1,92 @@
+package seeder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/validators"
+)
+
+func TestLoadFixtureOverridesDefaults(t *testing.T) {
+	fixture, err := LoadFixture("../fixtures/seed.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixture.Hubs != 20 || fixture.SKUs != 500 || fixture.Inventory != 4000 {
+		t.Errorf("sizes = %d/%d/%d, want 20/500/4000", fixture.Hubs, fixture.SKUs, fixture.Inventory)
+	}
+	if len(fixture.Categories) != len(DefaultFixture().Categories) {
+		t.Errorf("categories not inherited from defaults")
+	}
+	if len(fixture.SKURecords) != 2 || fixture.SKURecords[0].Price == nil || fixture.SKURecords[1].Price != nil {
+		t.Errorf("sku records = %+v, want one priced and one null-priced record", fixture.SKURecords)
+	}
+	if err := fixture.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	if _, err := LoadFixture("../fixtures/loadtest-1m.yaml"); err != nil {
+		t.Errorf("load test fixture: %v", err)
+	}
+}
+
+func TestValidateRejectsImpossibleSizes(t *testing.T) {
+	fixture := DefaultFixture()
+	fixture.Hubs, fixture.SKUs, fixture.Inventory = 2, 3, 7
+	if err := fixture.Validate(); err == nil {
+		t.Error("expected inventory larger than the matrix to be rejected")
+	}
+
+	fixture = DefaultFixture()
+	fixture.BatchSize = maxSeedBatchSize + 1
+	if err := fixture.Validate(); err == nil {
+		t.Error("expected oversized batch to be rejected")
+	}
+}
+
+func TestGeneratedRowsPassValidation(t *testing.T) {
+	gen := newGenerator(DefaultFixture())
+	for i := 0; i < 200; i++ {
+		hub := gen.hub(i)
+		if err := validators.ValidateHub(&hub); err != nil {
+			t.Fatalf("hub %d invalid: %v", i, err)
+		}
+		sku := gen.sku(i)
+		if err := validators.ValidateSKU(&sku); err != nil {
+			t.Fatalf("sku %d invalid: %v", i, err)
+		}
+	}
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	a, b := newGenerator(DefaultFixture()), newGenerator(DefaultFixture())
+	for i := 0; i < 50; i++ {
+		if !reflect.DeepEqual(a.sku(i), b.sku(i)) || !reflect.DeepEqual(a.hub(i), b.hub(i)) {
+			t.Fatalf("row %d differs between runs with the same seed", i)
+		}
+	}
+}
+
+func TestSamplePairsPicksExactDistinctPairs(t *testing.T) {
+	gen := newGenerator(DefaultFixture())
+	seen := make(map[[2]int]bool)
+	gen.samplePairs(30, 40, 500, func(hub, sku int) bool {
+		if hub < 0 || hub >= 30 || sku < 0 || sku >= 40 {
+			t.Fatalf("pair (%d, %d) out of range", hub, sku)
+		}
+		seen[[2]int{hub, sku}] = true
+		return true
+	})
+	if len(seen) != 500 {
+		t.Errorf("sampled %d distinct pairs, want 500", len(seen))
+	}
+
+	visited := 0
+	gen.samplePairs(30, 40, 500, func(int, int) bool {
+		visited++
+		return visited < 10
+	})
+	if visited != 10 {
+		t.Errorf("visited %d pairs after stopping, want 10", visited)
+	}
+}
@@ -0,0 +1,69 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RuleRequired   = "required"
+	RuleMaxLength  = "max_length"
+	RuleMin        = "min"
+	RuleEmail      = "email"
+	RulePostalCode = "postal_code"
+	RuleBatchSize  = "batch_size"
+	RuleDistinct   = "distinct"
+	RuleFormat     = "format"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects every failing rule for a single object.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, fieldErr := range v {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (v *ValidationErrors) add(field, rule, message string) {
+	*v = append(*v, FieldError{Field: field, Rule: rule, Message: message})
+}
+
+func (v ValidationErrors) errOrNil() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+type ItemErrors struct {
+	Index  int              `json:"index"`
+	Errors ValidationErrors `json:"errors"`
+}
+
+// BatchErrors holds the validation errors of every invalid item in a batch,
+// keyed by the item's position in the request.
+type BatchErrors []ItemErrors
+
+func (b BatchErrors) Error() string {
+	messages := make([]string, len(b))
+	for i, item := range b {
+		messages[i] = fmt.Sprintf("[%d] %s", item.Index, item.Errors.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (b BatchErrors) errOrNil() error {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
@@ -2,83 +2,230 @@ package validators
 
 import (
 	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
 	"strings"
 
 	"github.com/Trishank-Omniful/Onboarding-Task/models"
 )
 
+var postalCodePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 -]{1,8}[A-Za-z0-9]$`)
+
 func ValidateHub(hub *models.Hub) error {
-	if strings.TrimSpace(hub.Name) == "" {
-		return errors.New("hub name is required")
-	}
+	var errs ValidationErrors
 
-	if strings.TrimSpace(hub.Address) == "" {
-		return errors.New("hub address is required")
-	}
+	requireString(&errs, "name", "hub name", hub.Name, 255)
+	requireString(&errs, "address", "hub address", hub.Address, 512)
+	maxLength(&errs, "city", "hub city", hub.City, 100)
+	maxLength(&errs, "state", "hub state", hub.State, 100)
+	maxLength(&errs, "country", "hub country", hub.Country, 100)
+	maxLength(&errs, "contact_name", "contact name", hub.ContactName, 255)
 
-	if len(hub.Name) > 255 {
-		return errors.New("hub name too long (max 255 characters)")
+	if hub.PostalCode != "" && !postalCodePattern.MatchString(hub.PostalCode) {
+		errs.add("postal_code", RulePostalCode, "postal code must be 3-10 letters, digits, spaces or hyphens")
 	}
 
-	if len(hub.Address) > 512 {
-		return errors.New("hub address too long (max 512 characters)")
+	if hub.ContactEmail != "" {
+		if len(hub.ContactEmail) > 255 {
+			errs.add("contact_email", RuleMaxLength, "contact email too long (max 255 characters)")
+		} else if !isEmail(hub.ContactEmail) {
+			errs.add("contact_email", RuleEmail, "contact email is not a valid email address")
+		}
 	}
 
-	return nil
+	return errs.errOrNil()
 }
 
 func ValidateSKU(sku *models.SKU) error {
-	if strings.TrimSpace(sku.Code) == "" {
-		return errors.New("SKU code is required")
+	var errs ValidationErrors
+
+	requireString(&errs, "code", "SKU code", sku.Code, 255)
+	requireString(&errs, "name", "SKU name", sku.Name, 255)
+	requireString(&errs, "tenant_id", "tenant ID", sku.TenantId, 255)
+	requireString(&errs, "seller_id", "seller ID", sku.SellerId, 255)
+	maxLength(&errs, "category", "SKU category", sku.Category, 100)
+
+	if sku.Price.Valid && sku.Price.Float64 < 0 {
+		errs.add("price", RuleMin, "SKU price cannot be negative")
 	}
 
-	if strings.TrimSpace(sku.Name) == "" {
-		return errors.New("SKU name is required")
+	return errs.errOrNil()
+}
+
+func ValidateInventory(inventory *models.Inventory) error {
+	var errs ValidationErrors
+
+	if inventory.HubID == 0 {
+		errs.add("hub_id", RuleRequired, "hub ID is required")
 	}
 
-	if strings.TrimSpace(sku.TenantId) == "" {
-		return errors.New("tenant ID is required")
+	if inventory.SKUID == 0 {
+		errs.add("sku_id", RuleRequired, "SKU ID is required")
 	}
 
-	if strings.TrimSpace(sku.SellerId) == "" {
-		return errors.New("seller ID is required")
+	if inventory.Quantity < 0 {
+		errs.add("quantity", RuleMin, "inventory quantity cannot be negative")
 	}
 
-	if len(sku.Code) > 255 {
-		return errors.New("SKU code too long (max 255 characters)")
+	return errs.errOrNil()
+}
+
+// ValidateThresholds checks the stock thresholds of a hub/SKU pair. The
+// safety stock is the floor a reorder point, when set, is planned above.
+func ValidateThresholds(inventory *models.Inventory) error {
+	var errs ValidationErrors
+
+	if inventory.HubID == 0 {
+		errs.add("hub_id", RuleRequired, "hub ID is required")
+	}
+	if inventory.SKUID == 0 {
+		errs.add("sku_id", RuleRequired, "SKU ID is required")
+	}
+	if inventory.SafetyStock < 0 {
+		errs.add("safety_stock", RuleMin, "safety stock cannot be negative")
+	}
+	if inventory.ReorderPoint < 0 {
+		errs.add("reorder_point", RuleMin, "reorder point cannot be negative")
+	} else if inventory.ReorderPoint != 0 && inventory.ReorderPoint < inventory.SafetyStock {
+		errs.add("reorder_point", RuleMin, "reorder point cannot be below the safety stock")
 	}
 
-	if len(sku.Name) > 255 {
-		return errors.New("SKU name too long (max 255 characters)")
+	return errs.errOrNil()
+}
+
+func ValidateTransfer(transfer *models.InventoryTransfer) error {
+	var errs ValidationErrors
+
+	if transfer.SKUID == 0 {
+		errs.add("sku_id", RuleRequired, "SKU ID is required")
+	}
+	if transfer.SourceHubID == 0 {
+		errs.add("source_hub_id", RuleRequired, "source hub ID is required")
 	}
+	if transfer.DestinationHubID == 0 {
+		errs.add("destination_hub_id", RuleRequired, "destination hub ID is required")
+	} else if transfer.DestinationHubID == transfer.SourceHubID {
+		errs.add("destination_hub_id", RuleDistinct, "destination hub must differ from the source hub")
+	}
+	if transfer.Quantity <= 0 {
+		errs.add("quantity", RuleMin, "transfer quantity must be positive")
+	}
+	maxLength(&errs, "reference", "transfer reference", transfer.Reference, 255)
 
-	return nil
+	return errs.errOrNil()
 }
 
-func ValidateInventory(inventory *models.Inventory) error {
-	if inventory.HubID == 0 {
-		return errors.New("hub ID is required")
+func ValidateCycleCount(count *models.CycleCount) error {
+	var errs ValidationErrors
+
+	if count.HubID == 0 {
+		errs.add("hub_id", RuleRequired, "hub ID is required")
 	}
+	maxLength(&errs, "category", "category", count.Category, 100)
 
-	if inventory.SKUID == 0 {
-		return errors.New("SKU ID is required")
+	return errs.errOrNil()
+}
+
+// ValidateCycleCountEntries checks that each SKU is counted once with a
+// quantity that is not negative.
+func ValidateCycleCountEntries(entries []models.CycleCountEntry) error {
+	var batch BatchErrors
+	seen := make(map[uint]bool, len(entries))
+	for i, entry := range entries {
+		var errs ValidationErrors
+		if entry.SKUID == 0 {
+			errs.add("sku_id", RuleRequired, "SKU ID is required")
+		} else if seen[entry.SKUID] {
+			errs.add("sku_id", RuleDistinct, "SKU is counted more than once")
+		}
+		seen[entry.SKUID] = true
+		if entry.CountedQuantity < 0 {
+			errs.add("counted_quantity", RuleMin, "counted quantity cannot be negative")
+		}
+		batch.collect(i, errs.errOrNil())
+	}
+	return batch.errOrNil()
+}
+
+// ValidateAvailabilityLines checks that every line names a hub and a SKU,
+// by ID or code, and a positive quantity.
+func ValidateAvailabilityLines(lines []models.AvailabilityLine) error {
+	var batch BatchErrors
+	for i, line := range lines {
+		var errs ValidationErrors
+		if line.HubID == 0 && line.HubCode == "" {
+			errs.add("hub_id", RuleRequired, "hub ID or hub code is required")
+		}
+		if line.SKUID == 0 && line.SKUCode == "" {
+			errs.add("sku_id", RuleRequired, "SKU ID or SKU code is required")
+		}
+		if line.RequiredQuantity <= 0 {
+			errs.add("required_quantity", RuleMin, "required quantity must be positive")
+		}
+		batch.collect(i, errs.errOrNil())
 	}
+	return batch.errOrNil()
+}
 
-	if inventory.Quantity < 0 {
-		return errors.New("inventory quantity cannot be negative")
+func ValidateHubs(hubs []models.Hub) error {
+	var errs BatchErrors
+	for i := range hubs {
+		errs.collect(i, ValidateHub(&hubs[i]))
 	}
+	return errs.errOrNil()
+}
 
-	return nil
+func ValidateSKUs(skus []models.SKU) error {
+	var errs BatchErrors
+	for i := range skus {
+		errs.collect(i, ValidateSKU(&skus[i]))
+	}
+	return errs.errOrNil()
 }
 
-func ValidateBatchSize(size int) error {
+func ValidateInventories(inventories []models.Inventory) error {
+	var errs BatchErrors
+	for i := range inventories {
+		errs.collect(i, ValidateInventory(&inventories[i]))
+	}
+	return errs.errOrNil()
+}
+
+func ValidateBatchSize(size, max int) error {
 	if size == 0 {
-		return errors.New("batch cannot be empty")
+		return ValidationErrors{{Field: "batch", Rule: RuleBatchSize, Message: "batch cannot be empty"}}
 	}
 
-	if size > 1000 {
-		return errors.New("batch size too large (max 1000 items)")
+	if size > max {
+		return ValidationErrors{{Field: "batch", Rule: RuleBatchSize, Message: fmt.Sprintf("batch size too large (max %d items)", max)}}
 	}
 
 	return nil
 }
+
+func (b *BatchErrors) collect(index int, err error) {
+	var fieldErrs ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		*b = append(*b, ItemErrors{Index: index, Errors: fieldErrs})
+	}
+}
+
+func requireString(errs *ValidationErrors, field, label, value string, max int) {
+	if strings.TrimSpace(value) == "" {
+		errs.add(field, RuleRequired, label+" is required")
+		return
+	}
+	maxLength(errs, field, label, value, max)
+}
+
+func maxLength(errs *ValidationErrors, field, label, value string, max int) {
+	if len(value) > max {
+		errs.add(field, RuleMaxLength, fmt.Sprintf("%s too long (max %d characters)", label, max))
+	}
+}
+
+func isEmail(value string) bool {
+	addr, err := mail.ParseAddress(value)
+	return err == nil && addr.Address == value && strings.Contains(value[strings.LastIndex(value, "@"):], ".")
+}
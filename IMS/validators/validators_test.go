@@ -0,0 +1,120 @@
+package validators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/models"
+)
+
+func TestValidateHub(t *testing.T) {
+	tests := []struct {
+		name  string
+		hub   models.Hub
+		rules []string
+	}{
+		{"valid", models.Hub{Name: "hub", Address: "addr", PostalCode: "400001", ContactEmail: "ops@example.com"}, nil},
+		{"missing everything", models.Hub{}, []string{RuleRequired, RuleRequired}},
+		{"bad email", models.Hub{Name: "hub", Address: "addr", ContactEmail: "ops@example"}, []string{RuleEmail}},
+		{"display name email", models.Hub{Name: "hub", Address: "addr", ContactEmail: "Ops <ops@example.com>"}, []string{RuleEmail}},
+		{"bad postal code", models.Hub{Name: "hub", Address: "addr", PostalCode: "40#001"}, []string{RulePostalCode}},
+		{"alphanumeric postal code", models.Hub{Name: "hub", Address: "addr", PostalCode: "SW1A 1AA"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRules(t, ValidateHub(&tt.hub), tt.rules)
+		})
+	}
+}
+
+func TestValidateSKU(t *testing.T) {
+	valid := models.SKU{Code: "c", Name: "n", TenantId: "t", SellerId: "s", Price: models.ToNullFloat64(0)}
+	assertRules(t, ValidateSKU(&valid), nil)
+
+	negative := valid
+	negative.Price = models.ToNullFloat64(-0.01)
+	assertRules(t, ValidateSKU(&negative), []string{RuleMin})
+}
+
+func TestValidateInventories(t *testing.T) {
+	err := ValidateInventories([]models.Inventory{
+		{HubID: 1, SKUID: 1, Quantity: 1},
+		{HubID: 1, Quantity: -1},
+		{HubID: 1, SKUID: 1},
+		{},
+	})
+
+	var batchErrs BatchErrors
+	if !errors.As(err, &batchErrs) {
+		t.Fatalf("err = %v, want BatchErrors", err)
+	}
+	if len(batchErrs) != 2 || batchErrs[0].Index != 1 || batchErrs[1].Index != 3 {
+		t.Fatalf("batch errors = %+v, want items 1 and 3", batchErrs)
+	}
+	if len(batchErrs[0].Errors) != 2 {
+		t.Fatalf("item 1 errors = %+v, want sku_id and quantity", batchErrs[0].Errors)
+	}
+}
+
+func TestValidateThresholds(t *testing.T) {
+	assertRules(t, ValidateThresholds(&models.Inventory{HubID: 1, SKUID: 1, ReorderPoint: 10, SafetyStock: 4}), nil)
+	assertRules(t, ValidateThresholds(&models.Inventory{HubID: 1, SKUID: 1, SafetyStock: 4}), nil)
+	assertRules(t, ValidateThresholds(&models.Inventory{HubID: 1, SKUID: 1}), nil)
+	assertRules(t, ValidateThresholds(&models.Inventory{HubID: 1, SKUID: 1, ReorderPoint: 3, SafetyStock: 4}), []string{RuleMin})
+	assertRules(t, ValidateThresholds(&models.Inventory{SafetyStock: -1, ReorderPoint: -1}), []string{RuleRequired, RuleRequired, RuleMin, RuleMin})
+}
+
+func TestValidateAvailabilityLines(t *testing.T) {
+	err := ValidateAvailabilityLines([]models.AvailabilityLine{
+		{HubID: 1, SKUCode: "sku_a", RequiredQuantity: 1},
+		{HubCode: "hub_a", RequiredQuantity: 1},
+		{HubID: 1, SKUID: 1},
+	})
+
+	var batchErrs BatchErrors
+	if !errors.As(err, &batchErrs) || len(batchErrs) != 2 || batchErrs[0].Index != 1 || batchErrs[1].Index != 2 {
+		t.Fatalf("err = %v, want items 1 and 2 rejected", err)
+	}
+}
+
+func TestValidateCycleCountEntries(t *testing.T) {
+	err := ValidateCycleCountEntries([]models.CycleCountEntry{
+		{SKUID: 1, CountedQuantity: 0},
+		{SKUID: 1, CountedQuantity: 2},
+		{CountedQuantity: -1},
+	})
+
+	var batchErrs BatchErrors
+	if !errors.As(err, &batchErrs) || len(batchErrs) != 2 || batchErrs[0].Index != 1 || batchErrs[1].Index != 2 {
+		t.Fatalf("err = %v, want the repeated SKU and item 2 rejected", err)
+	}
+}
+
+func TestValidateBatchSize(t *testing.T) {
+	assertRules(t, ValidateBatchSize(0, 1000), []string{RuleBatchSize})
+	assertRules(t, ValidateBatchSize(1, 1000), nil)
+	assertRules(t, ValidateBatchSize(1001, 1000), []string{RuleBatchSize})
+}
+
+func assertRules(t *testing.T, err error, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Fatalf("err = %v, want nil", err)
+		}
+		return
+	}
+
+	var fieldErrs ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("err = %v, want ValidationErrors", err)
+	}
+	if len(fieldErrs) != len(want) {
+		t.Fatalf("errors = %+v, want rules %v", fieldErrs, want)
+	}
+	for i, rule := range want {
+		if fieldErrs[i].Rule != rule {
+			t.Fatalf("errors[%d].Rule = %q, want %q", i, fieldErrs[i].Rule, rule)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+// Package allocation decides which hubs fulfil an order.
+package allocation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Trishank-omniful/Onboarding-Task/clients"
+	"github.com/Trishank-omniful/Onboarding-Task/models"
+)
+
+// maxCombinations bounds the exhaustive search for the fewest hubs. Past it,
+// hubs are picked greedily by how much of the remaining order they cover.
+const maxCombinations = 10000
+
+// Shortfall is a line that no combination of hubs can fill or, with HubCode
+// set, a shipment line that hub no longer holds.
+type Shortfall struct {
+	SKUCode   string `json:"sku_code"`
+	HubCode   string `json:"hub_code,omitempty"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
+
+type InsufficientStockError struct {
+	Shortfalls []Shortfall
+}
+
+func (e *InsufficientStockError) Error() string {
+	codes := make([]string, len(e.Shortfalls))
+	for i, s := range e.Shortfalls {
+		codes[i] = fmt.Sprintf("%s (%d of %d)", s.SKUCode, s.Available, s.Requested)
+		if s.HubCode != "" {
+			codes[i] = fmt.Sprintf("%s at %s (%d of %d)", s.SKUCode, s.HubCode, s.Available, s.Requested)
+		}
+	}
+	return "insufficient stock for " + strings.Join(codes, ", ")
+}
+
+type ServiceInterface interface {
+	Allocate(ctx context.Context, items []models.OrderItem, address models.Address) ([]models.Shipment, error)
+}
+
+type Service struct {
+	ims clients.IMSClientInterface
+}
+
+func NewService(ims clients.IMSClientInterface) *Service {
+	return &Service{ims: ims}
+}
+
+// Allocate splits items into hub-level shipments using current IMS stock,
+// then confirms every shipment line with the IMS batch availability check,
+// since stock may have moved while the plan was made. The hub code on the
+// incoming items is ignored.
+func (s *Service) Allocate(ctx context.Context, items []models.OrderItem, address models.Address) ([]models.Shipment, error) {
+	lines := mergeLines(items)
+	codes := make([]string, len(lines))
+	for i, line := range lines {
+		codes[i] = line.SKUCode
+	}
+
+	stock, err := s.ims.GetHubStock(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("load hub stock: %w", err)
+	}
+	shipments, err := Plan(lines, stock, address)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.confirm(ctx, shipments); err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// confirm reports the shipment lines IMS can no longer fill as shortfalls.
+func (s *Service) confirm(ctx context.Context, shipments []models.Shipment) error {
+	var lines []clients.AvailabilityLine
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			lines = append(lines, clients.AvailabilityLine{HubCode: item.HubCode, SKUCode: item.SKUCode, RequiredQuantity: item.Quantity})
+		}
+	}
+
+	check, err := s.ims.CheckAvailabilityBatch(ctx, lines)
+	if err != nil {
+		return fmt.Errorf("check availability: %w", err)
+	}
+	if check.CanFulfil {
+		return nil
+	}
+	var missing []Shortfall
+	for _, line := range check.Lines {
+		if !line.Available {
+			missing = append(missing, Shortfall{
+				SKUCode:   line.SKUCode,
+				HubCode:   line.HubCode,
+				Requested: line.RequiredQuantity,
+				Available: line.AvailableQuantity,
+			})
+		}
+	}
+	return &InsufficientStockError{Shortfalls: missing}
+}
+
+// Plan prefers a single hub, then the fewest hubs, then hubs closest to the
+// address. Within the chosen hubs every line is filled from the closest hub
+// first, so a line is only split when no single chosen hub can fill it.
+func Plan(lines []models.OrderItem, stock []clients.HubStock, address models.Address) ([]models.Shipment, error) {
+	lines = mergeLines(lines)
+
+	var hubs []candidate
+	for _, hub := range stock {
+		if holdsAny(hub, lines) {
+			hubs = append(hubs, candidate{HubStock: hub, distance: distance(hub, address)})
+		}
+	}
+	sort.SliceStable(hubs, func(i, j int) bool {
+		if hubs[i].distance != hubs[j].distance {
+			return hubs[i].distance < hubs[j].distance
+		}
+		return hubs[i].HubCode < hubs[j].HubCode
+	})
+
+	if shortfalls := shortfalls(lines, hubs); len(shortfalls) > 0 {
+		return nil, &InsufficientStockError{Shortfalls: shortfalls}
+	}
+
+	chosen := fewestHubs(lines, hubs)
+	if chosen == nil {
+		chosen = greedyHubs(lines, hubs)
+	}
+	return assign(lines, chosen), nil
+}
+
+type candidate struct {
+	clients.HubStock
+	distance int
+}
+
+// distance ranks a hub against the shipping address by country, then state,
+// then the length of the shared postal code prefix, which narrows the region
+// digit by digit in most postal systems. Lower is closer.
+func distance(hub clients.HubStock, address models.Address) int {
+	switch {
+	case !strings.EqualFold(hub.Country, address.Country):
+		return 200
+	case !strings.EqualFold(hub.State, address.State):
+		return 100 - commonPrefix(hub.PostalCode, address.ZipCode)
+	}
+	return -commonPrefix(hub.PostalCode, address.ZipCode)
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// fewestHubs tries every combination of one hub, then two, and so on, and
+// returns the feasible combination of the smallest size with the lowest total
+// distance. It gives up once a size has more than maxCombinations options.
+func fewestHubs(lines []models.OrderItem, hubs []candidate) []candidate {
+	for k := 1; k <= len(hubs); k++ {
+		if binomial(len(hubs), k) > maxCombinations {
+			return nil
+		}
+
+		var best []int
+		bestDistance := 0
+		combination := make([]int, k)
+		for i := range combination {
+			combination[i] = i
+		}
+		for {
+			if covers(lines, hubs, combination) {
+				total := 0
+				for _, i := range combination {
+					total += hubs[i].distance
+				}
+				if best == nil || total < bestDistance {
+					best, bestDistance = append([]int(nil), combination...), total
+				}
+			}
+			if !nextCombination(combination, len(hubs)) {
+				break
+			}
+		}
+		if best != nil {
+			chosen := make([]candidate, len(best))
+			for i, index := range best {
+				chosen[i] = hubs[index]
+			}
+			return chosen
+		}
+	}
+	return nil
+}
+
+// greedyHubs repeatedly takes the hub covering the most outstanding units,
+// the closer one on a tie.
+func greedyHubs(lines []models.OrderItem, hubs []candidate) []candidate {
+	remaining := make(map[string]int, len(lines))
+	for _, line := range lines {
+		remaining[line.SKUCode] = line.Quantity
+	}
+
+	var chosen []candidate
+	used := make([]bool, len(hubs))
+	for outstanding(remaining) {
+		best, bestUnits := -1, 0
+		for i, hub := range hubs {
+			if used[i] {
+				continue
+			}
+			units := 0
+			for code, want := range remaining {
+				units += min(want, hub.Available[code])
+			}
+			if units > bestUnits {
+				best, bestUnits = i, units
+			}
+		}
+		used[best] = true
+		chosen = append(chosen, hubs[best])
+		for code, want := range remaining {
+			remaining[code] = want - min(want, hubs[best].Available[code])
+		}
+	}
+	sort.SliceStable(chosen, func(i, j int) bool { return chosen[i].distance < chosen[j].distance })
+	return chosen
+}
+
+// assign fills every line from the chosen hubs, closest first, and returns
+// one shipment per hub that received anything.
+func assign(lines []models.OrderItem, chosen []candidate) []models.Shipment {
+	shipments := make([]models.Shipment, len(chosen))
+	for i, hub := range chosen {
+		shipments[i].HubCode = hub.HubCode
+	}
+	for _, line := range lines {
+		want := line.Quantity
+		for i, hub := range chosen {
+			take := min(want, hub.Available[line.SKUCode])
+			if take == 0 {
+				continue
+			}
+			item := line
+			item.HubCode, item.Quantity = hub.HubCode, take
+			shipments[i].Items = append(shipments[i].Items, item)
+			if want -= take; want == 0 {
+				break
+			}
+		}
+	}
+
+	filled := shipments[:0]
+	for _, shipment := range shipments {
+		if len(shipment.Items) > 0 {
+			filled = append(filled, shipment)
+		}
+	}
+	return filled
+}
+
+// mergeLines folds repeated SKU codes into one line, keeping the first line's
+// position and price.
+func mergeLines(items []models.OrderItem) []models.OrderItem {
+	positions := make(map[string]int, len(items))
+	lines := make([]models.OrderItem, 0, len(items))
+	for _, item := range items {
+		if i, ok := positions[item.SKUCode]; ok {
+			lines[i].Quantity += item.Quantity
+			continue
+		}
+		positions[item.SKUCode] = len(lines)
+		item.HubCode = ""
+		lines = append(lines, item)
+	}
+	return lines
+}
+
+func shortfalls(lines []models.OrderItem, hubs []candidate) []Shortfall {
+	var missing []Shortfall
+	for _, line := range lines {
+		available := 0
+		for _, hub := range hubs {
+			available += hub.Available[line.SKUCode]
+		}
+		if available < line.Quantity {
+			missing = append(missing, Shortfall{SKUCode: line.SKUCode, Requested: line.Quantity, Available: available})
+		}
+	}
+	return missing
+}
+
+func covers(lines []models.OrderItem, hubs []candidate, combination []int) bool {
+	for _, line := range lines {
+		available := 0
+		for _, i := range combination {
+			available += hubs[i].Available[line.SKUCode]
+		}
+		if available < line.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+func holdsAny(hub clients.HubStock, lines []models.OrderItem) bool {
+	for _, line := range lines {
+		if hub.Available[line.SKUCode] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func outstanding(remaining map[string]int) bool {
+	for _, want := range remaining {
+		if want > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// nextCombination advances combination to the next k-subset of [0, n) in
+// lexicographic order and reports whether there was one.
+func nextCombination(combination []int, n int) bool {
+	k := len(combination)
+	i := k - 1
+	for i >= 0 && combination[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	combination[i]++
+	for j := i + 1; j < k; j++ {
+		combination[j] = combination[j-1] + 1
+	}
+	return true
+}
+
+// binomial returns n choose k, capped just above maxCombinations.
+func binomial(n, k int) int {
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+		if result > maxCombinations {
+			return maxCombinations + 1
+		}
+	}
+	return result
+}
@@ -0,0 +1,198 @@
+package allocation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Trishank-omniful/Onboarding-Task/clients"
+	"github.com/Trishank-omniful/Onboarding-Task/models"
+)
+
+var mumbai = models.Address{City: "Mumbai", State: "Maharashtra", ZipCode: "400001", Country: "India"}
+
+func hub(code, state, postalCode string, available map[string]int) clients.HubStock {
+	return clients.HubStock{HubCode: code, State: state, PostalCode: postalCode, Country: "India", Available: available}
+}
+
+// summary flattens shipments to hub -> sku -> quantity for comparison.
+func summary(t *testing.T, shipments []models.Shipment) map[string]map[string]int {
+	t.Helper()
+	out := make(map[string]map[string]int)
+	for _, shipment := range shipments {
+		out[shipment.HubCode] = make(map[string]int)
+		for _, item := range shipment.Items {
+			if item.HubCode != shipment.HubCode {
+				t.Fatalf("item %+v sits in the shipment of %s", item, shipment.HubCode)
+			}
+			out[shipment.HubCode][item.SKUCode] += item.Quantity
+		}
+	}
+	return out
+}
+
+func TestPlan(t *testing.T) {
+	lines := []models.OrderItem{{SKUCode: "A", Quantity: 4}, {SKUCode: "B", Quantity: 2}}
+
+	tests := []struct {
+		name  string
+		stock []clients.HubStock
+		want  map[string]map[string]int
+	}{
+		{
+			name: "single hub beats a closer split",
+			stock: []clients.HubStock{
+				hub("pune", "Maharashtra", "411001", map[string]int{"A": 10}),
+				hub("thane", "Maharashtra", "400601", map[string]int{"B": 10}),
+				hub("delhi", "Delhi", "110001", map[string]int{"A": 10, "B": 10}),
+			},
+			want: map[string]map[string]int{"delhi": {"A": 4, "B": 2}},
+		},
+		{
+			name: "closest of several complete hubs",
+			stock: []clients.HubStock{
+				hub("delhi", "Delhi", "110001", map[string]int{"A": 10, "B": 10}),
+				hub("pune", "Maharashtra", "411001", map[string]int{"A": 10, "B": 10}),
+				hub("thane", "Maharashtra", "400601", map[string]int{"A": 10, "B": 10}),
+			},
+			want: map[string]map[string]int{"thane": {"A": 4, "B": 2}},
+		},
+		{
+			name: "two hubs, nearest pair",
+			stock: []clients.HubStock{
+				hub("delhi", "Delhi", "110001", map[string]int{"A": 10}),
+				hub("pune", "Maharashtra", "411001", map[string]int{"A": 10}),
+				hub("thane", "Maharashtra", "400601", map[string]int{"B": 10}),
+			},
+			want: map[string]map[string]int{"pune": {"A": 4}, "thane": {"B": 2}},
+		},
+		{
+			name: "line split across hubs",
+			stock: []clients.HubStock{
+				hub("pune", "Maharashtra", "411001", map[string]int{"A": 1, "B": 2}),
+				hub("thane", "Maharashtra", "400601", map[string]int{"A": 3}),
+			},
+			want: map[string]map[string]int{"thane": {"A": 3}, "pune": {"A": 1, "B": 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipments, err := Plan(lines, tt.stock, mumbai)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := summary(t, shipments); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("shipments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanReportsShortfalls(t *testing.T) {
+	lines := []models.OrderItem{{SKUCode: "A", Quantity: 3}, {SKUCode: "B", Quantity: 1}, {SKUCode: "A", Quantity: 2}}
+	stock := []clients.HubStock{
+		hub("pune", "Maharashtra", "411001", map[string]int{"A": 2, "B": 1}),
+		hub("thane", "Maharashtra", "400601", map[string]int{"A": 2}),
+	}
+
+	_, err := Plan(lines, stock, mumbai)
+	var insufficient *InsufficientStockError
+	if !errors.As(err, &insufficient) {
+		t.Fatalf("err = %v, want InsufficientStockError", err)
+	}
+	want := []Shortfall{{SKUCode: "A", Requested: 5, Available: 4}}
+	if !reflect.DeepEqual(insufficient.Shortfalls, want) {
+		t.Fatalf("shortfalls = %+v, want %+v", insufficient.Shortfalls, want)
+	}
+}
+
+func TestPlanFallsBackToGreedyForManyHubs(t *testing.T) {
+	var lines []models.OrderItem
+	var stock []clients.HubStock
+	for i := 0; i < 40; i++ {
+		code := string(rune('a'+i%26)) + string(rune('a'+i/26))
+		lines = append(lines, models.OrderItem{SKUCode: code, Quantity: 1})
+		stock = append(stock, hub("hub-"+code, "Maharashtra", "411001", map[string]int{code: 1}))
+	}
+
+	shipments, err := Plan(lines, stock, mumbai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shipments) != 40 {
+		t.Fatalf("got %d shipments, want one per hub", len(shipments))
+	}
+}
+
+type stubIMS struct {
+	clients.IMSClientInterface
+	codes []string
+	stock []clients.HubStock
+	// checked is the stock the availability check sees, stock if nil.
+	checked []clients.HubStock
+	lines   []clients.AvailabilityLine
+}
+
+func (s *stubIMS) GetHubStock(_ context.Context, codes []string) ([]clients.HubStock, error) {
+	s.codes = codes
+	return s.stock, nil
+}
+
+func (s *stubIMS) CheckAvailabilityBatch(_ context.Context, lines []clients.AvailabilityLine) (*clients.AvailabilityCheck, error) {
+	s.lines = lines
+	stock := s.checked
+	if stock == nil {
+		stock = s.stock
+	}
+	check := &clients.AvailabilityCheck{CanFulfil: true}
+	for i, line := range lines {
+		result := clients.AvailabilityResult{Index: i, HubCode: line.HubCode, SKUCode: line.SKUCode, RequiredQuantity: line.RequiredQuantity}
+		for _, hub := range stock {
+			if hub.HubCode == line.HubCode {
+				result.AvailableQuantity = hub.Available[line.SKUCode]
+			}
+		}
+		result.Available = result.AvailableQuantity >= line.RequiredQuantity
+		check.CanFulfil = check.CanFulfil && result.Available
+		check.Lines = append(check.Lines, result)
+	}
+	return check, nil
+}
+
+func TestAllocateAsksIMSOncePerSKU(t *testing.T) {
+	ims := &stubIMS{stock: []clients.HubStock{hub("thane", "Maharashtra", "400601", map[string]int{"A": 5})}}
+	items := []models.OrderItem{{SKUCode: "A", HubCode: "elsewhere", Quantity: 2}, {SKUCode: "A", Quantity: 1}}
+
+	shipments, err := NewService(ims).Allocate(context.Background(), items, mumbai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ims.codes, []string{"A"}) {
+		t.Errorf("requested codes = %v, want [A]", ims.codes)
+	}
+	if len(shipments) != 1 || shipments[0].HubCode != "thane" || shipments[0].Items[0].Quantity != 3 {
+		t.Fatalf("shipments = %+v, want 3 of A from thane", shipments)
+	}
+	want := []clients.AvailabilityLine{{HubCode: "thane", SKUCode: "A", RequiredQuantity: 3}}
+	if !reflect.DeepEqual(ims.lines, want) {
+		t.Errorf("checked lines = %+v, want %+v", ims.lines, want)
+	}
+}
+
+func TestAllocateRejectsStockGoneBeforeTheCheck(t *testing.T) {
+	ims := &stubIMS{
+		stock:   []clients.HubStock{hub("thane", "Maharashtra", "400601", map[string]int{"A": 5})},
+		checked: []clients.HubStock{hub("thane", "Maharashtra", "400601", map[string]int{"A": 1})},
+	}
+
+	_, err := NewService(ims).Allocate(context.Background(), []models.OrderItem{{SKUCode: "A", Quantity: 3}}, mumbai)
+	var insufficient *InsufficientStockError
+	if !errors.As(err, &insufficient) {
+		t.Fatalf("err = %v, want InsufficientStockError", err)
+	}
+	want := []Shortfall{{SKUCode: "A", HubCode: "thane", Requested: 3, Available: 1}}
+	if !reflect.DeepEqual(insufficient.Shortfalls, want) {
+		t.Errorf("shortfalls = %+v, want %+v", insufficient.Shortfalls, want)
+	}
+}
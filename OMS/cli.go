@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Trishank-omniful/Onboarding-Task/config"
+	"github.com/Trishank-omniful/Onboarding-Task/constants"
+	"github.com/Trishank-omniful/Onboarding-Task/db"
+	"github.com/Trishank-omniful/Onboarding-Task/seeder"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const usage = `Usage:
+  oms [serve]        start the HTTP server
+  oms seed [flags]   generate orders from an IMS catalog, see oms seed -h`
+
+func runCommand(cfg *config.Config, args []string) int {
+	var err error
+	switch args[0] {
+	case "seed":
+		err = runSeed(cfg, args[1:])
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+		return 0
+	default:
+		err = fmt.Errorf("unknown command %q", args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "oms: %v\n\n%s\n", err, usage)
+		return 1
+	}
+	return 0
+}
+
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	catalogPath := flags.String("catalog", "catalog.json", "catalog written by `ims seed -catalog`")
+	count := flags.Int("orders", 1000, "number of orders to generate")
+	seed := flags.Int64("seed", 1, "random seed, the same seed reproduces the same orders")
+	batchSize := flags.Int("batch-size", 1000, "orders per InsertMany call")
+	reset := flags.Bool("reset", false, "delete existing orders first")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *count <= 0 || *batchSize <= 0 {
+		return fmt.Errorf("orders and batch-size must be positive")
+	}
+
+	catalog, err := seeder.LoadCatalog(*catalogPath)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := db.ConnectMongo(ctx, cfg.Mongo)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+	collection := client.Database(cfg.Mongo.Database).Collection(constants.OrdersCollection)
+
+	if *reset {
+		if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
+			return fmt.Errorf("reset orders: %w", err)
+		}
+	}
+
+	orderSeeder, err := seeder.NewOrderSeeder(collection, catalog, *seed, *batchSize)
+	if err != nil {
+		return err
+	}
+	inserted, err := orderSeeder.Run(ctx, *count)
+	if err != nil {
+		return err
+	}
+	log.Printf("Seeded %d orders", inserted)
+	return nil
+}
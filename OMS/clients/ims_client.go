@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-omniful/Onboarding-Task/config"
+	"github.com/Trishank-omniful/Onboarding-Task/constants"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+type IMSClientInterface interface {
+	GetHubStock(ctx context.Context, skuCodes []string) ([]HubStock, error)
+	CheckAvailabilityBatch(ctx context.Context, lines []AvailabilityLine) (*AvailabilityCheck, error)
+}
+
+// AvailabilityLine asks whether a hub holds RequiredQuantity of a SKU, both
+// named by code as orders carry them.
+type AvailabilityLine struct {
+	HubCode          string `json:"hub_code"`
+	SKUCode          string `json:"sku_code"`
+	RequiredQuantity int    `json:"required_quantity"`
+}
+
+// AvailabilityCheck mirrors the IMS batch check.
+type AvailabilityCheck struct {
+	CanFulfil bool                 `json:"can_fulfil"`
+	Lines     []AvailabilityResult `json:"lines"`
+}
+
+// AvailabilityResult answers one AvailabilityLine. A Reason names a hub or
+// SKU that IMS does not know.
+type AvailabilityResult struct {
+	Index             int    `json:"index"`
+	HubCode           string `json:"hub_code"`
+	SKUCode           string `json:"sku_code"`
+	RequiredQuantity  int    `json:"required_quantity"`
+	AvailableQuantity int    `json:"available_quantity"`
+	Available         bool   `json:"available"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// HubStock is the on-hand quantity of a hub per SKU code. IMS hubs are
+// identified by name, which is what orders carry as the hub code.
+type HubStock struct {
+	HubCode    string
+	State      string
+	PostalCode string
+	Country    string
+	Available  map[string]int
+}
+
+type imsClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewIMSClient returns a client whose transport starts a span per call and
+// sends the W3C traceparent header, so IMS spans join the OMS trace.
+func NewIMSClient(cfg config.IMSConfig) *imsClient {
+	return &imsClient{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+	}
+}
+
+// imsError mirrors the error body written by the IMS ErrorHandler.
+type imsError struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// CheckAvailabilityBatch checks every order line against IMS in one call.
+func (c *imsClient) CheckAvailabilityBatch(ctx context.Context, lines []AvailabilityLine) (*AvailabilityCheck, error) {
+	var check AvailabilityCheck
+	if err := c.post(ctx, "/api/v1/ims/inventory/check-availability/batch", lines, &check); err != nil {
+		return nil, err
+	}
+	return &check, nil
+}
+
+// GetHubStock returns every hub holding any of skuCodes, from one IMS
+// availability call. Unknown codes are skipped, so their SKUs simply have no
+// stock anywhere.
+func (c *imsClient) GetHubStock(ctx context.Context, skuCodes []string) ([]HubStock, error) {
+	var availability []struct {
+		SKUCode string `json:"sku_code"`
+		Hubs    []struct {
+			HubName    string `json:"hub_name"`
+			State      string `json:"state"`
+			Country    string `json:"country"`
+			PostalCode string `json:"postal_code"`
+			Quantity   int    `json:"quantity"`
+		} `json:"hubs"`
+	}
+	if err := c.post(ctx, "/api/v1/ims/inventory/availability", map[string][]string{"sku_codes": skuCodes}, &availability); err != nil {
+		return nil, err
+	}
+
+	var stock []HubStock
+	index := make(map[string]int)
+	for _, sku := range availability {
+		for _, hub := range sku.Hubs {
+			i, ok := index[hub.HubName]
+			if !ok {
+				i = len(stock)
+				index[hub.HubName] = i
+				stock = append(stock, HubStock{
+					HubCode:    hub.HubName,
+					State:      hub.State,
+					PostalCode: hub.PostalCode,
+					Country:    hub.Country,
+					Available:  make(map[string]int),
+				})
+			}
+			stock[i].Available[sku.SKUCode] += hub.Quantity
+		}
+	}
+	return stock, nil
+}
+
+func (c *imsClient) post(ctx context.Context, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode ims request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build ims request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if id := logger.RequestID(ctx); id != "" {
+		req.Header.Set(constants.HeaderRequestID, id)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call ims %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e imsError
+		json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("ims %s returned %d %s: %s", path, resp.StatusCode, e.Code, e.Error)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode ims response: %w", err)
+	}
+	return nil
+}
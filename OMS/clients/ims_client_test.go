@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"github.com/Trishank-omniful/Onboarding-Task/config"
+)
+
+func TestCheckAvailabilityBatchPropagatesTraceContext(t *testing.T) {
+	cfg := config.Default().Tracing
+	cfg.Exporter = "stdout"
+	shutdown, err := tracing.Init(context.Background(), "OMS", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(context.Background())
+
+	var traceparent, requestID string
+	ims := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		requestID = r.Header.Get("X-Request-ID")
+		w.Write([]byte(`{"can_fulfil":true,"lines":[{"index":0,"hub_code":"thane","sku_code":"A","required_quantity":3,"available_quantity":5,"available":true}]}`))
+	}))
+	defer ims.Close()
+
+	client := NewIMSClient(config.IMSConfig{BaseURL: ims.URL + "/", Timeout: time.Second})
+	ctx, span := tracing.Tracer().Start(logger.WithRequest(context.Background(), "req-42"), "allocate order")
+	defer span.End()
+
+	check, err := client.CheckAvailabilityBatch(ctx, []AvailabilityLine{{HubCode: "thane", SKUCode: "A", RequiredQuantity: 3}})
+	if err != nil || !check.CanFulfil {
+		t.Fatalf("got %+v, %v, want available", check, err)
+	}
+	if !strings.Contains(traceparent, span.SpanContext().TraceID().String()) {
+		t.Errorf("traceparent = %q, want the caller's trace id", traceparent)
+	}
+	if requestID != "req-42" {
+		t.Errorf("X-Request-ID = %q, want the OMS request id forwarded", requestID)
+	}
+}
+
+func TestCheckAvailabilityBatchReportsIMSErrors(t *testing.T) {
+	ims := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"HUB_NOT_FOUND","error":"Hub Not Found"}`))
+	}))
+	defer ims.Close()
+
+	client := NewIMSClient(config.IMSConfig{BaseURL: ims.URL, Timeout: time.Second})
+	_, err := client.CheckAvailabilityBatch(context.Background(), []AvailabilityLine{{HubCode: "nowhere", SKUCode: "A", RequiredQuantity: 1}})
+	if err == nil || !strings.Contains(err.Error(), "HUB_NOT_FOUND") {
+		t.Errorf("err = %v, want the IMS error code", err)
+	}
+}
+
+func TestGetHubStockGroupsAvailabilityByHub(t *testing.T) {
+	var codes []string
+	ims := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ims/inventory/availability" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			SKUCodes []string `json:"sku_codes"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		codes = body.SKUCodes
+		w.Write([]byte(`[
+			{"sku_code":"A","hubs":[{"hub_name":"thane","state":"Maharashtra","postal_code":"400601","country":"India","quantity":4}]},
+			{"sku_code":"B","hubs":[{"hub_name":"thane","quantity":2}]}
+		]`))
+	}))
+	defer ims.Close()
+
+	client := NewIMSClient(config.IMSConfig{BaseURL: ims.URL, Timeout: time.Second})
+	stock, err := client.GetHubStock(context.Background(), []string{"A", "B", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 3 {
+		t.Errorf("requested codes = %v, want all three", codes)
+	}
+	if len(stock) != 1 || stock[0].HubCode != "thane" || stock[0].PostalCode != "400601" {
+		t.Fatalf("stock = %+v, want only thane", stock)
+	}
+	if stock[0].Available["A"] != 4 || stock[0].Available["B"] != 2 {
+		t.Errorf("available = %v, want A:4 B:2", stock[0].Available)
+	}
+}
+
+func TestCheckAvailabilityBatch(t *testing.T) {
+	var lines []AvailabilityLine
+	ims := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&lines)
+		w.Write([]byte(`{"can_fulfil":false,"lines":[
+			{"index":0,"hub_code":"thane","sku_code":"A","required_quantity":2,"available_quantity":5,"available":true},
+			{"index":1,"hub_code":"pune","sku_code":"B","required_quantity":1,"reason":"Referenced Hub or SKU does not exist"}
+		]}`))
+	}))
+	defer ims.Close()
+
+	client := NewIMSClient(config.IMSConfig{BaseURL: ims.URL, Timeout: time.Second})
+	check, err := client.CheckAvailabilityBatch(context.Background(), []AvailabilityLine{
+		{HubCode: "thane", SKUCode: "A", RequiredQuantity: 2},
+		{HubCode: "pune", SKUCode: "B", RequiredQuantity: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[1].HubCode != "pune" {
+		t.Errorf("sent lines = %+v", lines)
+	}
+	if check.CanFulfil || !check.Lines[0].Available || check.Lines[1].Reason == "" {
+		t.Errorf("check = %+v, want line 1 unresolved", check)
+	}
+}
@@ -49,3 +49,12 @@ func (c *s3Client) UploadCSV(ctx context.Context, fileHeader *multipart.FileHead
 
 	return key, nil
 }
+
+// Ping checks that the bucket exists and the credentials can reach it.
+func (c *s3Client) Ping(ctx context.Context) error {
+	_, err := c.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(c.bucketName)})
+	if err != nil {
+		return fmt.Errorf("head bucket %s: %w", c.bucketName, err)
+	}
+	return nil
+}
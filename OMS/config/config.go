@@ -0,0 +1,364 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server  ServerConfig  `yaml:"server"`
+	Startup StartupConfig `yaml:"startup"`
+	Mongo   MongoConfig   `yaml:"mongo"`
+	Kafka   KafkaConfig   `yaml:"kafka"`
+	AWS     AWSConfig     `yaml:"aws"`
+	IMS     IMSConfig     `yaml:"ims"`
+	Tracing TracingConfig `yaml:"tracing"`
+	Logging LoggingConfig `yaml:"logging"`
+}
+
+type ServerConfig struct {
+	Port         string        `yaml:"port"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// ShutdownTimeout bounds how long in-flight requests may drain after
+	// SIGTERM before connection pools are closed anyway.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ReadinessTimeout bounds each dependency check behind /health/ready.
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout"`
+}
+
+// StartupConfig controls how long boot waits for dependencies before the
+// process exits non-zero.
+type StartupConfig struct {
+	Timeout        time.Duration `yaml:"timeout"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+}
+
+type MongoConfig struct {
+	URI            string        `yaml:"uri"`
+	Host           string        `yaml:"host"`
+	User           string        `yaml:"user"`
+	Password       string        `yaml:"password"`
+	Database       string        `yaml:"database"`
+	TLS            bool          `yaml:"tls"`
+	TLSCAFile      string        `yaml:"tls_ca_file"`
+	MaxPoolSize    uint64        `yaml:"max_pool_size"`
+	MinPoolSize    uint64        `yaml:"min_pool_size"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+}
+
+type KafkaConfig struct {
+	Brokers     []string `yaml:"brokers"`
+	ClientID    string   `yaml:"client_id"`
+	GroupID     string   `yaml:"group_id"`
+	AlertsTopic string   `yaml:"alerts_topic"`
+}
+
+type AWSConfig struct {
+	Region      string `yaml:"region"`
+	Endpoint    string `yaml:"endpoint"`
+	S3Bucket    string `yaml:"s3_bucket"`
+	SQSQueueURL string `yaml:"sqs_queue_url"`
+}
+
+type IMSConfig struct {
+	BaseURL string        `yaml:"base_url"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// TracingConfig selects where spans go: "none" keeps W3C propagation but
+// records nothing, "stdout" prints spans and "otlp" sends them over OTLP/HTTP.
+type TracingConfig = tracing.Config
+
+type LoggingConfig = logger.Config
+
+var (
+	logLevels  = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	logFormats = map[string]bool{"json": true, "text": true}
+)
+
+var tracingExporters = map[string]bool{"none": true, "stdout": true, "otlp": true}
+
+func Default() Config {
+	return Config{
+		// IMS listens on :8000, so OMS defaults to the next port to let both
+		// run on one host.
+		Server: ServerConfig{
+			Port:         ":8001",
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  70 * time.Second,
+
+			ShutdownTimeout:  15 * time.Second,
+			ReadinessTimeout: 2 * time.Second,
+		},
+		Startup: StartupConfig{
+			Timeout:        time.Minute,
+			InitialBackoff: 500 * time.Millisecond,
+			MaxBackoff:     10 * time.Second,
+		},
+		Mongo: MongoConfig{
+			Host:           "localhost:27017",
+			Database:       "oms",
+			MaxPoolSize:    100,
+			MinPoolSize:    5,
+			ConnectTimeout: 10 * time.Second,
+		},
+		Kafka: KafkaConfig{
+			Brokers:     []string{"localhost:9092"},
+			ClientID:    "oms",
+			GroupID:     "oms",
+			AlertsTopic: "ims.inventory.alerts",
+		},
+		AWS: AWSConfig{
+			Region:   "us-east-1",
+			Endpoint: "http://localhost:4566",
+			S3Bucket: "TEST",
+		},
+		IMS: IMSConfig{
+			BaseURL: "http://localhost:8000",
+			Timeout: 5 * time.Second,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
+		Logging: LoggingConfig{Level: "info", Format: "json"},
+	}
+}
+
+// Load starts from Default, applies the YAML file named by CONFIG_FILE if
+// set, then environment variables, and validates the result.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parse config %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) applyEnv() error {
+	env := &envReader{}
+	env.str("SERVER_PORT", &c.Server.Port)
+	env.duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	env.duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	env.duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	env.duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	env.duration("SERVER_READINESS_TIMEOUT", &c.Server.ReadinessTimeout)
+
+	env.duration("STARTUP_TIMEOUT", &c.Startup.Timeout)
+	env.duration("STARTUP_INITIAL_BACKOFF", &c.Startup.InitialBackoff)
+	env.duration("STARTUP_MAX_BACKOFF", &c.Startup.MaxBackoff)
+
+	env.str("MONGO_URI", &c.Mongo.URI)
+	env.str("MONGO_HOST", &c.Mongo.Host)
+	env.str("MONGO_INITDB_ROOT_USERNAME", &c.Mongo.User)
+	env.str("MONGO_INITDB_ROOT_PASSWORD", &c.Mongo.Password)
+	env.str("MONGO_DB_NAME", &c.Mongo.Database)
+	env.boolean("MONGO_TLS", &c.Mongo.TLS)
+	env.str("MONGO_TLS_CA_FILE", &c.Mongo.TLSCAFile)
+	env.uinteger("MONGO_MAX_POOL_SIZE", &c.Mongo.MaxPoolSize)
+	env.uinteger("MONGO_MIN_POOL_SIZE", &c.Mongo.MinPoolSize)
+	env.duration("MONGO_CONNECT_TIMEOUT", &c.Mongo.ConnectTimeout)
+
+	env.list("KAFKA_BROKERS", &c.Kafka.Brokers)
+	env.str("KAFKA_CLIENT_ID", &c.Kafka.ClientID)
+	env.str("KAFKA_GROUP_ID", &c.Kafka.GroupID)
+	env.str("KAFKA_ALERTS_TOPIC", &c.Kafka.AlertsTopic)
+
+	env.str("AWS_REGION", &c.AWS.Region)
+	env.str("LOCALSTACK_ENDPOINT", &c.AWS.Endpoint)
+	env.str("S3_BUCKET_NAME", &c.AWS.S3Bucket)
+	env.str("SQS_QUEUE_URL", &c.AWS.SQSQueueURL)
+
+	env.str("IMS_BASE_URL", &c.IMS.BaseURL)
+	env.duration("IMS_TIMEOUT", &c.IMS.Timeout)
+
+	env.str("TRACING_EXPORTER", &c.Tracing.Exporter)
+	env.str("OTEL_EXPORTER_OTLP_ENDPOINT", &c.Tracing.Endpoint)
+	env.boolean("TRACING_INSECURE", &c.Tracing.Insecure)
+	env.number("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	env.str("LOG_LEVEL", &c.Logging.Level)
+	env.str("LOG_FORMAT", &c.Logging.Format)
+	return errors.Join(env.errs...)
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, port, err := net.SplitHostPort(c.Server.Port); err != nil || port == "" {
+		errs = append(errs, fmt.Errorf("server.port %q must look like :8001 or host:8001", c.Server.Port))
+	}
+	check(c.Server.ReadTimeout > 0 && c.Server.WriteTimeout > 0 && c.Server.IdleTimeout > 0, "server timeouts must be positive")
+	check(c.Server.ShutdownTimeout > 0 && c.Server.ReadinessTimeout > 0, "server shutdown and readiness timeouts must be positive")
+
+	check(c.Startup.Timeout > 0, "startup.timeout must be positive")
+	check(c.Startup.InitialBackoff > 0 && c.Startup.InitialBackoff <= c.Startup.MaxBackoff,
+		"startup.initial_backoff must be positive and not exceed max_backoff")
+
+	check(c.Mongo.URI != "" || c.Mongo.Host != "", "mongo.uri or mongo.host is required")
+	check(c.Mongo.Database != "", "mongo.database is required")
+	check(c.Mongo.MaxPoolSize > 0 && c.Mongo.MinPoolSize <= c.Mongo.MaxPoolSize, "mongo.min_pool_size must not exceed a positive max_pool_size")
+	check(c.Mongo.ConnectTimeout > 0, "mongo.connect_timeout must be positive")
+	check(c.Mongo.TLSCAFile == "" || c.Mongo.TLS, "mongo.tls_ca_file requires mongo.tls")
+
+	check(len(c.Kafka.Brokers) > 0, "kafka.brokers is required")
+	check(c.Kafka.GroupID != "", "kafka.group_id is required")
+	check(c.Kafka.AlertsTopic != "", "kafka.alerts_topic is required")
+
+	check(c.AWS.Region != "", "aws.region is required")
+	check(c.AWS.S3Bucket != "", "aws.s3_bucket is required")
+	if c.AWS.Endpoint != "" {
+		_, err := url.ParseRequestURI(c.AWS.Endpoint)
+		check(err == nil, "aws.endpoint %q is not a valid URL", c.AWS.Endpoint)
+	}
+
+	_, err := url.ParseRequestURI(c.IMS.BaseURL)
+	check(err == nil, "ims.base_url %q is not a valid URL", c.IMS.BaseURL)
+	check(c.IMS.Timeout > 0, "ims.timeout must be positive")
+
+	check(tracingExporters[c.Tracing.Exporter], "tracing.exporter %q must be none, stdout or otlp", c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "otlp" || c.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(logLevels[strings.ToLower(c.Logging.Level)], "logging.level %q must be debug, info, warn or error", c.Logging.Level)
+	check(logFormats[strings.ToLower(c.Logging.Format)], "logging.format %q must be json or text", c.Logging.Format)
+
+	return errors.Join(errs...)
+}
+
+// ConnectionURI returns URI when set, otherwise it is built from the
+// individual fields.
+func (m MongoConfig) ConnectionURI() string {
+	if m.URI != "" {
+		return m.URI
+	}
+	u := url.URL{Scheme: "mongodb", Host: m.Host, Path: "/"}
+	if m.User != "" {
+		u.User = url.UserPassword(m.User, m.Password)
+	}
+	query := url.Values{}
+	if m.TLS {
+		query.Set("tls", "true")
+	}
+	if m.TLSCAFile != "" {
+		query.Set("tlsCAFile", m.TLSCAFile)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) lookup(key string) (string, bool) {
+	v, ok := os.LookupEnv(key)
+	return v, ok && v != ""
+}
+
+func (e *envReader) str(key string, dst *string) {
+	if v, ok := e.lookup(key); ok {
+		*dst = v
+	}
+}
+
+func (e *envReader) list(key string, dst *[]string) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	var values []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	*dst = values
+}
+
+func (e *envReader) boolean(key string, dst *bool) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a boolean", key, v))
+		return
+	}
+	*dst = b
+}
+
+func (e *envReader) uinteger(key string, dst *uint64) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a non-negative integer", key, v))
+		return
+	}
+	*dst = n
+}
+
+func (e *envReader) number(key string, dst *float64) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a number", key, v))
+		return
+	}
+	*dst = f
+}
+
+func (e *envReader) duration(key string, dst *time.Duration) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a duration like 10s or 5m", key, v))
+		return
+	}
+	*dst = d
+}
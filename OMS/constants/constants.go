@@ -18,18 +18,6 @@ const (
 	ErrInsufficientInventory = "Insufficient Inventory"
 	ErrBatchOperation        = "Batch Operation Failed"
 
-	RedisHost        = "localhost:6379"
-	RedisPoolSize    = 50
-	RedisMinIdleConn = 10
-
-	ServerPort         = ":8000"
-	ServerReadTimeout  = 10
-	ServerWriteTimeout = 10
-	ServerIdleTimeout  = 70
-
-	CacheTTLHubs = 5
-	CacheTTLSKUs = 5
-
 	CacheKeyHubID   = "hub:id:"
 	CacheKeyHubName = "hub:name:"
 	CacheKeySKUID   = "sku:id:"
@@ -52,4 +40,19 @@ const (
 	ErrInventoryReduce = "Failed to reduce inventory"
 	ErrInventoryView   = "Failed to view inventory"
 	ErrAtomicOperation = "Atomic operation failed"
+
+	OrdersCollection = "orders"
+
+	ErrAllocation        = "Failed to allocate order"
+	ErrInvalidAllocation = "items need a sku_code and a positive quantity"
+
+	ErrCodeInvalidJSON           = "INVALID_JSON"
+	ErrCodeInvalidRequest        = "INVALID_REQUEST"
+	ErrCodeInsufficientInventory = "INSUFFICIENT_INVENTORY"
+	ErrCodeIMSUnavailable        = "IMS_UNAVAILABLE"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	HeaderTenantID  = "X-Tenant-ID"
 )
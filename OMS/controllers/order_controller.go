@@ -1,20 +1,67 @@
 package controllers
 
 import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Trishank-omniful/Onboarding-Task/allocation"
 	"github.com/Trishank-omniful/Onboarding-Task/clients"
+	"github.com/Trishank-omniful/Onboarding-Task/constants"
+	"github.com/Trishank-omniful/Onboarding-Task/models"
 	"github.com/gin-gonic/gin"
 )
 
 type OrderController struct {
-	s3Client clients.S3ClientInterface
+	s3Client  clients.S3ClientInterface
+	allocator allocation.ServiceInterface
 }
 
-func NewOrderController(s3Client clients.S3ClientInterface) *OrderController {
+func NewOrderController(s3Client clients.S3ClientInterface, allocator allocation.ServiceInterface) *OrderController {
 	return &OrderController{
-		s3Client: s3Client,
+		s3Client:  s3Client,
+		allocator: allocator,
 	}
 }
 
 func (c *OrderController) BulkUploadCSV(g *gin.Context) {
 
 }
+
+// AllocateOrder splits order lines into hub-level shipments for the
+// shipping address, based on current IMS stock.
+func (c *OrderController) AllocateOrder(g *gin.Context) {
+	var request struct {
+		Items   []models.OrderItem `json:"items"`
+		Address models.Address     `json:"address"`
+	}
+	if err := g.ShouldBindJSON(&request); err != nil {
+		g.JSON(http.StatusBadRequest, gin.H{"code": constants.ErrCodeInvalidJSON, "error": constants.ErrParsingJSON})
+		return
+	}
+	valid := len(request.Items) > 0
+	for _, item := range request.Items {
+		valid = valid && item.SKUCode != "" && item.Quantity > 0
+	}
+	if !valid {
+		g.JSON(http.StatusBadRequest, gin.H{"code": constants.ErrCodeInvalidRequest, "error": constants.ErrInvalidAllocation})
+		return
+	}
+
+	shipments, err := c.allocator.Allocate(g.Request.Context(), request.Items, request.Address)
+	var insufficient *allocation.InsufficientStockError
+	switch {
+	case errors.As(err, &insufficient):
+		g.JSON(http.StatusConflict, gin.H{
+			"code":    constants.ErrCodeInsufficientInventory,
+			"error":   constants.ErrInsufficientInventory,
+			"details": insufficient.Shortfalls,
+		})
+		return
+	case err != nil:
+		slog.ErrorContext(g.Request.Context(), "order allocation failed", "error", err)
+		g.JSON(http.StatusBadGateway, gin.H{"code": constants.ErrCodeIMSUnavailable, "error": constants.ErrAllocation})
+		return
+	}
+	g.JSON(http.StatusOK, gin.H{"shipments": shipments})
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Trishank-omniful/Onboarding-Task/config"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+)
+
+func ConnectMongo(ctx context.Context, cfg config.MongoConfig) (*mongo.Client, error) {
+	opts := options.Client().
+		ApplyURI(cfg.ConnectionURI()).
+		SetMaxPoolSize(cfg.MaxPoolSize).
+		SetMinPoolSize(cfg.MinPoolSize).
+		SetConnectTimeout(cfg.ConnectTimeout).
+		SetMonitor(otelmongo.NewMonitor())
+
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("connect to mongo: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		return nil, fmt.Errorf("ping mongo: %w", err)
+	}
+	slog.InfoContext(ctx, "mongo connected", "database", cfg.Database)
+	return client, nil
+}
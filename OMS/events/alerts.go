@@ -0,0 +1,91 @@
+// Package events consumes the Kafka topics other services publish.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Trishank-omniful/Onboarding-Task/config"
+	"github.com/Trishank-omniful/Onboarding-Task/metrics"
+	"github.com/segmentio/kafka-go"
+)
+
+// StockAlert is the part of an IMS inventory alert OMS reads.
+type StockAlert struct {
+	ID       uint   `json:"id"`
+	HubID    uint   `json:"hub_id"`
+	SKUID    uint   `json:"sku_id"`
+	Level    string `json:"level"`
+	Quantity int    `json:"quantity"`
+}
+
+// AlertConsumer reads the IMS stock alerts topic in the OMS consumer group.
+// OMS keeps no stock of its own, so alerts are logged next to the order
+// failures they explain. The reader's lag is exported after every message.
+type AlertConsumer struct {
+	Reader *kafka.Reader
+	group  string
+	stop   context.CancelFunc
+	done   chan struct{}
+}
+
+func NewAlertConsumer(cfg config.KafkaConfig) *AlertConsumer {
+	return &AlertConsumer{
+		Reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: cfg.Brokers,
+			GroupID: cfg.GroupID,
+			Topic:   cfg.AlertsTopic,
+			Dialer:  &kafka.Dialer{ClientID: cfg.ClientID, Timeout: 10 * time.Second, DualStack: true},
+		}),
+		group: cfg.GroupID,
+	}
+}
+
+// Start reads alerts until Close. Offsets are committed by the consumer
+// group as messages are read.
+func (c *AlertConsumer) Start() {
+	ctx, stop := context.WithCancel(context.Background())
+	c.stop, c.done = stop, make(chan struct{})
+	go func() {
+		defer close(c.done)
+		for {
+			msg, err := c.Reader.ReadMessage(ctx)
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				slog.Error("failed to read stock alert", "topic", c.Reader.Config().Topic, "error", err)
+				continue
+			}
+			handleAlert(ctx, msg)
+			metrics.KafkaConsumerLag.WithLabelValues(msg.Topic, c.group).Set(float64(c.Reader.Stats().Lag))
+		}
+	}()
+}
+
+// Close stops reading, waiting up to ctx for the alert in hand, and leaves
+// the consumer group.
+func (c *AlertConsumer) Close(ctx context.Context) error {
+	if c.stop != nil {
+		c.stop()
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+		}
+	}
+	return c.Reader.Close()
+}
+
+func handleAlert(ctx context.Context, msg kafka.Message) {
+	var alert StockAlert
+	if err := json.Unmarshal(msg.Value, &alert); err != nil {
+		slog.ErrorContext(ctx, "malformed stock alert", "partition", msg.Partition, "offset", msg.Offset, "error", err)
+		return
+	}
+	slog.WarnContext(ctx, "stock alert received",
+		"alert_id", alert.ID, "hub_id", alert.HubID, "sku_id", alert.SKUID, "level", alert.Level, "quantity", alert.Quantity)
+}
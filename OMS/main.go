@@ -2,46 +2,138 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"log"
+	"log/slog"
+	stdhttp "net/http"
 	"os"
-	"time"
 
+	"github.com/Trishank-Omniful/Onboarding-Task/common/lifecycle"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-Omniful/Onboarding-Task/common/tracing"
+	"github.com/Trishank-omniful/Onboarding-Task/allocation"
+	"github.com/Trishank-omniful/Onboarding-Task/clients"
 	"github.com/Trishank-omniful/Onboarding-Task/config"
-	"github.com/Trishank-omniful/Onboarding-Task/constants"
+	"github.com/Trishank-omniful/Onboarding-Task/controllers"
+	"github.com/Trishank-omniful/Onboarding-Task/db"
+	"github.com/Trishank-omniful/Onboarding-Task/events"
+	"github.com/Trishank-omniful/Onboarding-Task/metrics"
+	"github.com/Trishank-omniful/Onboarding-Task/middleware"
+	"github.com/Trishank-omniful/Onboarding-Task/routes"
 	"github.com/joho/godotenv"
 	"github.com/omniful/go_commons/http"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func init() {
+	// .env is a convenience for local runs; containers set the environment
+	// directly and ship without one.
 	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Failed to Load env file")
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Print("No .env file, using the environment only")
+	} else if err != nil {
+		log.Fatal("Failed to Load env file: ", err)
 	}
 }
 
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+	if err := logger.Init(os.Stdout, "OMS", cfg.Logging); err != nil {
+		log.Fatal("Invalid logging configuration: ", err)
+	}
+
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] != "serve" {
+		os.Exit(runCommand(cfg, args))
+	}
+	serve(cfg)
+}
+
+func serve(cfg *config.Config) {
 	ctx := context.Background()
+	startup := lifecycle.RetryPolicy{
+		Timeout:        cfg.Startup.Timeout,
+		InitialBackoff: cfg.Startup.InitialBackoff,
+		MaxBackoff:     cfg.Startup.MaxBackoff,
+	}
 
-	s3, err := config.LoadAWSConfig(ctx)
+	shutdownTracing, err := tracing.Init(ctx, "OMS", cfg.Tracing)
+	if err != nil {
+		fatal("cannot start OMS", err)
+	}
 
+	var mongoClient *mongo.Client
+	err = lifecycle.Retry(ctx, "Mongo", startup, func(ctx context.Context) error {
+		client, err := db.ConnectMongo(ctx, cfg.Mongo)
+		mongoClient = client
+		return err
+	})
 	if err != nil {
-		log.Fatalf("Failed to initialize S3 client: %v", err)
+		fatal("cannot start OMS", err)
 	}
 
-	s3Bucket := os.Getenv("S3_BUCKET_NAME")
-	if s3Bucket == "" {
-		log.Printf("S3 Bucket not set. Switching to default: TEST")
-		s3Bucket = "TEST"
+	s3, err := config.LoadAWSConfig(ctx, cfg.AWS)
+
+	if err != nil {
+		fatal("failed to initialize S3 client", err)
+	}
+	s3Client := clients.NewS3Client(s3, cfg.AWS.S3Bucket)
+	if err := lifecycle.Retry(ctx, "S3", startup, s3Client.Ping); err != nil {
+		mongoClient.Disconnect(ctx)
+		fatal("cannot start OMS", err)
 	}
 
 	server := http.InitializeServer(
-		constants.ServerPort,
-		time.Duration(constants.ServerReadTimeout)*time.Second,
-		time.Duration(constants.ServerWriteTimeout)*time.Second,
-		time.Duration(constants.ServerIdleTimeout)*time.Second,
+		cfg.Server.Port,
+		cfg.Server.ReadTimeout,
+		cfg.Server.WriteTimeout,
+		cfg.Server.IdleTimeout,
 		false,
 	)
+	server.Engine.Use(otelgin.Middleware("OMS", otelgin.WithFilter(tracing.SkipProbes)))
+	server.Engine.Use(middleware.RequestIDMiddleware())
+	server.Engine.Use(middleware.MetricsMiddleware())
+	server.Engine.Use(middleware.LoggingMiddleware())
+
+	health := lifecycle.NewHealth("OMS", cfg.Server.ReadinessTimeout)
+	health.AddCheck("mongo", func(ctx context.Context) error { return mongoClient.Ping(ctx, nil) })
+	health.AddCheck("s3", s3Client.Ping)
+	health.Register(server.Engine)
+	server.GET("/metrics", metrics.Handler())
+
+	allocator := allocation.NewService(clients.NewIMSClient(cfg.IMS))
+	orderController := controllers.NewOrderController(s3Client, allocator)
+	api := server.Engine.Group("/api/v1")
+	routes.RegisterOMSRoutes(api, orderController)
+
+	alerts := events.NewAlertConsumer(cfg.Kafka)
+	alerts.Start()
 
-	oms := server.Engine.Group("/api/v1/oms")
+	// The go_commons server has no shutdown hook, so its engine is served by a
+	// stdlib server that can drain in-flight requests on SIGTERM.
+	srv := &stdhttp.Server{
+		Addr:         cfg.Server.Port,
+		Handler:      server.Engine,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+	err = lifecycle.Serve("OMS", srv, health, cfg.Server.ShutdownTimeout,
+		lifecycle.Closer{Name: "Tracing", Close: shutdownTracing},
+		lifecycle.Closer{Name: "Mongo", Close: mongoClient.Disconnect},
+		lifecycle.Closer{Name: "Kafka", Close: alerts.Close},
+	)
+	if err != nil {
+		fatal("server failed", err)
+	}
+}
 
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
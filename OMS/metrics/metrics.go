@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "oms"
+
+// Registry holds every OMS collector. A dedicated registry keeps tests and
+// the CLI free of the global default registry.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template and status code.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	// KafkaConsumerLag is set by consumers after each message to the reader's
+	// lag: the partition high-water mark minus the offset just read.
+	KafkaConsumerLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages between the last offset read and the partition high-water mark.",
+	}, []string{"topic", "group"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		KafkaConsumerLag,
+	)
+}
+
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
@@ -0,0 +1,54 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-omniful/Onboarding-Task/metrics"
+	"github.com/Trishank-omniful/Onboarding-Task/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsMiddlewareLabelsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.MetricsMiddleware())
+	router.GET("/orders/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/metrics", metrics.Handler())
+
+	for _, path := range []string{"/orders/1", "/orders/2", "/nope"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if n := testutil.CollectAndCount(metrics.HTTPRequestDuration); n != 2 {
+		t.Errorf("got %d series, want one for /orders/:id and one for unmatched paths", n)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`oms_http_request_duration_seconds_count{method="GET",route="/orders/:id",status="204"} 2`,
+		`oms_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics is missing %s", want)
+		}
+	}
+}
+
+func TestKafkaConsumerLagIsExported(t *testing.T) {
+	metrics.KafkaConsumerLag.WithLabelValues("ims.inventory.alerts", "oms").Set(3)
+
+	router := gin.New()
+	router.GET("/metrics", metrics.Handler())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	want := `oms_kafka_consumer_lag{group="oms",topic="ims.inventory.alerts"} 3`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("/metrics is missing %s", want)
+	}
+}
@@ -0,0 +1,95 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Trishank-Omniful/Onboarding-Task/common/logger"
+	"github.com/Trishank-omniful/Onboarding-Task/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func captureLogs(t *testing.T, level string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	if err := logger.Init(&buf, "OMS", logger.Config{Level: level, Format: "json"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func router() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RequestIDMiddleware(), middleware.LoggingMiddleware())
+	r.POST("/api/v1/oms/orders/allocate", func(c *gin.Context) {
+		slog.DebugContext(c.Request.Context(), "allocating order")
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequestLogsCarryRequestContext(t *testing.T) {
+	buf := captureLogs(t, "debug")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/oms/orders/allocate", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	req.Header.Set("X-Tenant-ID", "tenant_1")
+	w := httptest.NewRecorder()
+	router().ServeHTTP(w, req)
+
+	if got := w.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("response X-Request-ID = %q, want the caller's id echoed", got)
+	}
+	entries := lines(t, buf)
+	if len(entries) != 2 {
+		t.Fatalf("got %d log lines, want the handler line and the access line", len(entries))
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "req-123" || entry["tenant_id"] != "tenant_1" || entry["route"] != "/api/v1/oms/orders/allocate" || entry["service"] != "OMS" {
+			t.Errorf("entry %v is missing request fields", entry)
+		}
+	}
+	access := entries[1]
+	if access["msg"] != "http request" || access["status"] != float64(200) || access["latency_ms"] == nil {
+		t.Errorf("access line = %v", access)
+	}
+}
+
+func TestRequestIDIsGeneratedWhenMissingOrMalformed(t *testing.T) {
+	buf := captureLogs(t, "info")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/oms/orders/allocate", nil)
+	req.Header.Set("X-Request-ID", "bad id\nwith newline")
+	w := httptest.NewRecorder()
+	router().ServeHTTP(w, req)
+
+	id := w.Header().Get("X-Request-ID")
+	if len(id) != 32 {
+		t.Errorf("X-Request-ID = %q, want a generated 32-char id", id)
+	}
+	entries := lines(t, buf)
+	if len(entries) != 1 || entries[0]["request_id"] != id {
+		t.Errorf("entries = %v, want only the access line with the generated id", entries)
+	}
+}
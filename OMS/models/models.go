@@ -22,6 +22,7 @@ type Order struct {
 	ReferenceID    string              `json:"reference_id" bson:"reference_id"`
 	Status         OrderStatus         `json:"status" bson:"status"`
 	Items          []OrderItem         `json:"items" bson:"items"`
+	Shipments      []Shipment          `json:"shipments,omitempty" bson:"shipments,omitempty"`
 	CustomerInfo   CustomerInfo        `json:"customer_info" bson:"customer_info"`
 	ShippingInfo   ShippingInfo        `json:"shipping_info" bson:"shipping_info"`
 	PaymentInfo    PaymentInfo         `json:"payment_info" bson:"payment_info"`
@@ -40,6 +41,13 @@ type OrderItem struct {
 	UnitPrice float64 `json:"unit_price" bson:"unit_price"`
 }
 
+// Shipment is the part of an order fulfilled from one hub. Its items carry
+// that hub's code.
+type Shipment struct {
+	HubCode string      `json:"hub_code" bson:"hub_code"`
+	Items   []OrderItem `json:"items" bson:"items"`
+}
+
 type CustomerInfo struct {
 	FirstName string  `json:"first_name" bson:"first_name"`
 	LastName  string  `json:"last_name" bson:"last_name"`
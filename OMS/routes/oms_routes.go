@@ -1,10 +1,14 @@
 package routes
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/Trishank-omniful/Onboarding-Task/controllers"
+	"github.com/gin-gonic/gin"
+)
 
-func RegisterOMSRoutes(router *gin.RouterGroup) {
+func RegisterOMSRoutes(router *gin.RouterGroup, orderCtrl *controllers.OrderController) {
 	omsGroup := router.Group("oms")
 	{
-		omsGroup.POST("/orders/buld-upload", orderCtrl.BulkUploadCSV)
+		omsGroup.POST("/orders/bulk-upload", orderCtrl.BulkUploadCSV)
+		omsGroup.POST("/orders/allocate", orderCtrl.AllocateOrder)
 	}
 }
@@ -0,0 +1,221 @@
+package seeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Trishank-omniful/Onboarding-Task/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Catalog mirrors the file written by `ims seed -catalog`, so generated
+// orders only reference hubs and SKUs that exist in IMS.
+type Catalog struct {
+	Hubs []CatalogHub `json:"hubs"`
+	SKUs []CatalogSKU `json:"skus"`
+}
+
+type CatalogHub struct {
+	Name       string `json:"name"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postal_code"`
+}
+
+type CatalogSKU struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	TenantId string   `json:"tenant_id"`
+	SellerId string   `json:"seller_id"`
+	Price    *float64 `json:"price"`
+}
+
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("parse catalog %s: %w", path, err)
+	}
+	return &catalog, nil
+}
+
+var (
+	firstNames = []string{"Aarav", "Diya", "Ishaan", "Meera", "Kabir", "Ananya", "Rohan", "Saanvi", "Vikram", "Priya"}
+	lastNames  = []string{"Sharma", "Iyer", "Reddy", "Patel", "Gupta", "Nair", "Banerjee", "Singh", "Rao", "Mehta"}
+	streets    = []string{"MG Road", "Park Street", "Lake View Road", "Hill Road", "Church Street", "Main Bazaar"}
+	shipping   = []string{"standard", "express", "same_day"}
+	payments   = []string{"upi", "card", "cod", "netbanking"}
+)
+
+// statusWeights roughly matches a live order book: mostly new orders with a
+// tail of completed, held and canceled ones.
+var statusWeights = []struct {
+	status models.OrderStatus
+	weight int
+}{
+	{models.OrderStatusNew, 70},
+	{models.OrderStatusCompleted, 15},
+	{models.OrderStatusOnHold, 10},
+	{models.OrderStatusCanceled, 5},
+}
+
+type OrderSeeder struct {
+	Collection *mongo.Collection
+	Catalog    *Catalog
+	BatchSize  int
+	rng        *rand.Rand
+	sellers    [][]CatalogSKU
+	now        time.Time
+}
+
+func NewOrderSeeder(collection *mongo.Collection, catalog *Catalog, seed int64, batchSize int) (*OrderSeeder, error) {
+	if len(catalog.Hubs) == 0 {
+		return nil, fmt.Errorf("catalog has no hubs")
+	}
+
+	bySeller := make(map[string][]CatalogSKU)
+	var order []string
+	for _, sku := range catalog.SKUs {
+		if sku.Price == nil {
+			continue
+		}
+		key := sku.TenantId + "/" + sku.SellerId
+		if _, ok := bySeller[key]; !ok {
+			order = append(order, key)
+		}
+		bySeller[key] = append(bySeller[key], sku)
+	}
+	if len(order) == 0 {
+		return nil, fmt.Errorf("catalog has no priced skus")
+	}
+	sellers := make([][]CatalogSKU, len(order))
+	for i, key := range order {
+		sellers[i] = bySeller[key]
+	}
+
+	return &OrderSeeder{
+		Collection: collection,
+		Catalog:    catalog,
+		BatchSize:  batchSize,
+		rng:        rand.New(rand.NewSource(seed)),
+		sellers:    sellers,
+		now:        time.Now().UTC(),
+	}, nil
+}
+
+// Generate builds the i-th order. Every item comes from a single seller, and
+// the customer lives near one of the hubs so allocation has real choices.
+func (s *OrderSeeder) Generate(i int) models.Order {
+	skus := s.sellers[s.rng.Intn(len(s.sellers))]
+	hub := s.Catalog.Hubs[s.rng.Intn(len(s.Catalog.Hubs))]
+	first, last := firstNames[s.rng.Intn(len(firstNames))], lastNames[s.rng.Intn(len(lastNames))]
+
+	address := models.Address{
+		Street:  fmt.Sprintf("%d, %s", 1+s.rng.Intn(500), streets[s.rng.Intn(len(streets))]),
+		City:    hub.City,
+		State:   hub.State,
+		ZipCode: hub.PostalCode,
+		Country: hub.Country,
+	}
+
+	lines := 1 + s.rng.Intn(4)
+	if lines > len(skus) {
+		lines = len(skus)
+	}
+	items := make([]models.OrderItem, 0, lines)
+	total := 0.0
+	for _, j := range s.rng.Perm(len(skus))[:lines] {
+		item := models.OrderItem{
+			SKUCode:   skus[j].Code,
+			HubCode:   hub.Name,
+			Quantity:  1 + s.rng.Intn(5),
+			UnitPrice: *skus[j].Price,
+		}
+		total += float64(item.Quantity) * item.UnitPrice
+		items = append(items, item)
+	}
+	total = math.Round(total*100) / 100
+
+	orderDate := s.now.Add(-time.Duration(s.rng.Int63n(int64(90 * 24 * time.Hour))))
+	status := s.status()
+	history := []models.OrderHistoryEvent{{
+		Timestamp: orderDate, NewStatus: models.OrderStatusNew, Description: "Order created", Actor: "seeder",
+	}}
+	lastUpdated := orderDate
+	if status != models.OrderStatusNew {
+		lastUpdated = orderDate.Add(time.Duration(1+s.rng.Intn(72)) * time.Hour)
+		history = append(history, models.OrderHistoryEvent{
+			Timestamp: lastUpdated, OldStatus: models.OrderStatusNew, NewStatus: status,
+			Description: "Status changed to " + string(status), Actor: "seeder",
+		})
+	}
+
+	payment := models.PaymentInfo{Method: payments[s.rng.Intn(len(payments))], PaymentStatus: "pending"}
+	if payment.Method != "cod" || status == models.OrderStatusCompleted {
+		paidAt := orderDate
+		payment.PaymentStatus, payment.AmountPaid, payment.PaidAt = "paid", total, &paidAt
+		payment.TransactionID = fmt.Sprintf("TXN%010d", i+1)
+	}
+
+	return models.Order{
+		TenantID:    skus[0].TenantId,
+		SellerID:    skus[0].SellerId,
+		ReferenceID: fmt.Sprintf("SEED-%08d", i+1),
+		Status:      status,
+		Items:       items,
+		CustomerInfo: models.CustomerInfo{
+			FirstName: first,
+			LastName:  last,
+			Email:     fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), i+1),
+			Phone:     fmt.Sprintf("+91 9%09d", s.rng.Intn(1e9)),
+			Address:   address,
+		},
+		ShippingInfo: models.ShippingInfo{Method: shipping[s.rng.Intn(len(shipping))], Address: address},
+		PaymentInfo:  payment,
+		TotalAmount:  total,
+		Currency:     "INR",
+		OrderDate:    orderDate,
+		LastUpdated:  lastUpdated,
+		History:      history,
+	}
+}
+
+func (s *OrderSeeder) Run(ctx context.Context, count int) (int, error) {
+	inserted := 0
+	batch := make([]interface{}, 0, s.BatchSize)
+	for i := 0; i < count; i++ {
+		batch = append(batch, s.Generate(i))
+		if len(batch) < s.BatchSize && i < count-1 {
+			continue
+		}
+		if _, err := s.Collection.InsertMany(ctx, batch); err != nil {
+			return inserted, fmt.Errorf("insert orders: %w", err)
+		}
+		inserted += len(batch)
+		batch = batch[:0]
+		log.Printf("Seeded %d/%d orders", inserted, count)
+	}
+	return inserted, nil
+}
+
+func (s *OrderSeeder) status() models.OrderStatus {
+	n := s.rng.Intn(100)
+	for _, w := range statusWeights {
+		if n < w.weight {
+			return w.status
+		}
+		n -= w.weight
+	}
+	return models.OrderStatusNew
+}
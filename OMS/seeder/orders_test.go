@@ -0,0 +1,64 @@
+package seeder
+
+import (
+	"math"
+	"testing"
+)
+
+func testCatalog() *Catalog {
+	price := func(v float64) *float64 { return &v }
+	return &Catalog{
+		Hubs: []CatalogHub{
+			{Name: "Mumbai Warehouse 0001", City: "Mumbai", State: "Maharashtra", Country: "India", PostalCode: "400001"},
+			{Name: "Pune Dark Store 0002", City: "Pune", State: "Maharashtra", Country: "India", PostalCode: "411001"},
+		},
+		SKUs: []CatalogSKU{
+			{Code: "T01-ELEC-0000001", TenantId: "tenant_01", SellerId: "tenant_01_seller_01", Price: price(999)},
+			{Code: "T01-ELEC-0000002", TenantId: "tenant_01", SellerId: "tenant_01_seller_01", Price: price(49.5)},
+			{Code: "T02-GROC-0000003", TenantId: "tenant_02", SellerId: "tenant_02_seller_01", Price: price(120)},
+			{Code: "T02-GROC-0000004", TenantId: "tenant_02", SellerId: "tenant_02_seller_01"},
+		},
+	}
+}
+
+func TestGenerateOrdersReferenceTheCatalog(t *testing.T) {
+	catalog := testCatalog()
+	skus := make(map[string]CatalogSKU)
+	for _, sku := range catalog.SKUs {
+		skus[sku.Code] = sku
+	}
+
+	s, err := NewOrderSeeder(nil, catalog, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		order := s.Generate(i)
+		if len(order.Items) == 0 {
+			t.Fatalf("order %d has no items", i)
+		}
+		total := 0.0
+		for _, item := range order.Items {
+			sku, ok := skus[item.SKUCode]
+			if !ok || sku.Price == nil {
+				t.Fatalf("order %d references unknown or unpriced sku %q", i, item.SKUCode)
+			}
+			if sku.TenantId != order.TenantID || sku.SellerId != order.SellerID {
+				t.Fatalf("order %d mixes sellers", i)
+			}
+			total += float64(item.Quantity) * item.UnitPrice
+		}
+		if math.Abs(total-order.TotalAmount) > 0.01 {
+			t.Fatalf("order %d total = %v, want %v", i, order.TotalAmount, total)
+		}
+		if len(order.History) == 0 || order.History[len(order.History)-1].NewStatus != order.Status {
+			t.Fatalf("order %d history does not end in its status", i)
+		}
+	}
+}
+
+func TestNewOrderSeederRejectsEmptyCatalog(t *testing.T) {
+	if _, err := NewOrderSeeder(nil, &Catalog{}, 1, 100); err == nil {
+		t.Error("expected an error for a catalog without hubs")
+	}
+}
@@ -0,0 +1,119 @@
+package lifecycle
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type CheckResult struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type HealthResponse struct {
+	Status  string                 `json:"status"`
+	Service string                 `json:"service"`
+	Checks  map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Health serves liveness and readiness. Liveness only says the process is
+// serving; readiness pings every registered dependency and turns 503 while
+// any of them is down or the service is shutting down.
+type Health struct {
+	Service  string
+	Timeout  time.Duration
+	mu       sync.RWMutex
+	checks   map[string]func(context.Context) error
+	draining atomic.Bool
+}
+
+func NewHealth(service string, timeout time.Duration) *Health {
+	return &Health{Service: service, Timeout: timeout, checks: make(map[string]func(context.Context) error)}
+}
+
+func (h *Health) AddCheck(name string, check func(context.Context) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+func (h *Health) SetDraining() {
+	h.draining.Store(true)
+}
+
+func (h *Health) Register(router gin.IRoutes) {
+	router.GET("/health", h.Live)
+	router.GET("/health/live", h.Live)
+	router.GET("/health/ready", h.Ready)
+}
+
+func (h *Health) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok", Service: h.Service})
+}
+
+func (h *Health) Ready(c *gin.Context) {
+	checks := h.Check(c.Request.Context())
+
+	status, code := "ok", http.StatusOK
+	if h.draining.Load() {
+		status, code = "draining", http.StatusServiceUnavailable
+	}
+	for _, result := range checks {
+		if result.Status != StatusUp {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(code, HealthResponse{Status: status, Service: h.Service, Checks: checks})
+}
+
+// Check runs every dependency check concurrently, each bounded by Timeout.
+func (h *Health) Check(ctx context.Context) map[string]CheckResult {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h.mu.RUnlock()
+
+	results := make(map[string]CheckResult, len(names))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range names {
+		h.mu.RLock()
+		check := h.checks[name]
+		h.mu.RUnlock()
+
+		wg.Add(1)
+		go func(name string, check func(context.Context) error) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			result := CheckResult{Status: StatusUp}
+			if err := check(ctx); err != nil {
+				result = CheckResult{Status: StatusDown, Error: err.Error()}
+			}
+			result.LatencyMS = time.Since(start).Milliseconds()
+
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+	return results
+}
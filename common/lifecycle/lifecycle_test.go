@@ -0,0 +1,100 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var fastRetry = RetryPolicy{Timeout: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), "dep", fastRetry, func(context.Context) error {
+		if attempts++; attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Fatalf("err = %v, attempts = %d, want success on the third attempt", err, attempts)
+	}
+}
+
+func TestRetryGivesUpAtTimeout(t *testing.T) {
+	refused := errors.New("connection refused")
+	policy := fastRetry
+	policy.Timeout = 20 * time.Millisecond
+
+	start := time.Now()
+	err := Retry(context.Background(), "dep", policy, func(context.Context) error { return refused })
+	if !errors.Is(err, refused) {
+		t.Fatalf("err = %v, want the last attempt's error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("retry ran for %s, want it bounded by the policy timeout", elapsed)
+	}
+}
+
+func readiness(t *testing.T, h *Health) (int, HealthResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h.Register(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	var body HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode readiness: %v", err)
+	}
+	return w.Code, body
+}
+
+func TestReadyReportsEachDependency(t *testing.T) {
+	h := NewHealth("IMS", 50*time.Millisecond)
+	h.AddCheck("postgres", func(context.Context) error { return nil })
+	h.AddCheck("redis", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	code, body := readiness(t, h)
+	if code != http.StatusServiceUnavailable || body.Status != "unavailable" {
+		t.Fatalf("got %d %q, want 503 while redis is down", code, body.Status)
+	}
+	if body.Checks["postgres"].Status != StatusUp {
+		t.Errorf("postgres = %+v, want up", body.Checks["postgres"])
+	}
+	if redis := body.Checks["redis"]; redis.Status != StatusDown || redis.Error == "" {
+		t.Errorf("redis = %+v, want down with the timeout error", redis)
+	}
+}
+
+func TestReadyFailsWhileDrainingButLiveStaysUp(t *testing.T) {
+	h := NewHealth("IMS", time.Second)
+	h.AddCheck("postgres", func(context.Context) error { return nil })
+
+	if code, _ := readiness(t, h); code != http.StatusOK {
+		t.Fatalf("ready = %d, want 200 with healthy dependencies", code)
+	}
+
+	h.SetDraining()
+	if code, body := readiness(t, h); code != http.StatusServiceUnavailable || body.Status != "draining" {
+		t.Errorf("ready = %d %q, want 503 draining after shutdown starts", code, body.Status)
+	}
+
+	router := gin.New()
+	h.Register(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("live = %d, want 200 while draining", w.Code)
+	}
+}
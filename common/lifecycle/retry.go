@@ -0,0 +1,45 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+type RetryPolicy struct {
+	Timeout        time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Retry calls fn until it succeeds, backing off exponentially with jitter,
+// and gives up with the last error once policy.Timeout has elapsed.
+func Retry(ctx context.Context, name string, policy RetryPolicy, fn func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, policy.Timeout)
+	defer cancel()
+
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			if attempt > 1 {
+				slog.InfoContext(ctx, "dependency available", "dependency", name, "attempts", attempt)
+			}
+			return nil
+		}
+
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		slog.WarnContext(ctx, "dependency unavailable, retrying", "dependency", name, "attempt", attempt, "retry_in", wait.Round(time.Millisecond).String(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s unavailable after %d attempts: %w", name, attempt, err)
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Closer releases a resource during shutdown, e.g. a connection pool or a
+// consumer.
+type Closer struct {
+	Name  string
+	Close func(context.Context) error
+}
+
+// Serve runs srv until it fails or the process receives SIGINT/SIGTERM. On a
+// signal it marks health as draining, stops accepting connections, waits up
+// to timeout for in-flight requests, then runs closers in reverse order.
+func Serve(name string, srv *http.Server, health *Health, timeout time.Duration, closers ...Closer) error {
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("server listening", "service", name, "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	var serveErr error
+	select {
+	case serveErr = <-errCh:
+		slog.Error("server stopped", "service", name, "error", serveErr)
+	case sig := <-stop:
+		slog.Info("shutting down", "service", name, "signal", sig.String())
+	}
+
+	health.SetDraining()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Warn("in-flight requests did not drain", "service", name, "timeout", timeout.String(), "error", err)
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(ctx); err != nil {
+			slog.Error("failed to close dependency", "dependency", closers[i].Name, "error", err)
+			continue
+		}
+		slog.Info("closed dependency", "dependency", closers[i].Name)
+	}
+	return serveErr
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config selects the minimum level (debug, info, warn or error) and the
+// output format (json or text).
+type Config struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type ctxKey struct{}
+
+// fields are the request-scoped attributes attached to every line logged
+// with a context that went through the request middleware.
+type fields struct {
+	requestID string
+	attrs     []slog.Attr
+}
+
+// New builds a leveled JSON (or text) logger whose records pick up the
+// request ID, tenant, route and trace IDs stored in the logging context.
+func New(w io.Writer, service string, cfg Config) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("log level %q: %w", cfg.Level, err)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{handler}).With("service", service), nil
+}
+
+// Init installs New as the slog default, which also routes the standard log
+// package through it.
+func Init(w io.Writer, service string, cfg Config) error {
+	l, err := New(w, service, cfg)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(l)
+	return nil
+}
+
+// WithRequest stores the request ID and any extra attributes on ctx.
+func WithRequest(ctx context.Context, requestID string, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, ctxKey{}, fields{requestID: requestID, attrs: attrs})
+}
+
+// RequestID returns the ID stored by WithRequest, or "".
+func RequestID(ctx context.Context) string {
+	f, _ := ctx.Value(ctxKey{}).(fields)
+	return f.requestID
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if f, ok := ctx.Value(ctxKey{}).(fields); ok {
+		r.AddAttrs(slog.String("request_id", f.requestID))
+		r.AddAttrs(f.attrs...)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewAddsContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(&buf, "svc", Config{Level: "info", Format: "json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}, TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = WithRequest(ctx, "req-1", slog.String("tenant_id", "tenant_1"))
+	l.DebugContext(ctx, "dropped below the level")
+	l.InfoContext(ctx, "kept")
+
+	if got := RequestID(ctx); got != "req-1" {
+		t.Fatalf("RequestID = %q, want req-1", got)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want only the info line: %s", len(lines), buf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"service":    "svc",
+		"request_id": "req-1",
+		"tenant_id":  "tenant_1",
+		"trace_id":   sc.TraceID().String(),
+		"span_id":    sc.SpanID().String(),
+	} {
+		if entry[key] != want {
+			t.Errorf("%s = %v, want %q", key, entry[key], want)
+		}
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "svc", Config{Level: "verbose", Format: "json"}); err == nil {
+		t.Error("unknown level accepted")
+	}
+
+	var buf bytes.Buffer
+	l, err := New(&buf, "svc", Config{Level: "debug", Format: "text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("hello")
+	if !strings.Contains(buf.String(), "msg=hello") {
+		t.Errorf("text output = %q", buf.String())
+	}
+	if RequestID(context.Background()) != "" {
+		t.Error("RequestID of a bare context should be empty")
+	}
+}
@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHeadersCarryTraceContext(t *testing.T) {
+	shutdown, err := Init(context.Background(), "test", Config{Exporter: "stdout", SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(context.Background())
+
+	ctx, span := Tracer().Start(context.Background(), "publish")
+	defer span.End()
+
+	headers := InjectHeaders(ctx)
+	if headers["traceparent"] == "" {
+		t.Fatalf("headers = %v, want a W3C traceparent", headers)
+	}
+
+	remote := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier(headers)))
+	if remote.TraceID() != span.SpanContext().TraceID() || !remote.IsRemote() {
+		t.Errorf("extracted %v, want the producer's trace %v", remote.TraceID(), span.SpanContext().TraceID())
+	}
+}
+
+func TestSkipProbes(t *testing.T) {
+	for path, traced := range map[string]bool{
+		"/health/ready":     false,
+		"/metrics":          false,
+		"/api/v1/ims/hub/1": true,
+	} {
+		if got := SkipProbes(httptest.NewRequest("GET", path, nil)); got != traced {
+			t.Errorf("SkipProbes(%s) = %v, want %v", path, got, traced)
+		}
+	}
+}